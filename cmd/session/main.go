@@ -0,0 +1,239 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+// Command session inspects, validates and converts assistant session strings.
+//
+//	go run ./cmd/session info [STRING]
+//	go run ./cmd/session check [-api-id ID] [-api-hash HASH] [STRING]
+//	go run ./cmd/session convert -to pyrogram|telethon|gogram [-user-id ID] [-app-id ID] [STRING]
+//
+// The session string is read from standard input when it is omitted or "-", which keeps it out of the shell history.
+// check logs in with the session to confirm it still works; it reads API_ID and API_HASH from the environment by default.
+package main
+
+import (
+	"ashokshau/tgmusic/src/vc/sessions"
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	tg "github.com/amarnathcjd/gogram/telegram"
+)
+
+const usage = `Usage:
+  session info [STRING]
+        Detect the format of a session string and show what it contains.
+  session check [-api-id ID] [-api-hash HASH] [STRING]
+        Log in with the session and show the account it belongs to.
+  session convert -to FORMAT [-user-id ID] [-app-id ID] [-api-id ID] [-api-hash HASH] [STRING]
+        Convert the session to pyrogram, telethon or gogram.
+
+STRING is read from standard input when it is omitted or "-".
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "info":
+		err = runInfo(os.Args[2:])
+	case "check":
+		err = runCheck(os.Args[2:])
+	case "convert":
+		err = runConvert(os.Args[2:])
+	case "-h", "-help", "--help", "help":
+		fmt.Print(usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+// apiFlags registers the API credential flags, defaulting to API_ID and API_HASH from the environment.
+func apiFlags(fs *flag.FlagSet) (apiID *int, apiHash *string) {
+	envID, _ := strconv.Atoi(os.Getenv("API_ID"))
+	apiID = fs.Int("api-id", envID, "Telegram API ID (default $API_ID)")
+	apiHash = fs.String("api-hash", os.Getenv("API_HASH"), "Telegram API hash (default $API_HASH)")
+	return apiID, apiHash
+}
+
+func runInfo(args []string) error {
+	fs := flag.NewFlagSet("info", flag.ExitOnError)
+	_ = fs.Parse(args)
+
+	info, err := readSession(fs)
+	if err != nil {
+		return err
+	}
+
+	printInfo(info)
+	return nil
+}
+
+func runCheck(args []string) error {
+	fs := flag.NewFlagSet("check", flag.ExitOnError)
+	apiID, apiHash := apiFlags(fs)
+	_ = fs.Parse(args)
+
+	info, err := readSession(fs)
+	if err != nil {
+		return err
+	}
+
+	printInfo(info)
+	me, err := login(info, int32(*apiID), *apiHash)
+	if err != nil {
+		return err
+	}
+
+	name := strings.TrimSpace(me.FirstName + " " + me.LastName)
+	fmt.Printf("\nThe session is valid.\n")
+	fmt.Printf("Account:   %s (%d)\n", name, me.ID)
+	if me.Username != "" {
+		fmt.Printf("Username:  @%s\n", me.Username)
+	}
+	if me.Bot {
+		fmt.Println("Warning:   this is a bot account and cannot be used as an assistant.")
+	}
+	return nil
+}
+
+func runConvert(args []string) error {
+	fs := flag.NewFlagSet("convert", flag.ExitOnError)
+	to := fs.String("to", "", "format to convert to: pyrogram, telethon or gogram")
+	userID := fs.Int64("user-id", 0, "user ID to store in a pyrogram session (looked up by logging in if not set)")
+	appID := fs.Int("app-id", 0, "app ID to store in the session (defaults to the session's own, then -api-id)")
+	apiID, apiHash := apiFlags(fs)
+	_ = fs.Parse(args)
+
+	format, err := sessions.ParseFormat(*to)
+	if err != nil {
+		return fmt.Errorf("-to: %w", err)
+	}
+
+	info, err := readSession(fs)
+	if err != nil {
+		return err
+	}
+
+	if *userID != 0 {
+		info.UserID = *userID
+	}
+	switch {
+	case *appID != 0:
+		info.AppID = int32(*appID)
+	case info.AppID == 0:
+		info.AppID = int32(*apiID)
+	}
+
+	if format == sessions.FormatPyrogram && info.UserID == 0 {
+		if *apiHash == "" {
+			return errors.New("a pyrogram session needs the user ID: pass -user-id, or set API_ID and API_HASH to look it up")
+		}
+		me, err := login(info, int32(*apiID), *apiHash)
+		if err != nil {
+			return err
+		}
+		info.UserID, info.IsBot = me.ID, me.Bot
+	}
+
+	encoded, err := info.Encode(format)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(encoded)
+	return nil
+}
+
+// readSession reads the session string from the first argument or standard input and decodes it.
+func readSession(fs *flag.FlagSet) (*sessions.Info, error) {
+	sessionString := fs.Arg(0)
+	if sessionString == "" || sessionString == "-" {
+		scanner := bufio.NewScanner(os.Stdin)
+		scanner.Buffer(make([]byte, 0, 4096), 64*1024)
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return nil, fmt.Errorf("failed to read the session string: %w", err)
+			}
+			return nil, errors.New("no session string given")
+		}
+		sessionString = scanner.Text()
+	}
+
+	return sessions.Parse(sessionString)
+}
+
+func printInfo(info *sessions.Info) {
+	fmt.Printf("Format:    %s\n", info.Format)
+	if info.DC > 0 {
+		fmt.Printf("DC:        %d\n", info.DC)
+	} else {
+		fmt.Println("DC:        unknown")
+	}
+	fmt.Printf("Address:   %s\n", info.Hostname)
+	if info.TestMode {
+		fmt.Println("Server:    test")
+	}
+	if info.AppID != 0 {
+		fmt.Printf("App ID:    %d\n", info.AppID)
+	}
+	if info.UserID != 0 {
+		fmt.Printf("User ID:   %d\n", info.UserID)
+	} else {
+		fmt.Printf("User ID:   not stored in %s sessions\n", info.Format)
+	}
+	if info.IsBot {
+		fmt.Println("Bot:       yes")
+	}
+}
+
+// login connects with the session and returns the account it belongs to.
+// It never prompts for a login, so an expired session fails instead.
+func login(info *sessions.Info, apiID int32, apiHash string) (*tg.UserObj, error) {
+	if apiID == 0 || apiHash == "" {
+		return nil, errors.New("API_ID and API_HASH are required to log in; set them or pass -api-id and -api-hash")
+	}
+
+	client, err := tg.NewClient(tg.ClientConfig{
+		AppID:         apiID,
+		AppHash:       apiHash,
+		MemorySession: true,
+		StringSession: info.Session().Encode(),
+		NoUpdates:     true,
+		LogLevel:      tg.LogDisable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the client: %w", err)
+	}
+	defer func() { _ = client.Stop() }()
+
+	if err = client.Connect(); err != nil {
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+
+	me, err := client.GetMe()
+	if err != nil {
+		return nil, fmt.Errorf("the session is not logged in: %w", err)
+	}
+	return me, nil
+}
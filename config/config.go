@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 )
@@ -31,48 +32,45 @@ func LoadConfig() error {
 		ApiHash:           os.Getenv("API_HASH"),
 		Token:             os.Getenv("TOKEN"),
 		SessionStrings:    getSessionStrings("STRING", 10),
-		SessionType:       getEnvStr("SESSION_TYPE", "pyrogram"),
+		DbBackend:         strings.ToLower(getEnvStr("DB_BACKEND", StorageMongo)),
 		MongoUri:          os.Getenv("MONGO_URI"),
 		DbName:            getEnvStr("DB_NAME", "Anon"),
-		ApiUrl:            getEnvStr("API_URL", "https://beta.fallenapi.fun"),
-		ApiKey:            os.Getenv("API_KEY"),
+		DbPath:            getEnvStr("DB_PATH", filepath.Join("data", "tgmusic.db")),
+		DbMigrateDryRun:   getEnvBool("DB_MIGRATE_DRY_RUN", false),
+		apiUrl:            getEnvStr("API_URL", "https://beta.fallenapi.fun"),
+		apiKey:            os.Getenv("API_KEY"),
 		OwnerId:           getEnvInt64("OWNER_ID"),
 		LoggerId:          getEnvInt64("LOGGER_ID"),
-		Proxy:             os.Getenv("PROXY"),
-		DefaultService:    strings.ToLower(getEnvStr("DEFAULT_SERVICE", "youtube")),
-		MaxFileSize:       getEnvInt64("MAX_FILE_SIZE"),
-		SongDurationLimit: getEnvInt64("SONG_DURATION_LIMIT"),
+		proxy:             os.Getenv("PROXY"),
+		defaultService:    strings.ToLower(getEnvStr("DEFAULT_SERVICE", "youtube")),
+		maxFileSize:       getEnvInt64("MAX_FILE_SIZE"),
+		songDurationLimit: getEnvInt64("SONG_DURATION_LIMIT"),
 		DownloadsDir:      getEnvStr("DOWNLOADS_DIR", "downloads"),
 		SupportGroup:      getEnvStr("SUPPORT_GROUP", "https://t.me/FallenSupport"),
 		SupportChannel:    getEnvStr("SUPPORT_CHANNEL", "https://t.me/FallenProjects"),
 		cookiesUrl:        processCookieURLs(os.Getenv("COOKIES_URL")),
 		StartImg:          getEnvStr("START_IMG", "https://i.pinimg.com/736x/0d/f4/65/0df465d1e98239ecb6283400605fc813.jpg"),
 		Port:              getEnvStr("PORT", "6060"),
-		AutoLeave:         getEnvBool("AUTO_LEAVE", false),
-	}
-
-	devsEnv := os.Getenv("DEVS")
-	if devsEnv != "" {
-		devsEnv = strings.ReplaceAll(devsEnv, "\n", " ")
-		devsEnv = strings.ReplaceAll(devsEnv, ",", " ")
-
-		for _, idStr := range strings.Fields(devsEnv) {
-			idStr = strings.TrimSpace(idStr)
-			if idStr == "" {
-				continue
-			}
-			if id, err := strconv.ParseInt(idStr, 10, 64); err == nil {
-				Conf.DEVS = append(Conf.DEVS, id)
-			} else {
-				slog.Info("Invalid DEV ID ''", "id", idStr, "error", err)
-			}
-		}
+		autoLeave:         getEnvBool("AUTO_LEAVE", false),
+		emptyCallTimeout:  getEnvInt64("EMPTY_CALL_TIMEOUT"),
+		P2PCallMode:       strings.ToLower(getEnvStr("P2P_CALL_MODE", P2PAllowlist)),
+		P2PAllowedUsers:   getEnvInt64List("P2P_ALLOWED_USERS"),
+		audioProfile:      strings.ToLower(getEnvStr("AUDIO_PROFILE", AudioStereo48)),
+		SessionKey:        os.Getenv("SESSION_KEY"),
+		assignStrategy:    strings.ToLower(getEnvStr("ASSIGN_STRATEGY", AssignLeastCalls)),
+		DEVS:              getEnvInt64List("DEVS"),
 	}
 
 	if Conf.OwnerId != 0 && !containsInt(Conf.DEVS, Conf.OwnerId) {
 		Conf.DEVS = append(Conf.DEVS, Conf.OwnerId)
 	}
 
+	weights, err := parseAssistantWeights(os.Getenv("ASSISTANT_WEIGHTS"))
+	if err != nil {
+		slog.Info("Invalid ASSISTANT_WEIGHTS, ignoring", "error", err)
+	}
+	Conf.assistantWeights = weights
+
 	if err := Conf.validate(); err != nil {
 		return err
 	}
@@ -85,12 +83,35 @@ func LoadConfig() error {
 		if err := os.MkdirAll(cookiesDr, 0750); err != nil {
 			return fmt.Errorf("failed to create temp dir: %w", err)
 		}
-		go saveAllCookies(Conf.cookiesUrl)
+		go Conf.refreshCookies(Conf.cookiesUrl)
 	}
 
+	Conf.snapshotEnvSettings()
+
 	return nil
 }
 
+// getEnvInt64List parses a space, comma or newline separated list of IDs from an environment variable.
+func getEnvInt64List(key string) []int64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+
+	value = strings.ReplaceAll(value, "\n", " ")
+	value = strings.ReplaceAll(value, ",", " ")
+
+	var ids []int64
+	for _, idStr := range strings.Fields(value) {
+		if id, err := strconv.ParseInt(idStr, 10, 64); err == nil {
+			ids = append(ids, id)
+		} else {
+			slog.Info("Invalid ID", "key", key, "id", idStr, "error", err)
+		}
+	}
+	return ids
+}
+
 // getEnvBool gets environment variable as bool with default value
 func getEnvBool(key string, defaultValue bool) bool {
 	value := os.Getenv(key)
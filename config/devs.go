@@ -0,0 +1,63 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package config
+
+import (
+	"slices"
+	"sync"
+)
+
+// sudoMu guards BotConfig.sudoers, which changes at runtime through /addsudo and /rmsudo.
+var sudoMu sync.RWMutex
+
+// IsDev reports whether the user is the owner, a developer from DEVS or a runtime sudo user.
+func (c *BotConfig) IsDev(userID int64) bool {
+	if containsInt(c.DEVS, userID) {
+		return true
+	}
+
+	sudoMu.RLock()
+	defer sudoMu.RUnlock()
+	return containsInt(c.sudoers, userID)
+}
+
+// IsEnvDev reports whether the user is the owner or listed in DEVS. These cannot be removed at runtime.
+func (c *BotConfig) IsEnvDev(userID int64) bool {
+	return containsInt(c.DEVS, userID)
+}
+
+// Sudoers returns a copy of the developer IDs added at runtime.
+func (c *BotConfig) Sudoers() []int64 {
+	sudoMu.RLock()
+	defer sudoMu.RUnlock()
+	return slices.Clone(c.sudoers)
+}
+
+// SetSudoers replaces the runtime developer IDs, typically with the list stored in the database.
+func (c *BotConfig) SetSudoers(ids []int64) {
+	sudoMu.Lock()
+	defer sudoMu.Unlock()
+	c.sudoers = slices.Clone(ids)
+}
+
+// AddSudo grants developer access to a user until it is removed.
+func (c *BotConfig) AddSudo(userID int64) {
+	sudoMu.Lock()
+	defer sudoMu.Unlock()
+	if !containsInt(c.sudoers, userID) {
+		c.sudoers = append(c.sudoers, userID)
+	}
+}
+
+// RemoveSudo revokes developer access granted with AddSudo.
+func (c *BotConfig) RemoveSudo(userID int64) {
+	sudoMu.Lock()
+	defer sudoMu.Unlock()
+	c.sudoers = slices.DeleteFunc(c.sudoers, func(id int64) bool { return id == userID })
+}
@@ -15,11 +15,16 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 )
 
 const cookiesDr = "src/cookies"
 
+// cookiesMu serializes cookie refreshes so an older one cannot overwrite the files or paths of a newer one.
+var cookiesMu sync.Mutex
+
 // fetchContent downloads content from Pastebin or Batbin.
 func fetchContent(url string) (string, error) {
 	parts := strings.Split(strings.Trim(url, "/"), "/")
@@ -54,7 +59,8 @@ func fetchContent(url string) (string, error) {
 	return string(body), nil
 }
 
-// saveContent saves content to a file in /tmp and returns the file path.
+// saveContent saves content to a file in the cookies directory and returns the file path.
+// It writes a temporary file and renames it, so readers never see a partly written file.
 func saveContent(url, content string) (string, error) {
 	parts := strings.Split(strings.Trim(url, "/"), "/")
 	filename := parts[len(parts)-1]
@@ -65,24 +71,32 @@ func saveContent(url, content string) (string, error) {
 
 	filePath := filepath.Join(cookiesDr, filename)
 
-	f, err := os.Create(filePath)
+	f, err := os.CreateTemp(cookiesDr, filename+".*.tmp")
 	if err != nil {
 		return "", fmt.Errorf("failed to create file %s: %w", filePath, err)
 	}
-	defer func(f *os.File) {
-		_ = f.Close()
-	}(f)
+	defer func() {
+		_ = os.Remove(f.Name())
+	}()
 
 	if _, err := f.WriteString(content); err != nil {
+		_ = f.Close()
+		return "", fmt.Errorf("failed to write file %s: %w", filePath, err)
+	}
+	if err := f.Close(); err != nil {
 		return "", fmt.Errorf("failed to write file %s: %w", filePath, err)
 	}
 
+	if err := os.Rename(f.Name(), filePath); err != nil {
+		return "", fmt.Errorf("failed to replace file %s: %w", filePath, err)
+	}
 	return filePath, nil
 }
 
-// saveAllCookies downloads all URLs and stores paths in Conf.CookiesPath.
+// saveAllCookies downloads all URLs and returns the paths of the saved files.
 // It takes a slice of URLs as input.
-func saveAllCookies(urls []string) {
+func saveAllCookies(urls []string) []string {
+	var paths []string
 	for _, url := range urls {
 		content, err := fetchContent(url)
 		if err != nil {
@@ -96,6 +110,34 @@ func saveAllCookies(urls []string) {
 			continue
 		}
 
-		Conf.CookiesPath = append(Conf.CookiesPath, path)
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// refreshCookies downloads the given cookie URLs and replaces the cookies paths once they are saved.
+// Refreshes run one at a time, and one whose URLs were replaced while it waited is skipped.
+func (c *BotConfig) refreshCookies(urls []string) {
+	cookiesMu.Lock()
+	defer cookiesMu.Unlock()
+
+	settingsMu.RLock()
+	current := slices.Equal(urls, c.cookiesUrl)
+	settingsMu.RUnlock()
+	if !current {
+		return
 	}
+
+	if len(urls) > 0 {
+		if err := os.MkdirAll(cookiesDr, 0750); err != nil {
+			slog.Info("Failed to create the cookies directory", "error", err)
+			return
+		}
+	}
+
+	paths := saveAllCookies(urls)
+
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+	c.cookiesPath = paths
 }
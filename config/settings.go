@@ -0,0 +1,375 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// settingsMu guards the BotConfig fields that can be changed at runtime with /config.
+var settingsMu sync.RWMutex
+
+// Setting is a configuration value that can be changed at runtime.
+// Values are stored as strings, using the same format as the environment variable of the same name.
+type Setting struct {
+	Key    string // Key is the environment variable name, e.g. SONG_DURATION_LIMIT.
+	Help   string // Help is a short description shown in /config list.
+	Secret bool   // Secret values are redacted whenever they are displayed.
+
+	get func(c *BotConfig) string
+	set func(c *BotConfig, value string) error
+}
+
+// settings lists every runtime setting. get and set are called with settingsMu held.
+var settings = []Setting{
+	{
+		Key:  "SONG_DURATION_LIMIT",
+		Help: "Maximum track duration in seconds",
+		get:  func(c *BotConfig) string { return strconv.FormatInt(c.songDurationLimit, 10) },
+		set: func(c *BotConfig, value string) error {
+			n, err := parsePositive(value)
+			if err != nil {
+				return err
+			}
+			c.songDurationLimit = n
+			return nil
+		},
+	},
+	{
+		Key:  "MAX_FILE_SIZE",
+		Help: "Maximum Telegram file size in bytes",
+		get:  func(c *BotConfig) string { return strconv.FormatInt(c.maxFileSize, 10) },
+		set: func(c *BotConfig, value string) error {
+			n, err := parsePositive(value)
+			if err != nil {
+				return err
+			}
+			c.maxFileSize = n
+			return nil
+		},
+	},
+	{
+		Key:  "DEFAULT_SERVICE",
+		Help: "Search platform for plain queries (youtube/spotify)",
+		get:  func(c *BotConfig) string { return c.defaultService },
+		set: func(c *BotConfig, value string) error {
+			value = strings.ToLower(value)
+			if !isValidService(value) {
+				return fmt.Errorf("unsupported service %q", value)
+			}
+			c.defaultService = value
+			return nil
+		},
+	},
+	{
+		Key:    "PROXY",
+		Help:   "Proxy URL used by yt-dlp, empty to disable",
+		Secret: true,
+		get:    func(c *BotConfig) string { return c.proxy },
+		set: func(c *BotConfig, value string) error {
+			c.proxy = value
+			return nil
+		},
+	},
+	{
+		Key:    "COOKIES_URL",
+		Help:   "Comma separated Batbin/Pastebin links with YouTube cookies",
+		Secret: true,
+		get:    func(c *BotConfig) string { return strings.Join(c.cookiesUrl, ",") },
+		set: func(c *BotConfig, value string) error {
+			c.cookiesUrl = processCookieURLs(value)
+			urls := slices.Clone(c.cookiesUrl)
+			go c.refreshCookies(urls)
+			return nil
+		},
+	},
+	{
+		Key:  "AUTO_LEAVE",
+		Help: "Let assistants leave inactive chats periodically (true/false)",
+		get:  func(c *BotConfig) string { return strconv.FormatBool(c.autoLeave) },
+		set: func(c *BotConfig, value string) error {
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("expected true or false, got %q", value)
+			}
+			c.autoLeave = b
+			return nil
+		},
+	},
+	{
+		Key:  "EMPTY_CALL_TIMEOUT",
+		Help: "Seconds to wait in an empty voice chat before leaving",
+		get:  func(c *BotConfig) string { return strconv.FormatInt(c.emptyCallTimeout, 10) },
+		set: func(c *BotConfig, value string) error {
+			n, err := parsePositive(value)
+			if err != nil {
+				return err
+			}
+			c.emptyCallTimeout = n
+			return nil
+		},
+	},
+	{
+		Key:  "AUDIO_PROFILE",
+		Help: "Default audio profile (stereo48/mono48/mono24)",
+		get:  func(c *BotConfig) string { return c.audioProfile },
+		set: func(c *BotConfig, value string) error {
+			value = strings.ToLower(value)
+			switch value {
+			case AudioStereo48, AudioMono48, AudioMono24:
+				c.audioProfile = value
+				return nil
+			default:
+				return fmt.Errorf("unknown audio profile %q", value)
+			}
+		},
+	},
+	{
+		Key:  "ASSIGN_STRATEGY",
+		Help: "How chats get an assistant (least_calls/fewest_chats/weighted/random)",
+		get:  func(c *BotConfig) string { return c.assignStrategy },
+		set: func(c *BotConfig, value string) error {
+			value = strings.ToLower(value)
+			switch value {
+			case AssignLeastCalls, AssignFewestChats, AssignWeighted, AssignRandom:
+				c.assignStrategy = value
+				return nil
+			default:
+				return fmt.Errorf("unknown assignment strategy %q", value)
+			}
+		},
+	},
+	{
+		Key:  "ASSISTANT_WEIGHTS",
+		Help: "Assistant capacity for the weighted strategy, as ID:weight pairs",
+		get: func(c *BotConfig) string {
+			ids := slices.Sorted(maps.Keys(c.assistantWeights))
+			pairs := make([]string, 0, len(ids))
+			for _, id := range ids {
+				pairs = append(pairs, fmt.Sprintf("%d:%d", id, c.assistantWeights[id]))
+			}
+			return strings.Join(pairs, ",")
+		},
+		set: func(c *BotConfig, value string) error {
+			weights, err := parseAssistantWeights(value)
+			if err != nil {
+				return err
+			}
+			c.assistantWeights = weights
+			return nil
+		},
+	},
+	{
+		Key:  "API_URL",
+		Help: "Base URL of the download API, empty to disable",
+		get:  func(c *BotConfig) string { return c.apiUrl },
+		set: func(c *BotConfig, value string) error {
+			if value != "" && !strings.HasPrefix(value, "http://") && !strings.HasPrefix(value, "https://") {
+				return fmt.Errorf("expected an http(s) URL, got %q", value)
+			}
+			c.apiUrl = value
+			return nil
+		},
+	},
+	{
+		Key:    "API_KEY",
+		Help:   "Key for the download API",
+		Secret: true,
+		get:    func(c *BotConfig) string { return c.apiKey },
+		set: func(c *BotConfig, value string) error {
+			c.apiKey = value
+			return nil
+		},
+	},
+}
+
+// envSettings keeps the values loaded from the environment so a runtime override can be reset.
+var envSettings = map[string]string{}
+
+// Settings returns every runtime setting.
+func Settings() []Setting {
+	return slices.Clone(settings)
+}
+
+// LookupSetting finds a runtime setting by its key, ignoring case.
+func LookupSetting(key string) (Setting, bool) {
+	key = strings.ToUpper(key)
+	for _, s := range settings {
+		if s.Key == key {
+			return s, true
+		}
+	}
+	return Setting{}, false
+}
+
+// Get returns the current value of a runtime setting.
+func (c *BotConfig) Get(key string) (string, error) {
+	s, ok := LookupSetting(key)
+	if !ok {
+		return "", fmt.Errorf("unknown setting %q", key)
+	}
+
+	settingsMu.RLock()
+	defer settingsMu.RUnlock()
+	return s.get(c), nil
+}
+
+// Display returns the current value of a runtime setting, redacted when it is a secret.
+func (c *BotConfig) Display(key string) (string, error) {
+	value, err := c.Get(key)
+	if err != nil {
+		return "", err
+	}
+
+	if s, _ := LookupSetting(key); s.Secret {
+		return Redact(value), nil
+	}
+	return value, nil
+}
+
+// Set validates and applies a runtime setting. The current value is kept when the new one is invalid.
+func (c *BotConfig) Set(key, value string) error {
+	s, ok := LookupSetting(key)
+	if !ok {
+		return fmt.Errorf("unknown setting %q", key)
+	}
+
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+	return s.set(c, strings.TrimSpace(value))
+}
+
+// Reset restores a runtime setting to the value loaded from the environment.
+func (c *BotConfig) Reset(key string) error {
+	s, ok := LookupSetting(key)
+	if !ok {
+		return fmt.Errorf("unknown setting %q", key)
+	}
+	return c.Set(s.Key, envSettings[s.Key])
+}
+
+// snapshotEnvSettings records the validated environment values for Reset.
+func (c *BotConfig) snapshotEnvSettings() {
+	for _, s := range settings {
+		envSettings[s.Key] = s.get(c)
+	}
+}
+
+// Redact hides all but the last few characters of a secret value.
+func Redact(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	r := []rune(value)
+	if len(r) <= 8 {
+		return strings.Repeat("•", len(r))
+	}
+	return strings.Repeat("•", 8) + string(r[len(r)-4:])
+}
+
+func parsePositive(value string) (int64, error) {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("expected a positive number, got %q", value)
+	}
+	return n, nil
+}
+
+// ApiUrl returns the base URL of the download API.
+func (c *BotConfig) ApiUrl() string {
+	settingsMu.RLock()
+	defer settingsMu.RUnlock()
+	return c.apiUrl
+}
+
+// ApiKey returns the key for the download API.
+func (c *BotConfig) ApiKey() string {
+	settingsMu.RLock()
+	defer settingsMu.RUnlock()
+	return c.apiKey
+}
+
+// Proxy returns the proxy URL used by yt-dlp.
+func (c *BotConfig) Proxy() string {
+	settingsMu.RLock()
+	defer settingsMu.RUnlock()
+	return c.proxy
+}
+
+// DefaultService returns the default search platform.
+func (c *BotConfig) DefaultService() string {
+	settingsMu.RLock()
+	defer settingsMu.RUnlock()
+	return c.defaultService
+}
+
+// MaxFileSize returns the maximum file size for downloads, in bytes.
+func (c *BotConfig) MaxFileSize() int64 {
+	settingsMu.RLock()
+	defer settingsMu.RUnlock()
+	return c.maxFileSize
+}
+
+// SongDurationLimit returns the maximum duration of a song, in seconds.
+func (c *BotConfig) SongDurationLimit() int64 {
+	settingsMu.RLock()
+	defer settingsMu.RUnlock()
+	return c.songDurationLimit
+}
+
+// CookiesPath returns the paths of the downloaded cookies files.
+func (c *BotConfig) CookiesPath() []string {
+	settingsMu.RLock()
+	defer settingsMu.RUnlock()
+	return slices.Clone(c.cookiesPath)
+}
+
+// AutoLeave reports whether assistants should periodically leave inactive chats.
+func (c *BotConfig) AutoLeave() bool {
+	settingsMu.RLock()
+	defer settingsMu.RUnlock()
+	return c.autoLeave
+}
+
+// EmptyCallTimeout returns how long, in seconds, playback stays paused in an empty voice chat before the bot leaves.
+func (c *BotConfig) EmptyCallTimeout() int64 {
+	settingsMu.RLock()
+	defer settingsMu.RUnlock()
+	return c.emptyCallTimeout
+}
+
+// AudioProfile returns the default audio profile for chats that have not picked one.
+func (c *BotConfig) AudioProfile() string {
+	settingsMu.RLock()
+	defer settingsMu.RUnlock()
+	return c.audioProfile
+}
+
+// AssignStrategy returns how chats without an assistant get one.
+func (c *BotConfig) AssignStrategy() string {
+	settingsMu.RLock()
+	defer settingsMu.RUnlock()
+	return c.assignStrategy
+}
+
+// AssistantWeight returns the relative capacity of an assistant for the weighted strategy. It defaults to 1.
+func (c *BotConfig) AssistantWeight(assistantID int64) int {
+	settingsMu.RLock()
+	defer settingsMu.RUnlock()
+	if weight, ok := c.assistantWeights[assistantID]; ok {
+		return weight
+	}
+	return 1
+}
@@ -21,29 +21,67 @@ type BotConfig struct {
 	ApiId             int32    // ApiId is the Telegram API ID.
 	ApiHash           string   // ApiHash is the Telegram API hash.
 	Token             string   // Token is the bot token.
-	SessionStrings    []string // SessionStrings is a list of pyrogram/telethon/gogram session strings.
-	SessionType       string   // SessionType is the type of session (pyrogram/telethon/gogram).
+	SessionStrings    []string // SessionStrings is a list of pyrogram/telethon/gogram session strings; the format is detected per string.
+	DbBackend         string   // DbBackend is the storage backend (mongo/bolt).
 	MongoUri          string   // MongoUri is the MongoDB connection string.
 	DbName            string   // DbName is the name of the database.
-	ApiUrl            string   // ApiUrl is the URL of the API.
-	ApiKey            string   // ApiKey is the API key.
+	DbPath            string   // DbPath is the database file of the bolt backend.
+	DbMigrateDryRun   bool     // DbMigrateDryRun logs pending MongoDB migrations instead of applying them.
+	apiUrl            string   // apiUrl is the URL of the API.
+	apiKey            string   // apiKey is the API key.
 	OwnerId           int64    // OwnerId is the user ID of the bot owner.
 	LoggerId          int64    // LoggerId is the group ID of the bot logger.
-	Proxy             string   // Proxy is the proxy URL for the bot.
-	DefaultService    string   // DefaultService is the default search platform.
-	MaxFileSize       int64    // MaxFileSize is the maximum file size for downloads.
-	SongDurationLimit int64    // SongDurationLimit is the maximum duration of a song in seconds.
+	proxy             string   // proxy is the proxy URL for the bot.
+	defaultService    string   // defaultService is the default search platform.
+	maxFileSize       int64    // maxFileSize is the maximum file size for downloads.
+	songDurationLimit int64    // songDurationLimit is the maximum duration of a song in seconds.
 	DownloadsDir      string   // DownloadsDir is the directory where downloads are stored.
 	SupportGroup      string   // SupportGroup is the Telegram group link.
 	SupportChannel    string   // SupportChannel is the Telegram channel link.
 	DEVS              []int64  // DEVS is a list of developer user IDs.
-	CookiesPath       []string // CookiesPath is a list of paths to cookies files.
+	sudoers           []int64  // sudoers is a list of developer user IDs added at runtime; see IsDev.
+	cookiesPath       []string // cookiesPath is a list of paths to cookies files.
 	cookiesUrl        []string // cookiesUrl is a list of URLs to cookies files.
 	StartImg          string   // StartImg is the URL or path to the start image.
 	Port              string
-	AutoLeave         bool // AutoLeave is a boolean setting to automatically leave inactive chats.
+	autoLeave         bool          // autoLeave is a boolean setting to automatically leave inactive chats.
+	P2PCallMode       string        // P2PCallMode decides how assistants handle private calls (reject/allowlist/answer, default allowlist).
+	P2PAllowedUsers   []int64       // P2PAllowedUsers is a list of user IDs allowed to call assistants in allowlist mode.
+	emptyCallTimeout  int64         // emptyCallTimeout is how long, in seconds, playback stays paused in an empty voice chat before the bot leaves.
+	audioProfile      string        // audioProfile is the default audio profile for chats that have not picked one (stereo48/mono48/mono24).
+	SessionKey        string        // SessionKey encrypts assistant session strings stored in the database. Defaults to the bot token.
+	assignStrategy    string        // assignStrategy decides how chats without an assistant get one (least_calls/fewest_chats/weighted/random).
+	assistantWeights  map[int64]int // assistantWeights holds the relative capacity of assistants for the weighted strategy.
 }
 
+// Private call modes for P2PCallMode.
+const (
+	P2PReject    = "reject"
+	P2PAllowlist = "allowlist"
+	P2PAnswer    = "answer"
+)
+
+// Assignment strategies for AssignStrategy.
+const (
+	AssignLeastCalls  = "least_calls"
+	AssignFewestChats = "fewest_chats"
+	AssignWeighted    = "weighted"
+	AssignRandom      = "random"
+)
+
+// Storage backends for DbBackend.
+const (
+	StorageMongo = "mongo"
+	StorageBolt  = "bolt"
+)
+
+// Audio profiles for AudioProfile.
+const (
+	AudioStereo48 = "stereo48"
+	AudioMono48   = "mono48"
+	AudioMono24   = "mono24"
+)
+
 // getSessionStrings gets session strings from environment variable with prefix
 func getSessionStrings(prefix string, max int) []string {
 	var sessions []string
@@ -130,7 +168,7 @@ func (c *BotConfig) validate() error {
 		{"API_ID", fmt.Sprintf("%d", c.ApiId), func() bool { return c.ApiId > 0 }},
 		{"API_HASH", c.ApiHash, func() bool { return c.ApiHash != "" }},
 		{"TOKEN", c.Token, func() bool { return c.Token != "" }},
-		{"MONGO_URI", c.MongoUri, func() bool { return c.MongoUri != "" }},
+		{"MONGO_URI", c.MongoUri, func() bool { return c.DbBackend != StorageMongo || c.MongoUri != "" }},
 		{"OWNER_ID", fmt.Sprintf("%d", c.OwnerId), func() bool { return c.OwnerId > 0 }},
 	}
 
@@ -145,26 +183,84 @@ func (c *BotConfig) validate() error {
 		return fmt.Errorf("missing required configuration: %s", strings.Join(missing, ", "))
 	}
 
+	switch c.DbBackend {
+	case StorageMongo, StorageBolt:
+	default:
+		return fmt.Errorf("invalid DB_BACKEND %q: use %q or %q", c.DbBackend, StorageMongo, StorageBolt)
+	}
+
 	if len(c.SessionStrings) == 0 {
 		return fmt.Errorf("at least one session string (STRING1–10) is required")
 	}
 
-	if c.MaxFileSize <= 0 {
-		c.MaxFileSize = 500 * 1024 * 1024 // 500MB default
+	if c.maxFileSize <= 0 {
+		c.maxFileSize = 500 * 1024 * 1024 // 500MB default
+	}
+
+	if c.songDurationLimit <= 0 {
+		c.songDurationLimit = 3600 // 1 hour default
+	}
+
+	if c.emptyCallTimeout <= 0 {
+		c.emptyCallTimeout = 300 // 5 minutes default
+	}
+
+	if !isValidService(c.defaultService) {
+		c.defaultService = "youtube"
+		slog.Info("Invalid DEFAULT_SERVICE, defaulting to 'youtube'", "Service", c.defaultService)
 	}
 
-	if c.SongDurationLimit <= 0 {
-		c.SongDurationLimit = 3600 // 1 hour default
+	switch c.P2PCallMode {
+	case P2PReject, P2PAllowlist, P2PAnswer:
+	default:
+		slog.Info("Invalid P2P_CALL_MODE, defaulting to 'allowlist'", "Mode", c.P2PCallMode)
+		c.P2PCallMode = P2PAllowlist
 	}
 
-	if !isValidService(c.DefaultService) {
-		c.DefaultService = "youtube"
-		slog.Info("Invalid DEFAULT_SERVICE, defaulting to 'youtube'", "Service", c.DefaultService)
+	switch c.assignStrategy {
+	case AssignLeastCalls, AssignFewestChats, AssignWeighted, AssignRandom:
+	default:
+		slog.Info("Invalid ASSIGN_STRATEGY, defaulting to 'least_calls'", "Strategy", c.assignStrategy)
+		c.assignStrategy = AssignLeastCalls
+	}
+
+	switch c.audioProfile {
+	case AudioStereo48, AudioMono48, AudioMono24:
+	default:
+		slog.Info("Invalid AUDIO_PROFILE, defaulting to 'stereo48'", "Profile", c.audioProfile)
+		c.audioProfile = AudioStereo48
+	}
+
+	if c.SessionKey == "" {
+		c.SessionKey = c.Token
 	}
 
 	return nil
 }
 
+// parseAssistantWeights parses "assistantID:weight" pairs separated by spaces or commas.
+func parseAssistantWeights(value string) (map[int64]int, error) {
+	weights := make(map[int64]int)
+	for _, pair := range strings.Fields(strings.ReplaceAll(value, ",", " ")) {
+		idStr, weightStr, ok := strings.Cut(pair, ":")
+		if !ok {
+			return nil, fmt.Errorf("expected assistantID:weight, got %q", pair)
+		}
+
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid assistant ID %q", idStr)
+		}
+
+		weight, err := strconv.Atoi(weightStr)
+		if err != nil || weight <= 0 {
+			return nil, fmt.Errorf("invalid weight %q for assistant %d", weightStr, id)
+		}
+		weights[id] = weight
+	}
+	return weights, nil
+}
+
 // isValidService checks if the service is valid
 func isValidService(service string) bool {
 	validServices := map[string]bool{
@@ -173,3 +269,15 @@ func isValidService(service string) bool {
 	}
 	return validServices[strings.ToLower(service)]
 }
+
+// IsP2PAllowed reports whether the given user may talk to an assistant in a private call.
+func (c *BotConfig) IsP2PAllowed(userID int64) bool {
+	switch c.P2PCallMode {
+	case P2PAnswer:
+		return true
+	case P2PAllowlist:
+		return containsInt(c.P2PAllowedUsers, userID) || c.IsDev(userID)
+	default:
+		return false
+	}
+}
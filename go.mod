@@ -6,7 +6,9 @@ require (
 	github.com/AshokShau/gotdbot v0.9.2
 	github.com/amarnathcjd/gogram v1.7.3
 	github.com/shirou/gopsutil/v3 v3.24.5
+	go.etcd.io/bbolt v1.4.3
 	go.mongodb.org/mongo-driver/v2 v2.6.0
+	golang.org/x/image v0.25.0
 )
 
 require (
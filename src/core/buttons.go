@@ -10,6 +10,7 @@ package core
 
 import (
 	"ashokshau/tgmusic/config"
+	"ashokshau/tgmusic/src/core/lang"
 	"ashokshau/tgmusic/src/utils"
 	"fmt"
 
@@ -68,50 +69,94 @@ func SupportBtn() *gotdbot.ReplyMarkupInlineKeyboard {
 	}
 }
 
-func SettingsKeyboard(playMode, adminMode string, cmdDelete bool, language string) *gotdbot.ReplyMarkupInlineKeyboard {
-	playText := "Everyone"
+// RestoreKeyboard asks how to apply the backup previewed under token.
+func RestoreKeyboard(token string) *gotdbot.ReplyMarkupInlineKeyboard {
+	return &gotdbot.ReplyMarkupInlineKeyboard{
+		Rows: [][]gotdbot.InlineKeyboardButton{
+			{cb("Merge", "restore_merge_"+token), cb("Replace", "restore_replace_"+token)},
+			{cb("Cancel", "restore_cancel_"+token)},
+		},
+	}
+}
+
+func SettingsKeyboard(playMode, adminMode string, cmdDelete bool, language string, quality utils.VideoQuality, audio utils.AudioProfile) *gotdbot.ReplyMarkupInlineKeyboard {
+	playText := lang.Tr(language, "settings.everyone")
 	if playMode == utils.Admins {
-		playText = "Admins"
+		playText = lang.Tr(language, "settings.admins")
 	}
 
-	deleteText := "False"
+	deleteText := lang.Tr(language, "settings.off")
 	if cmdDelete {
-		deleteText = "True"
+		deleteText = lang.Tr(language, "settings.on")
 	}
 
-	adminText := "Everyone"
+	adminText := lang.Tr(language, "settings.everyone")
 	if adminMode == utils.Admins {
-		adminText = "Admins"
+		adminText = lang.Tr(language, "settings.admins")
 	}
 
-	langText := "English"
-	if language != "en" && language != "" {
-		langText = language
-	}
+	langText := lang.Name(language)
 
 	return &gotdbot.ReplyMarkupInlineKeyboard{
 		Rows: [][]gotdbot.InlineKeyboardButton{
 			{
-				cb("Play Mode ➜", "settings_main"),
+				cb(lang.Tr(language, "settings.play_mode"), "settings_main"),
 				cb(playText, "settings_play"),
 			},
 			{
-				cb("Command Delete ➜", "settings_main"),
+				cb(lang.Tr(language, "settings.cmd_delete"), "settings_main"),
 				cb(deleteText, "settings_delete"),
 			},
 			{
-				cb("Admin Mode ➜", "settings_main"),
+				cb(lang.Tr(language, "settings.admin_mode"), "settings_main"),
 				cb(adminText, "settings_admin"),
 			},
 			{
-				cb("Language ➜", "settings_main"),
+				cb(lang.Tr(language, "settings.language"), "settings_main"),
 				cb(langText, "settings_lang"),
 			},
+			{
+				cb(lang.Tr(language, "settings.video_quality"), "settings_main"),
+				cb(fmt.Sprintf("%dp", quality.Height), "settings_vres"),
+				cb(fmt.Sprintf("%d fps", quality.Fps), "settings_vfps"),
+			},
+			{
+				cb(lang.Tr(language, "settings.audio_quality"), "settings_main"),
+				cb(audio.String(), "settings_audio"),
+			},
 			{CloseBtn},
 		},
 	}
 }
 
+// LanguageKeyboard lists the supported languages, marking the current one.
+// Buttons carry the callback data prefix followed by the language code; back adds a return button when set.
+func LanguageKeyboard(prefix, current, back string) *gotdbot.ReplyMarkupInlineKeyboard {
+	var rows [][]gotdbot.InlineKeyboardButton
+	var row []gotdbot.InlineKeyboardButton
+	for _, l := range lang.Languages() {
+		text := l.Name
+		if l.Code == current {
+			text = "✅ " + text
+		}
+
+		row = append(row, cb(text, prefix+l.Code))
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	if back != "" {
+		rows = append(rows, []gotdbot.InlineKeyboardButton{cb(lang.Tr(current, "common.back"), back)})
+	}
+	rows = append(rows, []gotdbot.InlineKeyboardButton{CloseBtn})
+	return &gotdbot.ReplyMarkupInlineKeyboard{Rows: rows}
+}
+
 func HelpMenuKeyboard() *gotdbot.ReplyMarkupInlineKeyboard {
 
 	return &gotdbot.ReplyMarkupInlineKeyboard{
@@ -142,10 +187,20 @@ func ControlButtons(mode string) *gotdbot.ReplyMarkupInlineKeyboard {
 	muteBtn := cb("🔇", "play_mute")
 	unmuteBtn := cb("🔊", "play_unmute")
 	addToPlaylistBtn := cb("➕", "play_add_to_list")
+	likeBtn := cb("❤️", "np_like")
 
 	switch mode {
 
 	case "play":
+		return &gotdbot.ReplyMarkupInlineKeyboard{
+			Rows: [][]gotdbot.InlineKeyboardButton{
+				{skipBtn, stopBtn, pauseBtn},
+				{likeBtn, addToPlaylistBtn, CloseBtn},
+			},
+		}
+
+	// A batch of queued songs has no single song to like.
+	case "queue":
 		return &gotdbot.ReplyMarkupInlineKeyboard{
 			Rows: [][]gotdbot.InlineKeyboardButton{
 				{skipBtn, stopBtn, pauseBtn},
@@ -157,7 +212,7 @@ func ControlButtons(mode string) *gotdbot.ReplyMarkupInlineKeyboard {
 		return &gotdbot.ReplyMarkupInlineKeyboard{
 			Rows: [][]gotdbot.InlineKeyboardButton{
 				{skipBtn, stopBtn, resumeBtn},
-				{CloseBtn},
+				{likeBtn, CloseBtn},
 			},
 		}
 
@@ -165,7 +220,7 @@ func ControlButtons(mode string) *gotdbot.ReplyMarkupInlineKeyboard {
 		return &gotdbot.ReplyMarkupInlineKeyboard{
 			Rows: [][]gotdbot.InlineKeyboardButton{
 				{skipBtn, stopBtn, pauseBtn},
-				{CloseBtn},
+				{likeBtn, CloseBtn},
 			},
 		}
 
@@ -173,7 +228,7 @@ func ControlButtons(mode string) *gotdbot.ReplyMarkupInlineKeyboard {
 		return &gotdbot.ReplyMarkupInlineKeyboard{
 			Rows: [][]gotdbot.InlineKeyboardButton{
 				{skipBtn, stopBtn, unmuteBtn},
-				{CloseBtn},
+				{likeBtn, CloseBtn},
 			},
 		}
 
@@ -181,7 +236,7 @@ func ControlButtons(mode string) *gotdbot.ReplyMarkupInlineKeyboard {
 		return &gotdbot.ReplyMarkupInlineKeyboard{
 			Rows: [][]gotdbot.InlineKeyboardButton{
 				{skipBtn, stopBtn, muteBtn},
-				{CloseBtn},
+				{likeBtn, CloseBtn},
 			},
 		}
 
@@ -194,6 +249,109 @@ func ControlButtons(mode string) *gotdbot.ReplyMarkupInlineKeyboard {
 	}
 }
 
+// PaginationKeyboard builds a navigation row for paginated lists.
+// Buttons carry the callback data prefix followed by the zero-based page index; the middle button refreshes the current page.
+func PaginationKeyboard(prefix string, page, totalPages int) *gotdbot.ReplyMarkupInlineKeyboard {
+	return &gotdbot.ReplyMarkupInlineKeyboard{
+		Rows: [][]gotdbot.InlineKeyboardButton{
+			paginationRow(prefix, page, totalPages),
+			{CloseBtn},
+		},
+	}
+}
+
+// paginationRow builds the navigation buttons; their data is prefix followed by the page index.
+func paginationRow(prefix string, page, totalPages int) []gotdbot.InlineKeyboardButton {
+	var nav []gotdbot.InlineKeyboardButton
+	if page > 0 {
+		nav = append(nav, cb("◀", fmt.Sprintf("%s%d", prefix, page-1)))
+	}
+
+	nav = append(nav, cb(fmt.Sprintf("%d/%d", page+1, max(totalPages, 1)), fmt.Sprintf("%s%d", prefix, page)))
+	if page+1 < totalPages {
+		nav = append(nav, cb("▶", fmt.Sprintf("%s%d", prefix, page+1)))
+	}
+	return nav
+}
+
+// PlaylistButton is a playlist shown as a button.
+type PlaylistButton struct {
+	ID   string
+	Name string
+}
+
+// PlaylistsKeyboard lists a user's playlists, each opening its songs, with page navigation.
+func PlaylistsKeyboard(userID int64, playlists []PlaylistButton, page, totalPages int) *gotdbot.ReplyMarkupInlineKeyboard {
+	var rows [][]gotdbot.InlineKeyboardButton
+	for _, p := range playlists {
+		rows = append(rows, []gotdbot.InlineKeyboardButton{cb("🎵 "+p.Name, fmt.Sprintf("plist_view_%s_0", p.ID))})
+	}
+
+	if totalPages > 1 {
+		rows = append(rows, paginationRow(fmt.Sprintf("plist_list_%d_", userID), page, totalPages))
+	}
+	rows = append(rows, []gotdbot.InlineKeyboardButton{CloseBtn})
+	return &gotdbot.ReplyMarkupInlineKeyboard{Rows: rows}
+}
+
+// PlaylistSongsKeyboard shows one page of a playlist with move up, move down and remove buttons for the songs
+// at positions start onwards, one for each of tags. A button carries its position and the song's tag, so it is refused
+// once another song has taken that position. The owner's playlists are one tap away through back.
+func PlaylistSongsKeyboard(language, playlistID string, ownerID int64, start int, tags []string, page, totalPages int) *gotdbot.ReplyMarkupInlineKeyboard {
+	var rows [][]gotdbot.InlineKeyboardButton
+	for j, tag := range tags {
+		i := start + j
+		rows = append(rows, []gotdbot.InlineKeyboardButton{
+			cb(fmt.Sprintf("%d ⬆", i+1), fmt.Sprintf("plist_up_%s_%d:%s", playlistID, i, tag)),
+			cb(fmt.Sprintf("%d ⬇", i+1), fmt.Sprintf("plist_down_%s_%d:%s", playlistID, i, tag)),
+			cb(fmt.Sprintf("%d 🗑", i+1), fmt.Sprintf("plist_rm_%s_%d:%s", playlistID, i, tag)),
+		})
+	}
+
+	if totalPages > 1 {
+		rows = append(rows, paginationRow("plist_view_"+playlistID+"_", page, totalPages))
+	}
+	rows = append(rows, []gotdbot.InlineKeyboardButton{
+		cb(lang.Tr(language, "common.back"), fmt.Sprintf("plist_list_%d_0", ownerID)),
+		CloseBtn,
+	})
+	return &gotdbot.ReplyMarkupInlineKeyboard{Rows: rows}
+}
+
+// LikesKeyboard pages through a user's liked songs, with a button to unlike each song from start onwards, one for
+// each of tags. Like the playlist browser, a button carries the song's tag so it cannot unlike a song that moved in.
+func LikesKeyboard(userID int64, start int, tags []string, page, totalPages int) *gotdbot.ReplyMarkupInlineKeyboard {
+	var rows [][]gotdbot.InlineKeyboardButton
+	var row []gotdbot.InlineKeyboardButton
+	for j, tag := range tags {
+		i := start + j
+		row = append(row, cb(fmt.Sprintf("%d 💔", i+1), fmt.Sprintf("likes_rm_%d_%d:%s", userID, i, tag)))
+		if len(row) == 4 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	if totalPages > 1 {
+		rows = append(rows, paginationRow(fmt.Sprintf("likes_view_%d_", userID), page, totalPages))
+	}
+	rows = append(rows, []gotdbot.InlineKeyboardButton{CloseBtn})
+	return &gotdbot.ReplyMarkupInlineKeyboard{Rows: rows}
+}
+
+// PlaylistChooserKeyboard asks which playlist the song saved under token goes to.
+func PlaylistChooserKeyboard(token string, playlists []PlaylistButton) *gotdbot.ReplyMarkupInlineKeyboard {
+	var rows [][]gotdbot.InlineKeyboardButton
+	for _, p := range playlists {
+		rows = append(rows, []gotdbot.InlineKeyboardButton{cb("➕ "+p.Name, fmt.Sprintf("plist_pick_%s_%s", p.ID, token))})
+	}
+	rows = append(rows, []gotdbot.InlineKeyboardButton{CloseBtn})
+	return &gotdbot.ReplyMarkupInlineKeyboard{Rows: rows}
+}
+
 func AddMeMarkup(username string) *gotdbot.ReplyMarkupInlineKeyboard {
 
 	addMeBtn := url(
@@ -128,17 +128,22 @@ func (c *ChatCacher) IsActive(chatID int64) bool {
 	return ok && len(data.Queue) > 0
 }
 
-// ClearChat deletes all queued tracks for a chat.
-func (c *ChatCacher) ClearChat(chatID int64) {
+// ClearChat deletes all queued tracks for a chat and reports whether it was active. Checking and clearing is one
+// step, so when several callers race to clear a chat, exactly one of them sees it as active.
+func (c *ChatCacher) ClearChat(chatID int64) bool {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if data, ok := c.chatCache[chatID]; ok {
-		for i := range data.Queue {
-			data.Queue[i] = nil
-		}
-		delete(c.chatCache, chatID)
+	data, ok := c.chatCache[chatID]
+	if !ok {
+		return false
+	}
+	active := len(data.Queue) > 0
+	for i := range data.Queue {
+		data.Queue[i] = nil
 	}
+	delete(c.chatCache, chatID)
+	return active
 }
 
 // GetQueueLength returns the number of tracks queued for a chat.
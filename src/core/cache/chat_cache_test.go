@@ -3,6 +3,7 @@ package cache
 import (
 	"ashokshau/tgmusic/src/utils"
 	"sync"
+	"sync/atomic"
 	"testing"
 )
 
@@ -257,7 +258,31 @@ func TestClearChat(t *testing.T) {
 
 func TestClearChat_NonExistent(t *testing.T) {
 	c := newCache()
-	c.ClearChat(999) // should not panic
+	if c.ClearChat(999) {
+		t.Fatal("expected ClearChat to report an unknown chat as inactive")
+	}
+}
+
+func TestClearChat_ReportsOnce(t *testing.T) {
+	c := newCache()
+	c.AddSong(1, makeTrack("t1", "Track 1"))
+
+	var wg sync.WaitGroup
+	var cleared atomic.Int32
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if c.ClearChat(1) {
+				cleared.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := cleared.Load(); n != 1 {
+		t.Fatalf("expected exactly one ClearChat to see the chat active, got %d", n)
+	}
 }
 
 // GetQueueLength
@@ -0,0 +1,160 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package card
+
+import (
+	"ashokshau/tgmusic/config"
+	"ashokshau/tgmusic/src/utils"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"image"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	maxCoverSize = 5 << 20
+	// maxCards caps the cached cards; the least recently used ones are removed when a new card is written.
+	maxCards = 500
+)
+
+var (
+	httpClient   = &http.Client{Timeout: 10 * time.Second}
+	unsafeIDChar = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+)
+
+// Get returns the path of the now-playing card for a track, rendering it on first use.
+// Cards are cached on disk per track and requester, since the requester is drawn on the card, and at most
+// maxCards are kept; an error means the caller should fall back to text.
+func Get(track *utils.CachedTrack) (string, error) {
+	if track == nil || track.TrackID == "" || track.Thumbnail == "" {
+		return "", errors.New("track has no thumbnail")
+	}
+
+	dir := filepath.Join(config.Conf.DownloadsDir, "cards")
+	path := filepath.Join(dir, cacheKey(track)+".png")
+	if _, err := os.Stat(path); err == nil {
+		// Mark the card as used so pruning keeps it.
+		now := time.Now()
+		_ = os.Chtimes(path, now, now)
+		return path, nil
+	}
+
+	cover, err := fetchCover(track.Thumbnail)
+	if err != nil {
+		return "", err
+	}
+
+	img, err := Render(Info{
+		Title:     track.Name,
+		Channel:   track.Channel,
+		Requester: track.User,
+		Duration:  utils.SecToMin(track.Duration),
+		Live:      track.IsLive,
+		Cover:     cover,
+	})
+	if err != nil {
+		return "", fmt.Errorf("render card: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	// Write to a temporary file first so concurrent renders never expose a partial card.
+	tmp, err := os.CreateTemp(dir, "card-*.png")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := png.Encode(tmp, img); err != nil {
+		_ = tmp.Close()
+		return "", fmt.Errorf("encode card: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", err
+	}
+	pruneCards(dir, maxCards)
+	return path, nil
+}
+
+// pruneCards removes the least recently used cards in dir until at most limit are left. Temporary files of
+// renders in progress are left alone.
+func pruneCards(dir string, limit int) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	type card struct {
+		path    string
+		modTime time.Time
+	}
+	var cards []card
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), "card-") || filepath.Ext(entry.Name()) != ".png" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		cards = append(cards, card{path: filepath.Join(dir, entry.Name()), modTime: info.ModTime()})
+	}
+	if len(cards) <= limit {
+		return
+	}
+
+	slices.SortFunc(cards, func(a, b card) int { return a.modTime.Compare(b.modTime) })
+	for _, c := range cards[:len(cards)-limit] {
+		_ = os.Remove(c.path)
+	}
+}
+
+// cacheKey names the card file of a track; the requester is hashed in so each requester gets their own card.
+func cacheKey(track *utils.CachedTrack) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(track.User))
+	return fmt.Sprintf("%s_%08x", unsafeIDChar.ReplaceAllString(track.TrackID, "_"), h.Sum32())
+}
+
+// fetchCover downloads and decodes the cover art at url.
+func fetchCover(url string) (image.Image, error) {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("fetch thumbnail: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch thumbnail: unexpected status %s", resp.Status)
+	}
+
+	img, _, err := image.Decode(io.LimitReader(resp.Body, maxCoverSize))
+	if err != nil {
+		return nil, fmt.Errorf("decode thumbnail: %w", err)
+	}
+	return img, nil
+}
@@ -0,0 +1,55 @@
+package card
+
+import (
+	"ashokshau/tgmusic/src/utils"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCacheKey(t *testing.T) {
+	alice := &utils.CachedTrack{TrackID: "abc/../x", User: "Alice"}
+	bob := &utils.CachedTrack{TrackID: "abc/../x", User: "Bob"}
+
+	if cacheKey(alice) == cacheKey(bob) {
+		t.Errorf("cacheKey is %q for both requesters; want one per requester", cacheKey(alice))
+	}
+	if cacheKey(alice) != cacheKey(&utils.CachedTrack{TrackID: "abc/../x", User: "Alice"}) {
+		t.Error("cacheKey is not stable for the same track and requester")
+	}
+	if key := cacheKey(alice); unsafeIDChar.MatchString(key) {
+		t.Errorf("cacheKey = %q; want only safe characters", key)
+	}
+}
+
+func TestPruneCards(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	// The oldest card is also a render in progress, which pruning must not touch.
+	for i, name := range []string{"card-123.png", "a.png", "b.png", "c.png", "d.png"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+		at := now.Add(time.Duration(i) * time.Minute)
+		if err := os.Chtimes(path, at, at); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pruneCards(dir, 2)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if want := []string{"c.png", "card-123.png", "d.png"}; !slices.Equal(names, want) {
+		t.Errorf("cards left = %v; want %v", names, want)
+	}
+}
@@ -0,0 +1,214 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package card
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	cardWidth  = 1280
+	cardHeight = 720
+	coverSize  = 420
+	margin     = 80
+)
+
+var (
+	textColor   = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	mutedColor  = color.RGBA{R: 0xc8, G: 0xc8, B: 0xd0, A: 0xff}
+	accentColor = color.RGBA{R: 0xff, G: 0x45, B: 0x5a, A: 0xff}
+	trackColor  = color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x50}
+	shadeColor  = color.RGBA{A: 0xa8}
+)
+
+// Info holds the track details drawn on a card.
+type Info struct {
+	Title     string
+	Channel   string
+	Requester string
+	Duration  string
+	Live      bool
+	Cover     image.Image
+}
+
+type faces struct {
+	title, body, small font.Face
+}
+
+type fonts struct {
+	bold, regular *opentype.Font
+}
+
+// loadFonts parses the fonts once. Faces cache glyphs and are not safe for concurrent use, so newFaces
+// makes a set for each render.
+var loadFonts = sync.OnceValues(func() (*fonts, error) {
+	bold, err := opentype.Parse(gobold.TTF)
+	if err != nil {
+		return nil, err
+	}
+	regular, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		return nil, err
+	}
+	return &fonts{bold: bold, regular: regular}, nil
+})
+
+func newFaces() (*faces, error) {
+	fts, err := loadFonts()
+	if err != nil {
+		return nil, err
+	}
+
+	newFace := func(f *opentype.Font, size float64) (font.Face, error) {
+		return opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+	}
+
+	var fs faces
+	if fs.title, err = newFace(fts.bold, 52); err != nil {
+		return nil, err
+	}
+	if fs.body, err = newFace(fts.regular, 34); err != nil {
+		return nil, err
+	}
+	if fs.small, err = newFace(fts.bold, 26); err != nil {
+		return nil, err
+	}
+	return &fs, nil
+}
+
+// Render composes a now-playing card from info.
+func Render(info Info) (image.Image, error) {
+	fs, err := newFaces()
+	if err != nil {
+		return nil, err
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, cardWidth, cardHeight))
+	drawBackground(img, info.Cover)
+
+	coverRect := image.Rect(margin, (cardHeight-coverSize)/2, margin+coverSize, (cardHeight+coverSize)/2)
+	draw.CatmullRom.Scale(img, coverRect, info.Cover, squareCrop(info.Cover.Bounds()), draw.Src, nil)
+
+	x := coverRect.Max.X + 60
+	maxWidth := cardWidth - margin - x
+	y := coverRect.Min.Y + 30
+
+	drawText(img, fs.small, accentColor, x, y, "NOW PLAYING")
+	y += 70
+
+	for _, line := range wrap(fs.title, info.Title, maxWidth, 2) {
+		drawText(img, fs.title, textColor, x, y, line)
+		y += 62
+	}
+	y += 10
+
+	if info.Channel != "" {
+		drawText(img, fs.body, mutedColor, x, y, truncate(fs.body, info.Channel, maxWidth))
+		y += 48
+	}
+	if info.Requester != "" {
+		drawText(img, fs.body, mutedColor, x, y, truncate(fs.body, "Requested by "+info.Requester, maxWidth))
+	}
+
+	barY := coverRect.Max.Y - 50
+	drawProgress(img, fs.small, x, barY, maxWidth, info)
+	return img, nil
+}
+
+// drawBackground fills dst with a blurred, darkened copy of the cover.
+func drawBackground(dst *image.RGBA, cover image.Image) {
+	small := image.NewRGBA(image.Rect(0, 0, 32, 18))
+	draw.ApproxBiLinear.Scale(small, small.Bounds(), cover, cover.Bounds(), draw.Src, nil)
+	draw.BiLinear.Scale(dst, dst.Bounds(), small, small.Bounds(), draw.Src, nil)
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(shadeColor), image.Point{}, draw.Over)
+}
+
+// drawProgress draws the progress bar with the elapsed and total time under it.
+func drawProgress(dst *image.RGBA, face font.Face, x, y, width int, info Info) {
+	fill := image.Rect(x, y, x+width, y+8)
+	draw.Draw(dst, fill, image.NewUniform(trackColor), image.Point{}, draw.Over)
+
+	if info.Live {
+		draw.Draw(dst, fill, image.NewUniform(accentColor), image.Point{}, draw.Over)
+		drawText(dst, face, accentColor, x, y+48, "● LIVE")
+		return
+	}
+
+	knob := image.Rect(x, y-6, x+20, y+14)
+	draw.Draw(dst, knob, image.NewUniform(accentColor), image.Point{}, draw.Over)
+	drawText(dst, face, mutedColor, x, y+48, "0:00")
+
+	end := font.MeasureString(face, info.Duration).Ceil()
+	drawText(dst, face, mutedColor, x+width-end, y+48, info.Duration)
+}
+
+func drawText(dst *image.RGBA, face font.Face, c color.Color, x, y int, text string) {
+	d := &font.Drawer{Dst: dst, Src: image.NewUniform(c), Face: face, Dot: fixed.P(x, y)}
+	d.DrawString(text)
+}
+
+// wrap splits text into at most maxLines lines that fit in width, truncating the last one.
+func wrap(face font.Face, text string, width, maxLines int) []string {
+	var lines []string
+	var current string
+	words := strings.Fields(text)
+	for i, word := range words {
+		candidate := strings.TrimSpace(current + " " + word)
+		if font.MeasureString(face, candidate).Ceil() <= width || current == "" {
+			current = candidate
+			continue
+		}
+
+		if len(lines) == maxLines-1 {
+			current = strings.Join(append([]string{current}, words[i:]...), " ")
+			break
+		}
+		lines = append(lines, current)
+		current = word
+	}
+	if current != "" {
+		lines = append(lines, truncate(face, current, width))
+	}
+	return lines
+}
+
+// truncate shortens text with an ellipsis so that it fits in width.
+func truncate(face font.Face, text string, width int) string {
+	if font.MeasureString(face, text).Ceil() <= width {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		candidate := strings.TrimSpace(string(runes)) + "…"
+		if font.MeasureString(face, candidate).Ceil() <= width {
+			return candidate
+		}
+	}
+	return "…"
+}
+
+// squareCrop returns the centred square of r.
+func squareCrop(r image.Rectangle) image.Rectangle {
+	size := min(r.Dx(), r.Dy())
+	x := r.Min.X + (r.Dx()-size)/2
+	y := r.Min.Y + (r.Dy()-size)/2
+	return image.Rect(x, y, x+size, y+size)
+}
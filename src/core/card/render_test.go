@@ -0,0 +1,110 @@
+package card
+
+import (
+	"image"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/image/font"
+)
+
+func testFace(t *testing.T) font.Face {
+	t.Helper()
+	fs, err := newFaces()
+	if err != nil {
+		t.Fatalf("newFaces: %v", err)
+	}
+	return fs.title
+}
+
+func TestTruncate(t *testing.T) {
+	face := testFace(t)
+	short := "Song"
+	width := font.MeasureString(face, short).Ceil()
+
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  string
+	}{
+		{"fits", short, width, short},
+		{"empty", "", width, ""},
+		{"nothing fits", "A very long song title", 1, "…"},
+	}
+	for _, tt := range tests {
+		if got := truncate(face, tt.text, tt.width); got != tt.want {
+			t.Errorf("%s: truncate(%q, %d) = %q; want %q", tt.name, tt.text, tt.width, got, tt.want)
+		}
+	}
+
+	long := "Never Gonna Give You Up (Official Music Video)"
+	got := truncate(face, long, width*3)
+	if !strings.HasSuffix(got, "…") {
+		t.Errorf("truncate(%q) = %q; want an ellipsis", long, got)
+	}
+	if !strings.HasPrefix(long, strings.TrimSuffix(got, "…")) {
+		t.Errorf("truncate(%q) = %q; want a prefix of the text", long, got)
+	}
+	if w := font.MeasureString(face, got).Ceil(); w > width*3 {
+		t.Errorf("truncate(%q) is %d wide; want at most %d", long, w, width*3)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	face := testFace(t)
+	width := font.MeasureString(face, "Never Gonna").Ceil()
+
+	tests := []struct {
+		name     string
+		text     string
+		maxLines int
+		want     []string
+	}{
+		{"one line", "Never", 2, []string{"Never"}},
+		{"empty", "", 2, nil},
+		{"two lines", "Never Gonna Give You", 2, []string{"Never Gonna", "Give You"}},
+		{"collapses spaces", "  Never   Gonna  ", 2, []string{"Never Gonna"}},
+	}
+	for _, tt := range tests {
+		got := wrap(face, tt.text, width, tt.maxLines)
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("%s: wrap(%q) = %q; want %q", tt.name, tt.text, got, tt.want)
+		}
+	}
+
+	got := wrap(face, "Never Gonna Give You Up Never Gonna Let You Down", width, 2)
+	if len(got) != 2 {
+		t.Fatalf("wrap returned %d lines; want 2: %q", len(got), got)
+	}
+	if got[0] != "Never Gonna" || !strings.HasSuffix(got[1], "…") {
+		t.Errorf("wrap = %q; want the overflow truncated on the last line", got)
+	}
+	for _, line := range got {
+		if w := font.MeasureString(face, line).Ceil(); w > width {
+			t.Errorf("line %q is %d wide; want at most %d", line, w, width)
+		}
+	}
+}
+
+// TestRenderConcurrent renders cards in parallel, as playback in several chats does; run it with -race.
+func TestRenderConcurrent(t *testing.T) {
+	cover := image.NewRGBA(image.Rect(0, 0, 64, 64))
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Go(func() {
+			_, err := Render(Info{
+				Title:     strings.Repeat("Concurrent song ", i+1),
+				Channel:   "Channel",
+				Requester: "User",
+				Duration:  "3:20",
+				Cover:     cover,
+			})
+			if err != nil {
+				t.Errorf("Render: %v", err)
+			}
+		})
+	}
+	wg.Wait()
+}
@@ -9,47 +9,38 @@
 package db
 
 import (
-	"errors"
 	"log/slog"
-
-	"go.mongodb.org/mongo-driver/v2/bson"
-	"go.mongodb.org/mongo-driver/v2/mongo"
-	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
-// GetAssistant retrieves the index of the assistant for a chat.
-// Returns -1 if no assistant is assigned.
-func (db *Database) GetAssistant(chatID int64) (int, error) {
+// GetAssistant retrieves the ID of the assistant for a chat.
+// Returns 0 if no assistant is assigned.
+func (db *Database) GetAssistant(chatID int64) (int64, error) {
 	key := toKey(chatID)
 	if cached, ok := db.assistantCache.Get(key); ok {
 		return cached, nil
 	}
-	var doc struct {
-		Num int `bson:"num"`
-	}
 
 	ctx, cancel := db.ctx()
 	defer cancel()
 
-	err := db.assistantDB.FindOne(ctx, bson.M{"_id": chatID}).Decode(&doc)
+	assistantID, err := db.store.GetAssistant(ctx, chatID)
 	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return -1, nil
-		}
-		return -1, err
+		return 0, err
 	}
-	db.assistantCache.Set(key, doc.Num)
-	return doc.Num, nil
+	if assistantID != 0 {
+		db.assistantCache.Set(key, assistantID)
+	}
+	return assistantID, nil
 }
 
-// SetAssistant sets the assistant index for a given chat.
-func (db *Database) SetAssistant(chatID int64, num int) error {
+// SetAssistant sets the assistant ID for a given chat.
+func (db *Database) SetAssistant(chatID, assistantID int64) error {
 	ctx, cancel := db.ctx()
 	defer cancel()
 
-	_, err := db.assistantDB.UpdateOne(ctx, bson.M{"_id": chatID}, bson.M{"$set": bson.M{"num": num}}, options.UpdateOne().SetUpsert(true))
+	err := db.store.SetAssistant(ctx, chatID, assistantID)
 	if err == nil {
-		db.assistantCache.Set(toKey(chatID), num)
+		db.assistantCache.Set(toKey(chatID), assistantID)
 	}
 
 	return err
@@ -60,7 +51,7 @@ func (db *Database) RemoveAssistant(chatID int64) error {
 	ctx, cancel := db.ctx()
 	defer cancel()
 
-	_, err := db.assistantDB.DeleteOne(ctx, bson.M{"_id": chatID})
+	err := db.store.RemoveAssistant(ctx, chatID)
 	if err == nil {
 		db.assistantCache.Delete(toKey(chatID))
 	}
@@ -68,34 +59,48 @@ func (db *Database) RemoveAssistant(chatID int64) error {
 }
 
 // AssignAssistant attempts to set the assistant for a chat if it is not currently set.
-func (db *Database) AssignAssistant(chatID int64, proposedAssistant int) (int, error) {
+// It returns the assistant the chat ends up with, which differs from the proposed one if another was assigned first.
+func (db *Database) AssignAssistant(chatID, proposedAssistant int64) (int64, error) {
 	ctx, cancel := db.ctx()
 	defer cancel()
 
-	filter := bson.M{
-		"_id": chatID,
-		"$or": bson.A{
-			bson.M{"num": bson.M{"$exists": false}},
-			bson.M{"num": -1},
-		},
+	assistantID, err := db.store.AssignAssistant(ctx, chatID, proposedAssistant)
+	if err != nil {
+		return 0, err
 	}
-	update := bson.M{"$set": bson.M{"num": proposedAssistant}}
-	opts := options.UpdateOne().SetUpsert(true)
 
-	result, err := db.assistantDB.UpdateOne(ctx, filter, update, opts)
+	db.assistantCache.Set(toKey(chatID), assistantID)
+	return assistantID, nil
+}
+
+// UnassignAssistant removes every chat assignment of an assistant so those chats get a new one on their next play.
+func (db *Database) UnassignAssistant(assistantID int64) (int64, error) {
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	deleted, err := db.store.UnassignAssistant(ctx, assistantID)
 	if err != nil {
-		if mongo.IsDuplicateKeyError(err) {
-			return db.GetAssistant(chatID)
-		}
-		return -1, err
+		return 0, err
 	}
 
-	if result.ModifiedCount > 0 || result.UpsertedCount > 0 {
-		db.assistantCache.Set(toKey(chatID), proposedAssistant)
-		return proposedAssistant, nil
-	}
+	db.assistantCache.Clear()
+	return deleted, nil
+}
+
+// CountAssistantChats returns how many chats are assigned to each assistant.
+func (db *Database) CountAssistantChats() (map[int64]int64, error) {
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	return db.store.CountAssistantChats(ctx)
+}
+
+// GetAssistantChats returns the IDs of the chats assigned to an assistant.
+func (db *Database) GetAssistantChats(assistantID int64) ([]int64, error) {
+	ctx, cancel := db.ctx()
+	defer cancel()
 
-	return db.GetAssistant(chatID)
+	return db.store.ListAssistantChats(ctx, assistantID)
 }
 
 // ClearAllAssistants removes all assistant assignments.
@@ -103,12 +108,12 @@ func (db *Database) ClearAllAssistants() (int64, error) {
 	ctx, cancel := db.ctx()
 	defer cancel()
 
-	result, err := db.assistantDB.DeleteMany(ctx, bson.M{})
+	deleted, err := db.store.ClearAssistants(ctx)
 	if err != nil {
 		slog.Info("[DB] Error clearing assistants", "error", err)
 		return 0, err
 	}
 
 	db.assistantCache.Clear()
-	return result.DeletedCount, nil
+	return deleted, nil
 }
@@ -10,9 +10,6 @@ package db
 
 import (
 	"ashokshau/tgmusic/src/core/cache"
-
-	"go.mongodb.org/mongo-driver/v2/bson"
-	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
 // AddAuthUser adds a user to the list of authorized users for a chat.
@@ -20,12 +17,7 @@ func (db *Database) AddAuthUser(chatID, userID int64) error {
 	ctx, cancel := db.ctx()
 	defer cancel()
 
-	_, err := db.authDB.UpdateOne(ctx,
-		bson.M{"_id": chatID},
-		bson.M{"$addToSet": bson.M{"user_ids": userID}},
-		options.UpdateOne().SetUpsert(true),
-	)
-
+	err := db.store.AddAuthUser(ctx, chatID, userID)
 	if err == nil {
 		db.authCache.Delete(toKey(chatID))
 	}
@@ -37,10 +29,7 @@ func (db *Database) RemoveAuthUser(chatID, userID int64) error {
 	ctx, cancel := db.ctx()
 	defer cancel()
 
-	_, err := db.authDB.UpdateOne(ctx,
-		bson.M{"_id": chatID},
-		bson.M{"$pull": bson.M{"user_ids": userID}},
-	)
+	err := db.store.RemoveAuthUser(ctx, chatID, userID)
 	if err == nil {
 		db.authCache.Delete(toKey(chatID))
 	}
@@ -57,15 +46,12 @@ func (db *Database) GetAuthUsers(chatID int64) []int64 {
 	ctx, cancel := db.ctx()
 	defer cancel()
 
-	var doc struct {
-		UserIDs []int64 `bson:"user_ids"`
-	}
-	err := db.authDB.FindOne(ctx, bson.M{"_id": chatID}).Decode(&doc)
-	if err != nil {
+	users, err := db.store.GetAuthUsers(ctx, chatID)
+	if err != nil || users == nil {
 		return []int64{}
 	}
-	db.authCache.Set(key, doc.UserIDs)
-	return doc.UserIDs
+	db.authCache.Set(key, users)
+	return users
 }
 
 // IsAuthUser checks if a specific user is in the list of authorized users for a chat.
@@ -0,0 +1,578 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package db
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"time"
+)
+
+// maxBackupData is the most data ReadBackup decompresses from an archive.
+var maxBackupData int64 = 512 << 20
+
+// backupVersion is the archive format written by WriteBackup. ReadBackup accepts this version and older ones.
+// Version 2 added likes.
+const backupVersion = 2
+
+// Record types of a backup archive.
+const (
+	recordHeader    = "header"
+	recordChat      = "chat"
+	recordUser      = "user"
+	recordPlaylist  = "playlist"
+	recordAuth      = "auth"
+	recordAssistant = "assistant"
+	recordLang      = "lang"
+	recordBlacklist = "blacklist"
+	recordLike      = "like"
+)
+
+// Backup is a snapshot of the bot's data: chats, users, playlists, auth users, assistant assignments, languages,
+// blacklists and likes. Sudoers, settings and assistant sessions are deployment-specific and not included.
+type Backup struct {
+	CreatedAt  time.Time
+	Chats      []Chats
+	Users      []int64
+	Playlists  []Playlist
+	Auth       map[int64][]int64
+	Assistants map[int64]int64
+	Languages  map[int64]string
+	Blacklist  map[BlacklistKind][]BlacklistEntry
+	Likes      []Like
+}
+
+// BackupCounts is the number of records of each kind in a Backup.
+type BackupCounts struct {
+	Chats, Users, Playlists, Auth, Assistants, Languages, BlacklistedChats, BlacklistedUsers, Likes int
+}
+
+// Counts returns the number of records of each kind.
+func (b *Backup) Counts() BackupCounts {
+	return BackupCounts{
+		Chats:            len(b.Chats),
+		Users:            len(b.Users),
+		Playlists:        len(b.Playlists),
+		Auth:             len(b.Auth),
+		Assistants:       len(b.Assistants),
+		Languages:        len(b.Languages),
+		BlacklistedChats: len(b.Blacklist[BlacklistChats]),
+		BlacklistedUsers: len(b.Blacklist[BlacklistUsers]),
+		Likes:            len(b.Likes),
+	}
+}
+
+// backupRecord is one line of the archive.
+type backupRecord struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+type backupHeader struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type backupAuth struct {
+	ChatID  int64   `json:"chat_id"`
+	UserIDs []int64 `json:"user_ids"`
+}
+
+type backupAssistant struct {
+	ChatID      int64 `json:"chat_id"`
+	AssistantID int64 `json:"assistant_id"`
+}
+
+type backupLang struct {
+	ChatID int64  `json:"chat_id"`
+	Lang   string `json:"lang"`
+}
+
+type backupBlacklist struct {
+	Kind  BlacklistKind  `json:"kind"`
+	Entry BlacklistEntry `json:"entry"`
+}
+
+// ExportBackup writes everything a Backup holds to w as an archive in the WriteBackup format.
+// The data is read and written one kind of record at a time, so the whole database is never held in memory at once.
+func (db *Database) ExportBackup(ctx context.Context, w io.Writer, createdAt time.Time) (BackupCounts, error) {
+	bw, err := newBackupWriter(w, createdAt)
+	if err != nil {
+		return BackupCounts{}, err
+	}
+
+	chats, err := db.store.ListChats(ctx)
+	if err != nil {
+		return bw.counts, fmt.Errorf("chats: %w", err)
+	}
+	if err := bw.writeChats(chats); err != nil {
+		return bw.counts, err
+	}
+
+	users, err := db.store.ListUsers(ctx)
+	if err != nil {
+		return bw.counts, fmt.Errorf("users: %w", err)
+	}
+	if err := bw.writeUsers(users); err != nil {
+		return bw.counts, err
+	}
+
+	playlists, err := db.store.ListPlaylists(ctx)
+	if err != nil {
+		return bw.counts, fmt.Errorf("playlists: %w", err)
+	}
+	if err := bw.writePlaylists(playlists); err != nil {
+		return bw.counts, err
+	}
+
+	auth, err := db.store.ListAuth(ctx)
+	if err != nil {
+		return bw.counts, fmt.Errorf("auth: %w", err)
+	}
+	if err := bw.writeAuth(auth); err != nil {
+		return bw.counts, err
+	}
+
+	assistants, err := db.store.ListAssignments(ctx)
+	if err != nil {
+		return bw.counts, fmt.Errorf("assistants: %w", err)
+	}
+	if err := bw.writeAssistants(assistants); err != nil {
+		return bw.counts, err
+	}
+
+	languages, err := db.store.ListLanguages(ctx)
+	if err != nil {
+		return bw.counts, fmt.Errorf("languages: %w", err)
+	}
+	if err := bw.writeLanguages(languages); err != nil {
+		return bw.counts, err
+	}
+
+	for _, kind := range []BlacklistKind{BlacklistChats, BlacklistUsers} {
+		entries, err := db.store.GetBlacklisted(ctx, kind)
+		if err != nil {
+			return bw.counts, fmt.Errorf("%s: %w", kind, err)
+		}
+		if err := bw.writeBlacklist(kind, entries); err != nil {
+			return bw.counts, err
+		}
+	}
+
+	likes, err := db.store.ListAllLikes(ctx)
+	if err != nil {
+		return bw.counts, fmt.Errorf("likes: %w", err)
+	}
+	if err := bw.writeLikes(likes); err != nil {
+		return bw.counts, err
+	}
+	return bw.counts, bw.close()
+}
+
+// WriteBackup writes b to w as a gzip-compressed JSON-lines archive, one record per line after a header.
+func WriteBackup(w io.Writer, b *Backup) error {
+	bw, err := newBackupWriter(w, b.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	if err := bw.writeChats(b.Chats); err != nil {
+		return err
+	}
+	if err := bw.writeUsers(b.Users); err != nil {
+		return err
+	}
+	if err := bw.writePlaylists(b.Playlists); err != nil {
+		return err
+	}
+	if err := bw.writeAuth(b.Auth); err != nil {
+		return err
+	}
+	if err := bw.writeAssistants(b.Assistants); err != nil {
+		return err
+	}
+	if err := bw.writeLanguages(b.Languages); err != nil {
+		return err
+	}
+	for _, kind := range []BlacklistKind{BlacklistChats, BlacklistUsers} {
+		if err := bw.writeBlacklist(kind, b.Blacklist[kind]); err != nil {
+			return err
+		}
+	}
+	if err := bw.writeLikes(b.Likes); err != nil {
+		return err
+	}
+	return bw.close()
+}
+
+// backupWriter writes the records of an archive and counts them.
+type backupWriter struct {
+	gz     *gzip.Writer
+	enc    *json.Encoder
+	counts BackupCounts
+}
+
+// newBackupWriter starts an archive on w and writes its header.
+func newBackupWriter(w io.Writer, createdAt time.Time) (*backupWriter, error) {
+	gz := gzip.NewWriter(w)
+	bw := &backupWriter{gz: gz, enc: json.NewEncoder(gz)}
+	if err := bw.write(recordHeader, backupHeader{Version: backupVersion, CreatedAt: createdAt}); err != nil {
+		return nil, err
+	}
+	return bw, nil
+}
+
+func (bw *backupWriter) write(typ string, data any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return bw.enc.Encode(backupRecord{Type: typ, Data: raw})
+}
+
+// close flushes the archive. It does not close the underlying writer.
+func (bw *backupWriter) close() error {
+	return bw.gz.Close()
+}
+
+func (bw *backupWriter) writeChats(chats []Chats) error {
+	for _, chat := range chats {
+		if err := bw.write(recordChat, chat); err != nil {
+			return err
+		}
+		bw.counts.Chats++
+	}
+	return nil
+}
+
+func (bw *backupWriter) writeUsers(users []int64) error {
+	for _, userID := range users {
+		if err := bw.write(recordUser, userID); err != nil {
+			return err
+		}
+		bw.counts.Users++
+	}
+	return nil
+}
+
+func (bw *backupWriter) writePlaylists(playlists []Playlist) error {
+	for _, playlist := range playlists {
+		if err := bw.write(recordPlaylist, playlist); err != nil {
+			return err
+		}
+		bw.counts.Playlists++
+	}
+	return nil
+}
+
+func (bw *backupWriter) writeAuth(auth map[int64][]int64) error {
+	for _, chatID := range slices.Sorted(maps.Keys(auth)) {
+		if err := bw.write(recordAuth, backupAuth{ChatID: chatID, UserIDs: auth[chatID]}); err != nil {
+			return err
+		}
+		bw.counts.Auth++
+	}
+	return nil
+}
+
+func (bw *backupWriter) writeAssistants(assistants map[int64]int64) error {
+	for _, chatID := range slices.Sorted(maps.Keys(assistants)) {
+		if err := bw.write(recordAssistant, backupAssistant{ChatID: chatID, AssistantID: assistants[chatID]}); err != nil {
+			return err
+		}
+		bw.counts.Assistants++
+	}
+	return nil
+}
+
+func (bw *backupWriter) writeLanguages(languages map[int64]string) error {
+	for _, chatID := range slices.Sorted(maps.Keys(languages)) {
+		if err := bw.write(recordLang, backupLang{ChatID: chatID, Lang: languages[chatID]}); err != nil {
+			return err
+		}
+		bw.counts.Languages++
+	}
+	return nil
+}
+
+func (bw *backupWriter) writeBlacklist(kind BlacklistKind, entries []BlacklistEntry) error {
+	for _, entry := range entries {
+		if err := bw.write(recordBlacklist, backupBlacklist{Kind: kind, Entry: entry}); err != nil {
+			return err
+		}
+		if kind == BlacklistChats {
+			bw.counts.BlacklistedChats++
+		} else {
+			bw.counts.BlacklistedUsers++
+		}
+	}
+	return nil
+}
+
+func (bw *backupWriter) writeLikes(likes []Like) error {
+	for _, like := range likes {
+		if err := bw.write(recordLike, like); err != nil {
+			return err
+		}
+		bw.counts.Likes++
+	}
+	return nil
+}
+
+// ReadBackup reads and validates an archive written by WriteBackup.
+// When a record appears more than once, the last one wins. Archives that decompress to more than
+// maxBackupData bytes are rejected, so a small compressed file cannot exhaust memory.
+func ReadBackup(r io.Reader) (*Backup, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a gzip archive: %w", err)
+	}
+	defer gz.Close()
+
+	// One byte over the limit tells an archive that is too large from one that ends exactly at it.
+	limited := &io.LimitedReader{R: gz, N: maxBackupData + 1}
+
+	b := &Backup{
+		Auth:       make(map[int64][]int64),
+		Assistants: make(map[int64]int64),
+		Languages:  make(map[int64]string),
+		Blacklist:  make(map[BlacklistKind][]BlacklistEntry),
+	}
+	chats := make(map[int64]int)
+	users := make(map[int64]bool)
+	playlists := make(map[string]int)
+	blacklisted := make(map[BlacklistKind]map[int64]int)
+	likes := make(map[string]int)
+
+	dec := json.NewDecoder(limited)
+	for line := 1; ; line++ {
+		var rec backupRecord
+		err := dec.Decode(&rec)
+		if limited.N <= 0 {
+			return nil, fmt.Errorf("the archive decompresses to more than %d MB", maxBackupData>>20)
+		}
+		if errors.Is(err, io.EOF) {
+			if line == 1 {
+				return nil, errors.New("the archive is empty")
+			}
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("record %d: %w", line, err)
+		}
+
+		if line == 1 {
+			if rec.Type != recordHeader {
+				return nil, errors.New("the archive has no header")
+			}
+			var h backupHeader
+			if err := json.Unmarshal(rec.Data, &h); err != nil {
+				return nil, fmt.Errorf("header: %w", err)
+			}
+			if h.Version < 1 || h.Version > backupVersion {
+				return nil, fmt.Errorf("unsupported backup version %d", h.Version)
+			}
+			b.CreatedAt = h.CreatedAt
+			continue
+		}
+
+		if err := readRecord(b, rec, chats, users, playlists, blacklisted, likes); err != nil {
+			return nil, fmt.Errorf("record %d (%s): %w", line, rec.Type, err)
+		}
+	}
+	return b, nil
+}
+
+// readRecord validates a record and adds it to b. The maps index the records already read, so duplicates replace them.
+func readRecord(b *Backup, rec backupRecord, chats map[int64]int, users map[int64]bool, playlists map[string]int, blacklisted map[BlacklistKind]map[int64]int, likes map[string]int) error {
+	switch rec.Type {
+	case recordChat:
+		var chat Chats
+		if err := json.Unmarshal(rec.Data, &chat); err != nil {
+			return err
+		}
+		if chat.ID == 0 {
+			return errors.New("missing chat ID")
+		}
+		if i, ok := chats[chat.ID]; ok {
+			b.Chats[i] = chat
+		} else {
+			chats[chat.ID] = len(b.Chats)
+			b.Chats = append(b.Chats, chat)
+		}
+
+	case recordUser:
+		var userID int64
+		if err := json.Unmarshal(rec.Data, &userID); err != nil {
+			return err
+		}
+		if userID == 0 {
+			return errors.New("missing user ID")
+		}
+		if !users[userID] {
+			users[userID] = true
+			b.Users = append(b.Users, userID)
+		}
+
+	case recordPlaylist:
+		var playlist Playlist
+		if err := json.Unmarshal(rec.Data, &playlist); err != nil {
+			return err
+		}
+		if playlist.ID == "" || playlist.UserID == 0 {
+			return errors.New("missing playlist ID or owner")
+		}
+		if playlist.Songs == nil {
+			playlist.Songs = []Song{}
+		}
+		if i, ok := playlists[playlist.ID]; ok {
+			b.Playlists[i] = playlist
+		} else {
+			playlists[playlist.ID] = len(b.Playlists)
+			b.Playlists = append(b.Playlists, playlist)
+		}
+
+	case recordAuth:
+		var auth backupAuth
+		if err := json.Unmarshal(rec.Data, &auth); err != nil {
+			return err
+		}
+		if auth.ChatID == 0 {
+			return errors.New("missing chat ID")
+		}
+		b.Auth[auth.ChatID] = auth.UserIDs
+
+	case recordAssistant:
+		var assistant backupAssistant
+		if err := json.Unmarshal(rec.Data, &assistant); err != nil {
+			return err
+		}
+		if assistant.ChatID == 0 || assistant.AssistantID <= 0 {
+			return errors.New("missing chat or assistant ID")
+		}
+		b.Assistants[assistant.ChatID] = assistant.AssistantID
+
+	case recordLang:
+		var lang backupLang
+		if err := json.Unmarshal(rec.Data, &lang); err != nil {
+			return err
+		}
+		if lang.ChatID == 0 || lang.Lang == "" {
+			return errors.New("missing chat ID or language")
+		}
+		b.Languages[lang.ChatID] = lang.Lang
+
+	case recordBlacklist:
+		var bl backupBlacklist
+		if err := json.Unmarshal(rec.Data, &bl); err != nil {
+			return err
+		}
+		if bl.Kind != BlacklistChats && bl.Kind != BlacklistUsers {
+			return fmt.Errorf("unknown blacklist %q", bl.Kind)
+		}
+		if bl.Entry.ID == 0 {
+			return errors.New("missing blacklisted ID")
+		}
+		if blacklisted[bl.Kind] == nil {
+			blacklisted[bl.Kind] = make(map[int64]int)
+		}
+		if i, ok := blacklisted[bl.Kind][bl.Entry.ID]; ok {
+			b.Blacklist[bl.Kind][i] = bl.Entry
+		} else {
+			blacklisted[bl.Kind][bl.Entry.ID] = len(b.Blacklist[bl.Kind])
+			b.Blacklist[bl.Kind] = append(b.Blacklist[bl.Kind], bl.Entry)
+		}
+
+	case recordLike:
+		var like Like
+		if err := json.Unmarshal(rec.Data, &like); err != nil {
+			return err
+		}
+		if like.UserID == 0 || like.Song.TrackID == "" {
+			return errors.New("missing user or track ID")
+		}
+		id := likeID(like.UserID, like.Song.TrackID)
+		if i, ok := likes[id]; ok {
+			b.Likes[i] = like
+		} else {
+			likes[id] = len(b.Likes)
+			b.Likes = append(b.Likes, like)
+		}
+
+	case recordHeader:
+		return errors.New("unexpected second header")
+	default:
+		return errors.New("unknown record type")
+	}
+	return nil
+}
+
+// RestoreBackup writes b to the database. With replace set, the data a backup covers is deleted first;
+// otherwise the backup is merged, overwriting records with the same ID and keeping the rest.
+func (db *Database) RestoreBackup(ctx context.Context, b *Backup, replace bool) error {
+	// Cached values may be stale whether or not the restore completes.
+	defer db.clearCaches()
+
+	if replace {
+		if err := db.store.ClearBackupData(ctx); err != nil {
+			return fmt.Errorf("clearing data: %w", err)
+		}
+	}
+
+	for _, chat := range b.Chats {
+		if err := db.store.PutChat(ctx, chat); err != nil {
+			return fmt.Errorf("chat %d: %w", chat.ID, err)
+		}
+	}
+	for _, userID := range b.Users {
+		if err := db.store.AddUser(ctx, userID); err != nil {
+			return fmt.Errorf("user %d: %w", userID, err)
+		}
+	}
+	for _, playlist := range b.Playlists {
+		if err := db.store.PutPlaylist(ctx, playlist); err != nil {
+			return fmt.Errorf("playlist %s: %w", playlist.ID, err)
+		}
+	}
+	for chatID, userIDs := range b.Auth {
+		for _, userID := range userIDs {
+			if err := db.store.AddAuthUser(ctx, chatID, userID); err != nil {
+				return fmt.Errorf("auth %d: %w", chatID, err)
+			}
+		}
+	}
+	for chatID, assistantID := range b.Assistants {
+		if err := db.store.SetAssistant(ctx, chatID, assistantID); err != nil {
+			return fmt.Errorf("assistant of %d: %w", chatID, err)
+		}
+	}
+	for chatID, code := range b.Languages {
+		if err := db.store.SetLanguage(ctx, chatID, code); err != nil {
+			return fmt.Errorf("language of %d: %w", chatID, err)
+		}
+	}
+	for kind, entries := range b.Blacklist {
+		for _, entry := range entries {
+			if err := db.store.AddBlacklisted(ctx, kind, entry); err != nil {
+				return fmt.Errorf("%s %d: %w", kind, entry.ID, err)
+			}
+		}
+	}
+	for _, like := range b.Likes {
+		if _, err := db.store.AddLike(ctx, like); err != nil {
+			return fmt.Errorf("like %s: %w", likeID(like.UserID, like.Song.TrackID), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package db
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestDatabase(t *testing.T) *Database {
+	t.Helper()
+	store, err := openBolt(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("openBolt: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close(context.Background()) })
+	return newDatabase(store)
+}
+
+func testBackup() *Backup {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	return &Backup{
+		CreatedAt:  now,
+		Chats:      []Chats{{ID: -1, PlayType: 1, AdminMode: "auth"}, {ID: -2, CmdDelete: true}},
+		Users:      []int64{1, 2},
+		Playlists:  []Playlist{{ID: "tgpl_a", Name: "A", UserID: 1, Songs: []Song{{URL: "u", Name: "n", TrackID: "t", Duration: 5, Platform: "youtube"}}}},
+		Auth:       map[int64][]int64{-1: {1, 2}},
+		Assistants: map[int64]int64{-1: 10},
+		Languages:  map[int64]string{-2: "hi"},
+		Blacklist: map[BlacklistKind][]BlacklistEntry{
+			BlacklistChats: {{ID: -3, Reason: "spam", AddedBy: 1, AddedAt: now}},
+			BlacklistUsers: {{ID: 3, AddedAt: now}},
+		},
+		Likes: []Like{{UserID: 1, Song: Song{Name: "n", TrackID: "t", Platform: "youtube"}, LikedAt: now}},
+	}
+}
+
+func TestBackupRoundTrip(t *testing.T) {
+	want := testBackup()
+
+	var buf bytes.Buffer
+	if err := WriteBackup(&buf, want); err != nil {
+		t.Fatalf("WriteBackup: %v", err)
+	}
+	got, err := ReadBackup(&buf)
+	if err != nil {
+		t.Fatalf("ReadBackup: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadBackup = %+v, want %+v", got, want)
+	}
+}
+
+func TestRestoreBackup(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDatabase(t)
+	backup := testBackup()
+
+	// Data that is not in the backup survives a merge but not a replace.
+	if err := db.store.AddUser(ctx, 99); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.store.PutChat(ctx, Chats{ID: -1, CmdDelete: true}); err != nil {
+		t.Fatal(err)
+	}
+	// Warm the cache so a stale value would be noticed.
+	if db.GetCmdDelete(-1) != true {
+		t.Fatal("setup: chat -1 should have cmd_delete on")
+	}
+
+	if err := db.RestoreBackup(ctx, backup, false); err != nil {
+		t.Fatalf("RestoreBackup(merge): %v", err)
+	}
+	if db.GetCmdDelete(-1) {
+		t.Error("merge did not replace chat -1, or the cache was not cleared")
+	}
+
+	exported := exportBackup(t, db)
+	if users := slices.Sorted(slices.Values(exported.Users)); !slices.Equal(users, []int64{1, 2, 99}) {
+		t.Errorf("users after merge = %v, want [1 2 99]", users)
+	}
+
+	if err := db.RestoreBackup(ctx, backup, true); err != nil {
+		t.Fatalf("RestoreBackup(replace): %v", err)
+	}
+	exported = exportBackup(t, db)
+	exported.CreatedAt = backup.CreatedAt
+	slices.SortFunc(exported.Chats, func(a, b Chats) int { return int(b.ID - a.ID) })
+	slices.Sort(exported.Users)
+	if !reflect.DeepEqual(exported, backup) {
+		t.Errorf("export after replace = %+v, want %+v", exported, backup)
+	}
+}
+
+// exportBackup exports db and reads the archive back, checking the counts ExportBackup reports.
+func exportBackup(t *testing.T, db *Database) *Backup {
+	t.Helper()
+
+	var buf bytes.Buffer
+	counts, err := db.ExportBackup(context.Background(), &buf, time.Now().UTC())
+	if err != nil {
+		t.Fatalf("ExportBackup: %v", err)
+	}
+	b, err := ReadBackup(&buf)
+	if err != nil {
+		t.Fatalf("ReadBackup: %v", err)
+	}
+	if counts != b.Counts() {
+		t.Errorf("ExportBackup counts = %+v, the archive has %+v", counts, b.Counts())
+	}
+	return b
+}
+
+func gzipLines(lines ...string) *bytes.Buffer {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, _ = gz.Write([]byte(strings.Join(lines, "\n")))
+	_ = gz.Close()
+	return &buf
+}
+
+func TestReadBackupInvalid(t *testing.T) {
+	header := `{"type":"header","data":{"version":1,"created_at":"2026-01-01T00:00:00Z"}}`
+	tests := []struct {
+		name  string
+		input *bytes.Buffer
+	}{
+		{"not gzip", bytes.NewBufferString(`{"type":"header"}`)},
+		{"empty", gzipLines()},
+		{"no header", gzipLines(`{"type":"user","data":1}`)},
+		{"future version", gzipLines(`{"type":"header","data":{"version":99}}`)},
+		{"unknown type", gzipLines(header, `{"type":"song","data":{}}`)},
+		{"second header", gzipLines(header, header)},
+		{"zero chat", gzipLines(header, `{"type":"chat","data":{"id":0}}`)},
+		{"bad user", gzipLines(header, `{"type":"user","data":"one"}`)},
+		{"playlist without owner", gzipLines(header, `{"type":"playlist","data":{"id":"tgpl_a"}}`)},
+		{"unknown blacklist", gzipLines(header, `{"type":"blacklist","data":{"kind":"bl_songs","entry":{"id":1}}}`)},
+		{"like without track", gzipLines(header, `{"type":"like","data":{"user_id":1,"song":{}}}`)},
+		{"truncated", gzipLines(header, `{"type":"user","data":`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadBackup(tt.input); err == nil {
+				t.Error("ReadBackup succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestReadBackupDuplicates(t *testing.T) {
+	b, err := ReadBackup(gzipLines(
+		`{"type":"header","data":{"version":1}}`,
+		`{"type":"chat","data":{"id":-1,"play_type":0}}`,
+		`{"type":"user","data":5}`,
+		`{"type":"chat","data":{"id":-1,"play_type":1}}`,
+		`{"type":"user","data":5}`,
+		`{"type":"playlist","data":{"id":"tgpl_a","user_id":1}}`,
+	))
+	if err != nil {
+		t.Fatalf("ReadBackup: %v", err)
+	}
+
+	counts := b.Counts()
+	if counts.Chats != 1 || counts.Users != 1 || counts.Playlists != 1 {
+		t.Errorf("Counts = %+v, want one chat, user and playlist", counts)
+	}
+	if b.Chats[0].PlayType != 1 {
+		t.Error("the later chat record did not win")
+	}
+	if b.Playlists[0].Songs == nil {
+		t.Error("a playlist without songs should get an empty list")
+	}
+}
+
+func TestReadBackupTooLarge(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteBackup(&buf, testBackup()); err != nil {
+		t.Fatalf("WriteBackup: %v", err)
+	}
+	archive := buf.Bytes()
+
+	defer func(limit int64) { maxBackupData = limit }(maxBackupData)
+	maxBackupData = 64
+	if _, err := ReadBackup(bytes.NewReader(archive)); err == nil || !strings.Contains(err.Error(), "decompresses to more than") {
+		t.Errorf("ReadBackup over the limit: err = %v, want a size error", err)
+	}
+
+	maxBackupData = 1 << 20
+	if _, err := ReadBackup(bytes.NewReader(archive)); err != nil {
+		t.Errorf("ReadBackup under the limit: %v", err)
+	}
+}
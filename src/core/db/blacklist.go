@@ -9,20 +9,21 @@
 package db
 
 import (
-	"go.mongodb.org/mongo-driver/v2/bson"
-	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"slices"
+	"time"
 )
 
-// AddBlacklistedChat adds a chat to the blacklist.
-func (db *Database) AddBlacklistedChat(chatID int64) error {
-	ctx, cancel := db.ctx()
-	defer cancel()
+// BlacklistEntry records why and when a chat or user was blacklisted.
+type BlacklistEntry struct {
+	ID      int64     `json:"id" bson:"id"`
+	Reason  string    `json:"reason" bson:"reason"`
+	AddedBy int64     `json:"added_by" bson:"added_by"`
+	AddedAt time.Time `json:"added_at" bson:"added_at"`
+}
 
-	_, err := db.cacheDB.UpdateOne(ctx,
-		bson.M{"_id": "bl_chats"},
-		bson.M{"$addToSet": bson.M{"chat_ids": chatID}},
-		options.UpdateOne().SetUpsert(true),
-	)
+// AddBlacklistedChat adds a chat to the blacklist.
+func (db *Database) AddBlacklistedChat(chatID, addedBy int64, reason string) error {
+	err := db.addBlacklistEntry(BlacklistChats, chatID, addedBy, reason)
 	if err == nil {
 		db.blChatsCache.Delete("bl_chats")
 	}
@@ -31,13 +32,7 @@ func (db *Database) AddBlacklistedChat(chatID int64) error {
 
 // RemoveBlacklistedChat removes a chat from the blacklist.
 func (db *Database) RemoveBlacklistedChat(chatID int64) error {
-	ctx, cancel := db.ctx()
-	defer cancel()
-
-	_, err := db.cacheDB.UpdateOne(ctx,
-		bson.M{"_id": "bl_chats"},
-		bson.M{"$pull": bson.M{"chat_ids": chatID}},
-	)
+	err := db.removeBlacklistEntry(BlacklistChats, chatID)
 	if err == nil {
 		db.blChatsCache.Delete("bl_chats")
 	}
@@ -49,19 +44,18 @@ func (db *Database) GetBlacklistedChats() []int64 {
 	if cached, ok := db.blChatsCache.Get("bl_chats"); ok {
 		return cached
 	}
-	var doc struct {
-		ChatIDs []int64 `bson:"chat_ids"`
-	}
-
-	ctx, cancel := db.ctx()
-	defer cancel()
 
-	err := db.cacheDB.FindOne(ctx, bson.M{"_id": "bl_chats"}).Decode(&doc)
+	chats, err := db.getBlacklistIDs(BlacklistChats)
 	if err != nil {
 		return []int64{}
 	}
-	db.blChatsCache.Set("bl_chats", doc.ChatIDs)
-	return doc.ChatIDs
+	db.blChatsCache.Set("bl_chats", chats)
+	return chats
+}
+
+// GetBlacklistedChatEntries returns the blacklisted chats with their reasons, oldest first.
+func (db *Database) GetBlacklistedChatEntries() ([]BlacklistEntry, error) {
+	return db.getBlacklistEntries(BlacklistChats)
 }
 
 // IsBlacklistedChat checks if a chat is blacklisted.
@@ -71,15 +65,8 @@ func (db *Database) IsBlacklistedChat(chatID int64) bool {
 }
 
 // AddBlacklistedUser adds a user to the blacklist.
-func (db *Database) AddBlacklistedUser(userID int64) error {
-	ctx, cancel := db.ctx()
-	defer cancel()
-
-	_, err := db.cacheDB.UpdateOne(ctx,
-		bson.M{"_id": "bl_users"},
-		bson.M{"$addToSet": bson.M{"user_ids": userID}},
-		options.UpdateOne().SetUpsert(true),
-	)
+func (db *Database) AddBlacklistedUser(userID, addedBy int64, reason string) error {
+	err := db.addBlacklistEntry(BlacklistUsers, userID, addedBy, reason)
 	if err == nil {
 		db.blUsersCache.Delete("bl_users")
 	}
@@ -88,13 +75,7 @@ func (db *Database) AddBlacklistedUser(userID int64) error {
 
 // RemoveBlacklistedUser removes a user from the blacklist.
 func (db *Database) RemoveBlacklistedUser(userID int64) error {
-	ctx, cancel := db.ctx()
-	defer cancel()
-
-	_, err := db.cacheDB.UpdateOne(ctx,
-		bson.M{"_id": "bl_users"},
-		bson.M{"$pull": bson.M{"user_ids": userID}},
-	)
+	err := db.removeBlacklistEntry(BlacklistUsers, userID)
 	if err == nil {
 		db.blUsersCache.Delete("bl_users")
 	}
@@ -106,19 +87,18 @@ func (db *Database) GetBlacklistedUsers() []int64 {
 	if cached, ok := db.blUsersCache.Get("bl_users"); ok {
 		return cached
 	}
-	var doc struct {
-		UserIDs []int64 `bson:"user_ids"`
-	}
-	
-	ctx, cancel := db.ctx()
-	defer cancel()
 
-	err := db.cacheDB.FindOne(ctx, bson.M{"_id": "bl_users"}).Decode(&doc)
+	users, err := db.getBlacklistIDs(BlacklistUsers)
 	if err != nil {
 		return []int64{}
 	}
-	db.blUsersCache.Set("bl_users", doc.UserIDs)
-	return doc.UserIDs
+	db.blUsersCache.Set("bl_users", users)
+	return users
+}
+
+// GetBlacklistedUserEntries returns the blacklisted users with their reasons, oldest first.
+func (db *Database) GetBlacklistedUserEntries() ([]BlacklistEntry, error) {
+	return db.getBlacklistEntries(BlacklistUsers)
 }
 
 // IsBlacklistedUser checks if a user is blacklisted.
@@ -126,3 +106,51 @@ func (db *Database) IsBlacklistedUser(userID int64) bool {
 	users := db.GetBlacklistedUsers()
 	return contains(users, userID)
 }
+
+func (db *Database) addBlacklistEntry(kind BlacklistKind, id, addedBy int64, reason string) error {
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	entry := BlacklistEntry{ID: id, Reason: reason, AddedBy: addedBy, AddedAt: time.Now()}
+	return db.store.AddBlacklisted(ctx, kind, entry)
+}
+
+func (db *Database) removeBlacklistEntry(kind BlacklistKind, id int64) error {
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	return db.store.RemoveBlacklisted(ctx, kind, id)
+}
+
+// getBlacklistIDs returns the IDs on a blacklist.
+func (db *Database) getBlacklistIDs(kind BlacklistKind) ([]int64, error) {
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	entries, err := db.store.GetBlacklisted(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+	}
+	return ids, nil
+}
+
+// getBlacklistEntries lists a blacklist, oldest first. IDs added before details were stored sort first.
+func (db *Database) getBlacklistEntries(kind BlacklistKind) ([]BlacklistEntry, error) {
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	entries, err := db.store.GetBlacklisted(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(entries, func(a, b BlacklistEntry) int {
+		return a.AddedAt.Compare(b.AddedAt)
+	})
+	return entries, nil
+}
@@ -0,0 +1,836 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package db
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Buckets of the bolt backend. They mirror the MongoDB collections.
+const (
+	chatsBucket     = "chats"
+	usersBucket     = "users"
+	playlistsBucket = "playlists"
+	assistantBucket = "assistant"
+	authBucket      = "auth"
+	langBucket      = "lang"
+	cacheBucket     = "cache"
+	settingsBucket  = "settings"
+	sessionsBucket  = "sessions"
+	likesBucket     = "likes"
+)
+
+var boltBuckets = []string{
+	chatsBucket, usersBucket, playlistsBucket, assistantBucket, authBucket,
+	langBucket, cacheBucket, settingsBucket, sessionsBucket, likesBucket,
+}
+
+// boltStore is the embedded Storage backend. It keeps everything in a single BoltDB file, one bucket per
+// collection, with records encoded as BSON so they share the MongoDB document layout.
+// Operations are local and fast, so the context is not consulted.
+type boltStore struct {
+	db *bbolt.DB
+}
+
+// openBolt opens or creates the database file at path.
+func openBolt(path string) (*boltStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return nil, fmt.Errorf("failed to create the database directory: %w", err)
+	}
+
+	db, err := bbolt.Open(path, 0600, &bbolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		for _, name := range boltBuckets {
+			if _, err := tx.CreateBucketIfNotExists([]byte(name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to create buckets: %w", err)
+	}
+	return &boltStore{db: db}, nil
+}
+
+func (s *boltStore) Ping(context.Context) error {
+	return s.db.View(func(*bbolt.Tx) error { return nil })
+}
+
+func (s *boltStore) Close(context.Context) error {
+	return s.db.Close()
+}
+
+func idKey(id int64) []byte {
+	return []byte(strconv.FormatInt(id, 10))
+}
+
+// getDoc decodes the record at key into v, returning ErrNotFound if there is none.
+func getDoc(tx *bbolt.Tx, bucket string, key []byte, v any) error {
+	data := tx.Bucket([]byte(bucket)).Get(key)
+	if data == nil {
+		return ErrNotFound
+	}
+	return bson.Unmarshal(data, v)
+}
+
+func putDoc(tx *bbolt.Tx, bucket string, key []byte, v any) error {
+	data, err := bson.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket([]byte(bucket)).Put(key, data)
+}
+
+func deleteDoc(tx *bbolt.Tx, bucket string, key []byte) error {
+	return tx.Bucket([]byte(bucket)).Delete(key)
+}
+
+// view decodes the record at key into v in a read-only transaction.
+func (s *boltStore) view(bucket string, key []byte, v any) error {
+	return s.db.View(func(tx *bbolt.Tx) error {
+		return getDoc(tx, bucket, key, v)
+	})
+}
+
+// updateDoc loads the record at key into a T, zero if there is none, applies fn and stores the result.
+func updateDoc[T any](s *boltStore, bucket string, key []byte, fn func(doc *T) error) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		var doc T
+		if err := getDoc(tx, bucket, key, &doc); err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+		if err := fn(&doc); err != nil {
+			return err
+		}
+		return putDoc(tx, bucket, key, &doc)
+	})
+}
+
+// eachDoc decodes every record of a bucket into a T and passes it to fn.
+func eachDoc[T any](s *boltStore, bucket string, fn func(key []byte, doc *T) error) error {
+	return s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte(bucket)).ForEach(func(k, v []byte) error {
+			var doc T
+			if err := bson.Unmarshal(v, &doc); err != nil {
+				return fmt.Errorf("%s/%s: %w", bucket, k, err)
+			}
+			return fn(k, &doc)
+		})
+	})
+}
+
+func (s *boltStore) GetChat(_ context.Context, chatID int64) (*Chats, error) {
+	var chat Chats
+	if err := s.view(chatsBucket, idKey(chatID), &chat); err != nil {
+		return nil, err
+	}
+	return &chat, nil
+}
+
+func (s *boltStore) AddChat(_ context.Context, chatID int64) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		if tx.Bucket([]byte(chatsBucket)).Get(idKey(chatID)) != nil {
+			return nil
+		}
+		return putDoc(tx, chatsBucket, idKey(chatID), bson.M{"_id": chatID})
+	})
+}
+
+// SetChatField updates the stored document field by field, so fields this version does not know about are kept.
+func (s *boltStore) SetChatField(_ context.Context, chatID int64, field string, value any) error {
+	return updateDoc(s, chatsBucket, idKey(chatID), func(doc *bson.M) error {
+		if *doc == nil {
+			*doc = bson.M{}
+		}
+		(*doc)["_id"] = chatID
+		(*doc)[field] = value
+		return nil
+	})
+}
+
+func (s *boltStore) ListChats(context.Context) ([]Chats, error) {
+	var chats []Chats
+	err := eachDoc(s, chatsBucket, func(_ []byte, chat *Chats) error {
+		chats = append(chats, *chat)
+		return nil
+	})
+	return chats, err
+}
+
+func (s *boltStore) AddUser(_ context.Context, userID int64) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return putDoc(tx, usersBucket, idKey(userID), Users{ID: userID})
+	})
+}
+
+func (s *boltStore) RemoveUser(_ context.Context, userID int64) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return deleteDoc(tx, usersBucket, idKey(userID))
+	})
+}
+
+func (s *boltStore) HasUser(_ context.Context, userID int64) (bool, error) {
+	var found bool
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		found = tx.Bucket([]byte(usersBucket)).Get(idKey(userID)) != nil
+		return nil
+	})
+	return found, err
+}
+
+func (s *boltStore) ListUsers(context.Context) ([]int64, error) {
+	var users []int64
+	err := eachDoc(s, usersBucket, func(_ []byte, user *Users) error {
+		users = append(users, user.ID)
+		return nil
+	})
+	return users, err
+}
+
+func (s *boltStore) InsertPlaylist(_ context.Context, playlist Playlist) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		if tx.Bucket([]byte(playlistsBucket)).Get([]byte(playlist.ID)) != nil {
+			return fmt.Errorf("playlist %s already exists", playlist.ID)
+		}
+		return putDoc(tx, playlistsBucket, []byte(playlist.ID), playlist)
+	})
+}
+
+func (s *boltStore) GetPlaylist(_ context.Context, id string) (*Playlist, error) {
+	var playlist Playlist
+	if err := s.view(playlistsBucket, []byte(id), &playlist); err != nil {
+		return nil, err
+	}
+	return &playlist, nil
+}
+
+func (s *boltStore) DeletePlaylist(_ context.Context, id string, userID int64) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		var playlist Playlist
+		if err := getDoc(tx, playlistsBucket, []byte(id), &playlist); err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return nil
+			}
+			return err
+		}
+		if playlist.UserID != userID {
+			return nil
+		}
+		return deleteDoc(tx, playlistsBucket, []byte(id))
+	})
+}
+
+// updatePlaylist applies fn to an existing playlist. Like MongoDB's UpdateOne, a missing playlist is not an error.
+func (s *boltStore) updatePlaylist(id string, fn func(playlist *Playlist)) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		var playlist Playlist
+		if err := getDoc(tx, playlistsBucket, []byte(id), &playlist); err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return nil
+			}
+			return err
+		}
+		fn(&playlist)
+		return putDoc(tx, playlistsBucket, []byte(id), playlist)
+	})
+}
+
+func (s *boltStore) AddPlaylistSong(_ context.Context, id string, song Song) error {
+	return s.updatePlaylist(id, func(playlist *Playlist) {
+		playlist.Songs = append(playlist.Songs, song)
+		playlist.Version++
+	})
+}
+
+func (s *boltStore) RemovePlaylistSong(_ context.Context, id string, trackID string) error {
+	return s.updatePlaylist(id, func(playlist *Playlist) {
+		playlist.Songs = slices.DeleteFunc(playlist.Songs, func(song Song) bool {
+			return song.TrackID == trackID
+		})
+		playlist.Version++
+	})
+}
+
+func (s *boltStore) RenamePlaylist(_ context.Context, id string, name string) error {
+	return s.updatePlaylist(id, func(playlist *Playlist) {
+		playlist.Name = name
+	})
+}
+
+func (s *boltStore) SetPlaylistSongs(_ context.Context, id string, version int64, songs []Song) (bool, error) {
+	saved := false
+	err := s.updatePlaylist(id, func(playlist *Playlist) {
+		if playlist.Version != version {
+			return
+		}
+		playlist.Songs = songs
+		playlist.Version++
+		saved = true
+	})
+	return saved, err
+}
+
+func (s *boltStore) ListUserPlaylists(_ context.Context, userID int64) ([]Playlist, error) {
+	var playlists []Playlist
+	err := eachDoc(s, playlistsBucket, func(_ []byte, playlist *Playlist) error {
+		if playlist.UserID == userID {
+			playlists = append(playlists, *playlist)
+		}
+		return nil
+	})
+	return playlists, err
+}
+
+func (s *boltStore) ListChatPlaylists(_ context.Context, chatID int64) ([]Playlist, error) {
+	var playlists []Playlist
+	err := eachDoc(s, playlistsBucket, func(_ []byte, playlist *Playlist) error {
+		if playlist.ChatID == chatID {
+			playlists = append(playlists, *playlist)
+		}
+		return nil
+	})
+	return playlists, err
+}
+
+func (s *boltStore) SearchPublicPlaylists(_ context.Context, query string, limit int) ([]Playlist, error) {
+	query = strings.ToLower(query)
+	var playlists []Playlist
+	err := eachDoc(s, playlistsBucket, func(_ []byte, playlist *Playlist) error {
+		if playlist.Visibility == VisibilityPublic && strings.Contains(strings.ToLower(playlist.Name), query) {
+			playlists = append(playlists, *playlist)
+		}
+		return nil
+	})
+	slices.SortFunc(playlists, func(a, b Playlist) int { return strings.Compare(a.Name, b.Name) })
+	return playlists[:min(len(playlists), limit)], err
+}
+
+func (s *boltStore) SetPlaylistVisibility(_ context.Context, id string, visibility PlaylistVisibility) error {
+	return s.updatePlaylist(id, func(playlist *Playlist) {
+		playlist.Visibility = visibility
+	})
+}
+
+func (s *boltStore) AddPlaylistCollaborator(_ context.Context, id string, userID int64) error {
+	return s.updatePlaylist(id, func(playlist *Playlist) {
+		if !slices.Contains(playlist.Collaborators, userID) {
+			playlist.Collaborators = append(playlist.Collaborators, userID)
+		}
+	})
+}
+
+func (s *boltStore) RemovePlaylistCollaborator(_ context.Context, id string, userID int64) error {
+	return s.updatePlaylist(id, func(playlist *Playlist) {
+		playlist.Collaborators = slices.DeleteFunc(playlist.Collaborators, func(id int64) bool {
+			return id == userID
+		})
+	})
+}
+
+type boltAssistant struct {
+	AssistantID int64 `bson:"assistant_id"`
+}
+
+func (s *boltStore) GetAssistant(_ context.Context, chatID int64) (int64, error) {
+	var doc boltAssistant
+	err := s.view(assistantBucket, idKey(chatID), &doc)
+	if errors.Is(err, ErrNotFound) {
+		return 0, nil
+	}
+	return doc.AssistantID, err
+}
+
+func (s *boltStore) SetAssistant(_ context.Context, chatID, assistantID int64) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return putDoc(tx, assistantBucket, idKey(chatID), boltAssistant{AssistantID: assistantID})
+	})
+}
+
+func (s *boltStore) RemoveAssistant(_ context.Context, chatID int64) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return deleteDoc(tx, assistantBucket, idKey(chatID))
+	})
+}
+
+func (s *boltStore) AssignAssistant(_ context.Context, chatID, proposed int64) (int64, error) {
+	assigned := proposed
+	err := updateDoc(s, assistantBucket, idKey(chatID), func(doc *boltAssistant) error {
+		if doc.AssistantID != 0 {
+			assigned = doc.AssistantID
+		} else {
+			doc.AssistantID = proposed
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return assigned, nil
+}
+
+// deleteAssistants removes the assignments matching fn and returns how many there were.
+func (s *boltStore) deleteAssistants(fn func(assistantID int64) bool) (int64, error) {
+	var deleted int64
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(assistantBucket))
+		var keys [][]byte
+		err := bucket.ForEach(func(k, v []byte) error {
+			var doc boltAssistant
+			if err := bson.Unmarshal(v, &doc); err != nil {
+				return err
+			}
+			if fn(doc.AssistantID) {
+				keys = append(keys, slices.Clone(k))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, k := range keys {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+		deleted = int64(len(keys))
+		return nil
+	})
+	return deleted, err
+}
+
+func (s *boltStore) UnassignAssistant(_ context.Context, assistantID int64) (int64, error) {
+	return s.deleteAssistants(func(id int64) bool { return id == assistantID })
+}
+
+func (s *boltStore) CountAssistantChats(context.Context) (map[int64]int64, error) {
+	counts := make(map[int64]int64)
+	err := eachDoc(s, assistantBucket, func(_ []byte, doc *boltAssistant) error {
+		if doc.AssistantID > 0 {
+			counts[doc.AssistantID]++
+		}
+		return nil
+	})
+	return counts, err
+}
+
+func (s *boltStore) ListAssistantChats(_ context.Context, assistantID int64) ([]int64, error) {
+	chats := []int64{}
+	err := eachDoc(s, assistantBucket, func(k []byte, doc *boltAssistant) error {
+		if doc.AssistantID != assistantID {
+			return nil
+		}
+		chatID, err := strconv.ParseInt(string(k), 10, 64)
+		if err != nil {
+			return err
+		}
+		chats = append(chats, chatID)
+		return nil
+	})
+	return chats, err
+}
+
+func (s *boltStore) ClearAssistants(context.Context) (int64, error) {
+	return s.deleteAssistants(func(int64) bool { return true })
+}
+
+// boltIDList is a record holding a set of IDs, used for auth users and sudoers.
+type boltIDList struct {
+	UserIDs []int64 `bson:"user_ids"`
+}
+
+func addID(ids []int64, id int64) []int64 {
+	if slices.Contains(ids, id) {
+		return ids
+	}
+	return append(ids, id)
+}
+
+func removeID(ids []int64, id int64) []int64 {
+	return slices.DeleteFunc(ids, func(v int64) bool { return v == id })
+}
+
+func (s *boltStore) AddAuthUser(_ context.Context, chatID, userID int64) error {
+	return updateDoc(s, authBucket, idKey(chatID), func(doc *boltIDList) error {
+		doc.UserIDs = addID(doc.UserIDs, userID)
+		return nil
+	})
+}
+
+func (s *boltStore) RemoveAuthUser(_ context.Context, chatID, userID int64) error {
+	return updateDoc(s, authBucket, idKey(chatID), func(doc *boltIDList) error {
+		doc.UserIDs = removeID(doc.UserIDs, userID)
+		return nil
+	})
+}
+
+func (s *boltStore) GetAuthUsers(_ context.Context, chatID int64) ([]int64, error) {
+	var doc boltIDList
+	err := s.view(authBucket, idKey(chatID), &doc)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	return doc.UserIDs, err
+}
+
+type boltLang struct {
+	Lang string `bson:"lang"`
+}
+
+func (s *boltStore) GetLanguage(_ context.Context, chatID int64) (string, error) {
+	var doc boltLang
+	if err := s.view(langBucket, idKey(chatID), &doc); err != nil {
+		return "", err
+	}
+	return doc.Lang, nil
+}
+
+func (s *boltStore) SetLanguage(_ context.Context, chatID int64, langCode string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return putDoc(tx, langBucket, idKey(chatID), boltLang{Lang: langCode})
+	})
+}
+
+// boltBlacklist keeps the IDs in the order they were added, with their details keyed by ID.
+type boltBlacklist struct {
+	IDs     []int64                   `bson:"ids"`
+	Entries map[string]BlacklistEntry `bson:"entries"`
+}
+
+func (s *boltStore) AddBlacklisted(_ context.Context, kind BlacklistKind, entry BlacklistEntry) error {
+	return updateDoc(s, cacheBucket, []byte(kind), func(doc *boltBlacklist) error {
+		doc.IDs = addID(doc.IDs, entry.ID)
+		if doc.Entries == nil {
+			doc.Entries = make(map[string]BlacklistEntry)
+		}
+		doc.Entries[strconv.FormatInt(entry.ID, 10)] = entry
+		return nil
+	})
+}
+
+func (s *boltStore) RemoveBlacklisted(_ context.Context, kind BlacklistKind, id int64) error {
+	return updateDoc(s, cacheBucket, []byte(kind), func(doc *boltBlacklist) error {
+		doc.IDs = removeID(doc.IDs, id)
+		delete(doc.Entries, strconv.FormatInt(id, 10))
+		return nil
+	})
+}
+
+func (s *boltStore) GetBlacklisted(_ context.Context, kind BlacklistKind) ([]BlacklistEntry, error) {
+	var doc boltBlacklist
+	err := s.view(cacheBucket, []byte(kind), &doc)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	entries := make([]BlacklistEntry, 0, len(doc.IDs))
+	for _, id := range doc.IDs {
+		entry, ok := doc.Entries[strconv.FormatInt(id, 10)]
+		if !ok {
+			entry = BlacklistEntry{ID: id}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Likes are keyed "userID:trackID", so one user's likes are adjacent and can be read with a prefix scan.
+func (s *boltStore) AddLike(_ context.Context, like Like) (bool, error) {
+	added := false
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		key := []byte(likeID(like.UserID, like.Song.TrackID))
+		if tx.Bucket([]byte(likesBucket)).Get(key) != nil {
+			return nil
+		}
+		added = true
+		return putDoc(tx, likesBucket, key, like)
+	})
+	return added, err
+}
+
+func (s *boltStore) RemoveLike(_ context.Context, userID int64, trackID string) (bool, error) {
+	removed := false
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		key := []byte(likeID(userID, trackID))
+		if tx.Bucket([]byte(likesBucket)).Get(key) == nil {
+			return nil
+		}
+		removed = true
+		return deleteDoc(tx, likesBucket, key)
+	})
+	return removed, err
+}
+
+func (s *boltStore) ListLikes(_ context.Context, userID int64) ([]Like, error) {
+	var likes []Like
+	prefix := []byte(likeID(userID, ""))
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		c := tx.Bucket([]byte(likesBucket)).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			var like Like
+			if err := bson.Unmarshal(v, &like); err != nil {
+				return fmt.Errorf("%s/%s: %w", likesBucket, k, err)
+			}
+			likes = append(likes, like)
+		}
+		return nil
+	})
+	slices.SortStableFunc(likes, func(a, b Like) int {
+		return b.LikedAt.Compare(a.LikedAt)
+	})
+	return likes, err
+}
+
+func (s *boltStore) CountLikes(_ context.Context, trackID string) (int, error) {
+	count := 0
+	err := eachDoc(s, likesBucket, func(_ []byte, like *Like) error {
+		if like.Song.TrackID == trackID {
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+func (s *boltStore) TopLikes(_ context.Context, limit int) ([]SongLikes, error) {
+	index := make(map[string]int)
+	var top []SongLikes
+	err := eachDoc(s, likesBucket, func(_ []byte, like *Like) error {
+		i, ok := index[like.Song.TrackID]
+		if !ok {
+			i = len(top)
+			index[like.Song.TrackID] = i
+			top = append(top, SongLikes{Song: like.Song})
+		}
+		top[i].Count++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(top, func(a, b SongLikes) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return strings.Compare(a.Song.TrackID, b.Song.TrackID)
+	})
+	return top[:min(limit, len(top))], nil
+}
+
+type boltLogger struct {
+	Status bool `bson:"status"`
+}
+
+func (s *boltStore) GetLoggerStatus(context.Context) (bool, error) {
+	var doc boltLogger
+	err := s.view(cacheBucket, []byte("logger"), &doc)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	return doc.Status, err
+}
+
+func (s *boltStore) SetLoggerStatus(_ context.Context, status bool) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return putDoc(tx, cacheBucket, []byte("logger"), boltLogger{Status: status})
+	})
+}
+
+func (s *boltStore) GetSudoers(context.Context) ([]int64, error) {
+	var doc boltIDList
+	err := s.view(cacheBucket, []byte("sudoers"), &doc)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	return doc.UserIDs, err
+}
+
+func (s *boltStore) AddSudo(_ context.Context, userID int64) error {
+	return updateDoc(s, cacheBucket, []byte("sudoers"), func(doc *boltIDList) error {
+		doc.UserIDs = addID(doc.UserIDs, userID)
+		return nil
+	})
+}
+
+func (s *boltStore) RemoveSudo(_ context.Context, userID int64) error {
+	return updateDoc(s, cacheBucket, []byte("sudoers"), func(doc *boltIDList) error {
+		doc.UserIDs = removeID(doc.UserIDs, userID)
+		return nil
+	})
+}
+
+type boltSetting struct {
+	Value string `bson:"value"`
+}
+
+func (s *boltStore) GetSettings(context.Context) (map[string]string, error) {
+	settings := make(map[string]string)
+	err := eachDoc(s, settingsBucket, func(k []byte, doc *boltSetting) error {
+		settings[string(k)] = doc.Value
+		return nil
+	})
+	return settings, err
+}
+
+func (s *boltStore) SetSetting(_ context.Context, key, value string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return putDoc(tx, settingsBucket, []byte(key), boltSetting{Value: value})
+	})
+}
+
+func (s *boltStore) DeleteSetting(_ context.Context, key string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return deleteDoc(tx, settingsBucket, []byte(key))
+	})
+}
+
+func (s *boltStore) SaveAssistantSession(_ context.Context, session AssistantSession) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return putDoc(tx, sessionsBucket, idKey(session.ID), session)
+	})
+}
+
+func (s *boltStore) RemoveAssistantSession(_ context.Context, assistantID int64) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return deleteDoc(tx, sessionsBucket, idKey(assistantID))
+	})
+}
+
+func (s *boltStore) HasAssistantSession(_ context.Context, assistantID int64) (bool, error) {
+	var found bool
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		found = tx.Bucket([]byte(sessionsBucket)).Get(idKey(assistantID)) != nil
+		return nil
+	})
+	return found, err
+}
+
+func (s *boltStore) ListAssistantSessions(context.Context) ([]AssistantSession, error) {
+	var sessions []AssistantSession
+	err := eachDoc(s, sessionsBucket, func(_ []byte, session *AssistantSession) error {
+		sessions = append(sessions, *session)
+		return nil
+	})
+	return sessions, err
+}
+
+func (s *boltStore) ListPlaylists(context.Context) ([]Playlist, error) {
+	var playlists []Playlist
+	err := eachDoc(s, playlistsBucket, func(_ []byte, playlist *Playlist) error {
+		playlists = append(playlists, *playlist)
+		return nil
+	})
+	return playlists, err
+}
+
+func (s *boltStore) ListAssignments(context.Context) (map[int64]int64, error) {
+	assignments := make(map[int64]int64)
+	err := eachDoc(s, assistantBucket, func(k []byte, doc *boltAssistant) error {
+		if doc.AssistantID <= 0 {
+			return nil
+		}
+		chatID, err := strconv.ParseInt(string(k), 10, 64)
+		if err != nil {
+			return err
+		}
+		assignments[chatID] = doc.AssistantID
+		return nil
+	})
+	return assignments, err
+}
+
+func (s *boltStore) ListAuth(context.Context) (map[int64][]int64, error) {
+	auth := make(map[int64][]int64)
+	err := eachDoc(s, authBucket, func(k []byte, doc *boltIDList) error {
+		if len(doc.UserIDs) == 0 {
+			return nil
+		}
+		chatID, err := strconv.ParseInt(string(k), 10, 64)
+		if err != nil {
+			return err
+		}
+		auth[chatID] = doc.UserIDs
+		return nil
+	})
+	return auth, err
+}
+
+func (s *boltStore) ListLanguages(context.Context) (map[int64]string, error) {
+	langs := make(map[int64]string)
+	err := eachDoc(s, langBucket, func(k []byte, doc *boltLang) error {
+		chatID, err := strconv.ParseInt(string(k), 10, 64)
+		if err != nil {
+			return err
+		}
+		langs[chatID] = doc.Lang
+		return nil
+	})
+	return langs, err
+}
+
+func (s *boltStore) ListAllLikes(context.Context) ([]Like, error) {
+	var likes []Like
+	err := eachDoc(s, likesBucket, func(_ []byte, like *Like) error {
+		likes = append(likes, *like)
+		return nil
+	})
+	return likes, err
+}
+
+func (s *boltStore) PutChat(_ context.Context, chat Chats) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return putDoc(tx, chatsBucket, idKey(chat.ID), chat)
+	})
+}
+
+func (s *boltStore) PutPlaylist(_ context.Context, playlist Playlist) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return putDoc(tx, playlistsBucket, []byte(playlist.ID), playlist)
+	})
+}
+
+func (s *boltStore) ClearBackupData(context.Context) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		for _, name := range []string{chatsBucket, usersBucket, playlistsBucket, assistantBucket, authBucket, langBucket, likesBucket} {
+			if err := tx.DeleteBucket([]byte(name)); err != nil {
+				return err
+			}
+			if _, err := tx.CreateBucket([]byte(name)); err != nil {
+				return err
+			}
+		}
+		for _, kind := range []BlacklistKind{BlacklistChats, BlacklistUsers} {
+			if err := deleteDoc(tx, cacheBucket, []byte(kind)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
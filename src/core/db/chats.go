@@ -14,19 +14,17 @@ import (
 	"errors"
 	"log/slog"
 	"time"
-
-	"go.mongodb.org/mongo-driver/v2/bson"
-	"go.mongodb.org/mongo-driver/v2/mongo"
-	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
 // Chats represents a chat document in the database.
 type Chats struct {
-	ID        int64  `bson:"_id"`
-	PlayType  int    `bson:"play_type"`
-	AdminPlay bool   `bson:"admin_play"`
-	AdminMode string `bson:"admin_mode"`
-	CmdDelete bool   `bson:"cmd_delete"`
+	ID           int64              `json:"id" bson:"_id"`
+	PlayType     int                `json:"play_type" bson:"play_type"`
+	AdminPlay    bool               `json:"admin_play" bson:"admin_play"`
+	AdminMode    string             `json:"admin_mode" bson:"admin_mode"`
+	CmdDelete    bool               `json:"cmd_delete" bson:"cmd_delete"`
+	VideoQuality utils.VideoQuality `json:"video_quality" bson:"video_quality"`
+	AudioProfile string             `json:"audio_profile" bson:"audio_profile"`
 }
 
 // getChat retrieves a chat's data from the cache or database.
@@ -36,18 +34,18 @@ func (db *Database) getChat(chatID int64) (*Chats, error) {
 		return cached, nil
 	}
 
-	var chat Chats
+	var chat *Chats
 	var err error
 
 	ctx, cancel := db.ctx()
 	defer cancel()
 
 	for i := 0; i < 3; i++ {
-		err = db.chatDB.FindOne(ctx, bson.M{"_id": chatID}).Decode(&chat)
+		chat, err = db.store.GetChat(ctx, chatID)
 		if err == nil {
 			break
 		}
-		if errors.Is(err, mongo.ErrNoDocuments) {
+		if errors.Is(err, ErrNotFound) {
 			return nil, nil
 		}
 
@@ -62,8 +60,8 @@ func (db *Database) getChat(chatID int64) (*Chats, error) {
 		return nil, err
 	}
 
-	db.chatCache.Set(key, &chat)
-	return &chat, nil
+	db.chatCache.Set(key, chat)
+	return chat, nil
 }
 
 // AddChat adds a new chat to the database if it does not already exist.
@@ -76,7 +74,7 @@ func (db *Database) AddChat(chatID int64) error {
 	ctx, cancel := db.ctx()
 	defer cancel()
 
-	_, err := db.chatDB.UpdateOne(ctx, bson.M{"_id": chatID}, bson.M{"$setOnInsert": bson.M{}}, options.UpdateOne().SetUpsert(true))
+	err := db.store.AddChat(ctx, chatID)
 	if err == nil {
 		slog.Info("[DB] A new chat has been added", "id", chatID)
 	}
@@ -94,14 +92,7 @@ func (db *Database) GetPlayType(chatID int64) int {
 
 // SetPlayType sets the play type for a given chat.
 func (db *Database) SetPlayType(chatID int64, playType int) error {
-	ctx, cancel := db.ctx()
-	defer cancel()
-
-	_, err := db.chatDB.UpdateOne(ctx, bson.M{"_id": chatID}, bson.M{"$set": bson.M{"play_type": playType}}, options.UpdateOne().SetUpsert(true))
-	if err == nil {
-		db.chatCache.Delete(toKey(chatID))
-	}
-	return err
+	return db.setChatField(chatID, chatPlayType, playType)
 }
 
 // GetPlayMode retrieves the play mode for a chat.
@@ -115,14 +106,7 @@ func (db *Database) GetPlayMode(chatID int64) bool {
 
 // SetPlayMode sets the play mode for a given chat.
 func (db *Database) SetPlayMode(chatID int64, adminPlay bool) error {
-	ctx, cancel := db.ctx()
-	defer cancel()
-
-	_, err := db.chatDB.UpdateOne(ctx, bson.M{"_id": chatID}, bson.M{"$set": bson.M{"admin_play": adminPlay}}, options.UpdateOne().SetUpsert(true))
-	if err == nil {
-		db.chatCache.Delete(toKey(chatID))
-	}
-	return err
+	return db.setChatField(chatID, chatAdminPlay, adminPlay)
 }
 
 // GetAdminMode retrieves the admin mode for a chat.
@@ -136,14 +120,7 @@ func (db *Database) GetAdminMode(chatID int64) string {
 
 // SetAdminMode sets the admin mode for a given chat.
 func (db *Database) SetAdminMode(chatID int64, adminMode string) error {
-	ctx, cancel := db.ctx()
-	defer cancel()
-
-	_, err := db.chatDB.UpdateOne(ctx, bson.M{"_id": chatID}, bson.M{"$set": bson.M{"admin_mode": adminMode}}, options.UpdateOne().SetUpsert(true))
-	if err == nil {
-		db.chatCache.Delete(toKey(chatID))
-	}
-	return err
+	return db.setChatField(chatID, chatAdminMode, adminMode)
 }
 
 // GetCmdDelete retrieves the command delete setting for a chat.
@@ -157,14 +134,38 @@ func (db *Database) GetCmdDelete(chatID int64) bool {
 
 // SetCmdDelete sets the command delete setting for a given chat.
 func (db *Database) SetCmdDelete(chatID int64, cmdDelete bool) error {
-	ctx, cancel := db.ctx()
-	defer cancel()
+	return db.setChatField(chatID, chatCmdDelete, cmdDelete)
+}
 
-	_, err := db.chatDB.UpdateOne(ctx, bson.M{"_id": chatID}, bson.M{"$set": bson.M{"cmd_delete": cmdDelete}}, options.UpdateOne().SetUpsert(true))
-	if err == nil {
-		db.chatCache.Delete(toKey(chatID))
+// GetVideoQuality retrieves the video streaming profile for a chat, falling back to the default profile.
+func (db *Database) GetVideoQuality(chatID int64) utils.VideoQuality {
+	chat, _ := db.getChat(chatID)
+	if chat == nil {
+		return utils.DefaultVideoQuality
 	}
-	return err
+	return chat.VideoQuality.Or(utils.DefaultVideoQuality)
+}
+
+// SetVideoQuality sets the video streaming profile for a given chat.
+func (db *Database) SetVideoQuality(chatID int64, quality utils.VideoQuality) error {
+	return db.setChatField(chatID, chatVideoQuality, quality)
+}
+
+// GetAudioProfile retrieves the audio profile for a chat, falling back to the global profile.
+func (db *Database) GetAudioProfile(chatID int64) utils.AudioProfile {
+	chat, _ := db.getChat(chatID)
+	if chat == nil {
+		return utils.DefaultAudioProfile()
+	}
+	if profile, ok := utils.GetAudioProfile(chat.AudioProfile); ok {
+		return profile
+	}
+	return utils.DefaultAudioProfile()
+}
+
+// SetAudioProfile sets the audio profile for a given chat.
+func (db *Database) SetAudioProfile(chatID int64, profile string) error {
+	return db.setChatField(chatID, chatAudioProfile, profile)
 }
 
 // GetAllChats retrieves a list of all chat IDs from the database.
@@ -172,25 +173,27 @@ func (db *Database) GetAllChats() ([]int64, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	cursor, err := db.chatDB.Find(ctx, bson.M{})
+	docs, err := db.store.ListChats(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer func(cursor *mongo.Cursor, ctx context.Context) {
-		_ = cursor.Close(ctx)
-	}(cursor, ctx)
-
-	var chats []int64
-	for cursor.Next(ctx) {
-		var doc Chats
-		if err := cursor.Decode(&doc); err != nil {
-			return nil, err
-		}
-		chats = append(chats, doc.ID)
-		db.chatCache.Set(toKey(doc.ID), &doc)
-	}
-	if err := cursor.Err(); err != nil {
-		return nil, err
+
+	chats := make([]int64, 0, len(docs))
+	for i := range docs {
+		chats = append(chats, docs[i].ID)
+		db.chatCache.Set(toKey(docs[i].ID), &docs[i])
 	}
 	return chats, nil
 }
+
+// setChatField updates a single chat setting and drops the cached chat.
+func (db *Database) setChatField(chatID int64, field string, value any) error {
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	err := db.store.SetChatField(ctx, chatID, field, value)
+	if err == nil {
+		db.chatCache.Delete(toKey(chatID))
+	}
+	return err
+}
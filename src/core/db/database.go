@@ -0,0 +1,104 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package db
+
+import (
+	"ashokshau/tgmusic/config"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"ashokshau/tgmusic/src/core/cache"
+)
+
+// Database wraps the configured Storage backend with caches.
+type Database struct {
+	store Storage
+
+	chatCache      *cache.Cache[*Chats]
+	userCache      *cache.Cache[*Users]
+	assistantCache *cache.Cache[int64]
+	authCache      *cache.Cache[[]int64]
+	langCache      *cache.Cache[string]
+	loggerCache    *cache.Cache[bool]
+	blChatsCache   *cache.Cache[[]int64]
+	blUsersCache   *cache.Cache[[]int64]
+}
+
+// Instance is the global singleton for the database.
+var Instance *Database
+
+// InitDatabase opens the backend selected by DB_BACKEND, applies pending migrations and sets up the global instance.
+func InitDatabase() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	store, err := openStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	if m, ok := store.(migrator); ok {
+		// Migrations can touch every document, so they get more time than the connection.
+		migrateCtx, cancelMigrate := context.WithTimeout(context.Background(), 15*time.Minute)
+		defer cancelMigrate()
+		if err := m.migrate(migrateCtx, config.Conf.DbMigrateDryRun); err != nil {
+			// The connection context may have expired while migrating.
+			closeCtx, cancelClose := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancelClose()
+			_ = store.Close(closeCtx)
+			return fmt.Errorf("failed to migrate the database: %w", err)
+		}
+	}
+
+	Instance = newDatabase(store)
+	slog.Info("[DB] The database connection has been successfully established.", "backend", config.Conf.DbBackend)
+	return nil
+}
+
+func newDatabase(store Storage) *Database {
+	return &Database{
+		store: store,
+
+		chatCache:      cache.NewCache[*Chats](20 * time.Minute),
+		userCache:      cache.NewCache[*Users](20 * time.Minute),
+		assistantCache: cache.NewCache[int64](20 * time.Minute),
+		authCache:      cache.NewCache[[]int64](20 * time.Minute),
+		langCache:      cache.NewCache[string](20 * time.Minute),
+		loggerCache:    cache.NewCache[bool](20 * time.Minute),
+		blChatsCache:   cache.NewCache[[]int64](20 * time.Minute),
+		blUsersCache:   cache.NewCache[[]int64](20 * time.Minute),
+	}
+}
+
+// Close gracefully closes the database connection.
+func (db *Database) Close() error {
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	slog.Info("[DB] Closing the database connection...")
+	return db.store.Close(ctx)
+}
+
+func (db *Database) ctx() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), 5*time.Second)
+}
+
+// clearCaches drops every cached value, for after the stored data changed wholesale.
+func (db *Database) clearCaches() {
+	db.chatCache.Clear()
+	db.userCache.Clear()
+	db.assistantCache.Clear()
+	db.authCache.Clear()
+	db.langCache.Clear()
+	db.loggerCache.Clear()
+	db.blChatsCache.Clear()
+	db.blUsersCache.Clear()
+}
@@ -11,10 +11,6 @@ package db
 import (
 	"context"
 	"errors"
-
-	"go.mongodb.org/mongo-driver/v2/bson"
-	"go.mongodb.org/mongo-driver/v2/mongo"
-	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
 // GetLanguage retrieves the language code for a chat.
@@ -27,28 +23,21 @@ func (db *Database) GetLanguage(chatID int64) (string, error) {
 	ctx, cancel := db.ctx()
 	defer cancel()
 
-	var doc struct {
-		Lang string `bson:"lang"`
-	}
-	err := db.langDB.FindOne(ctx, bson.M{"_id": chatID}).Decode(&doc)
+	langCode, err := db.store.GetLanguage(ctx, chatID)
 	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
+		if errors.Is(err, ErrNotFound) {
+			db.langCache.Set(key, "en")
 			return "en", nil
 		}
 		return "", err
 	}
-	db.langCache.Set(key, doc.Lang)
-	return doc.Lang, nil
+	db.langCache.Set(key, langCode)
+	return langCode, nil
 }
 
 // SetLanguage sets the language code for a chat.
 func (db *Database) SetLanguage(ctx context.Context, chatID int64, langCode string) error {
-	_, err := db.langDB.UpdateOne(ctx,
-		bson.M{"_id": chatID},
-		bson.M{"$set": bson.M{"lang": langCode}},
-		options.UpdateOne().SetUpsert(true),
-	)
-
+	err := db.store.SetLanguage(ctx, chatID, langCode)
 	if err == nil {
 		db.langCache.Set(toKey(chatID), langCode)
 	}
@@ -0,0 +1,99 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package db
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// ErrNoTrackID is returned when liking a song that has no track ID to identify it by.
+var ErrNoTrackID = errors.New("the song has no track ID")
+
+// Like is a song a user has liked.
+type Like struct {
+	UserID  int64     `json:"user_id" bson:"user_id"`
+	Song    Song      `json:"song" bson:"song"`
+	LikedAt time.Time `json:"liked_at" bson:"liked_at"`
+}
+
+// SongLikes is a song and how many users like it.
+type SongLikes struct {
+	Song  Song `bson:"song"`
+	Count int  `bson:"count"`
+}
+
+// likeID is the key of a like; a user likes each track at most once.
+func likeID(userID int64, trackID string) string {
+	return strconv.FormatInt(userID, 10) + ":" + trackID
+}
+
+// LikeSong adds a song to the user's liked songs and reports whether it was not liked yet.
+func (db *Database) LikeSong(userID int64, song Song) (bool, error) {
+	if song.TrackID == "" {
+		return false, ErrNoTrackID
+	}
+
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	return db.store.AddLike(ctx, Like{UserID: userID, Song: song, LikedAt: time.Now().UTC()})
+}
+
+// UnlikeSong removes a track from the user's liked songs and reports whether it was liked.
+func (db *Database) UnlikeSong(userID int64, trackID string) (bool, error) {
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	return db.store.RemoveLike(ctx, userID, trackID)
+}
+
+// ToggleLike likes the song, or unlikes it if the user already likes it, and reports whether it is now liked.
+func (db *Database) ToggleLike(userID int64, song Song) (bool, error) {
+	added, err := db.LikeSong(userID, song)
+	if err != nil || added {
+		return added, err
+	}
+	_, err = db.UnlikeSong(userID, song.TrackID)
+	return false, err
+}
+
+// GetLikes returns the user's liked songs, newest first.
+func (db *Database) GetLikes(userID int64) ([]Like, error) {
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	return db.store.ListLikes(ctx, userID)
+}
+
+// GetLikeCount returns how many users like a track.
+func (db *Database) GetLikeCount(trackID string) (int, error) {
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	return db.store.CountLikes(ctx, trackID)
+}
+
+// GetTopLiked returns up to limit of the most liked songs across all users.
+func (db *Database) GetTopLiked(limit int) ([]SongLikes, error) {
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	return db.store.TopLikes(ctx, limit)
+}
+
+// LikedSongs returns the songs of the likes, in the same order.
+func LikedSongs(likes []Like) []Song {
+	songs := make([]Song, 0, len(likes))
+	for _, like := range likes {
+		songs = append(songs, like.Song)
+	}
+	return songs
+}
@@ -8,11 +8,6 @@
 
 package db
 
-import (
-	"go.mongodb.org/mongo-driver/v2/bson"
-	"go.mongodb.org/mongo-driver/v2/mongo/options"
-)
-
 // GetLoggerStatus retrieves the logger status for a given bot.
 func (db *Database) GetLoggerStatus() bool {
 	if cached, ok := db.loggerCache.Get("logger"); ok {
@@ -22,26 +17,19 @@ func (db *Database) GetLoggerStatus() bool {
 	ctx, cancel := db.ctx()
 	defer cancel()
 
-	var doc struct {
-		Status bool `bson:"status"`
-	}
-	err := db.cacheDB.FindOne(ctx, bson.M{"_id": "logger"}).Decode(&doc)
+	status, err := db.store.GetLoggerStatus(ctx)
 	if err != nil {
 		return false
 	}
-	db.loggerCache.Set("logger", doc.Status)
-	return doc.Status
+	db.loggerCache.Set("logger", status)
+	return status
 }
 
 // SetLoggerStatus enables or disables the logger for a bot.
 func (db *Database) SetLoggerStatus(status bool) error {
 	ctx, cancel := db.ctx()
 	defer cancel()
-	_, err := db.cacheDB.UpdateOne(ctx,
-		bson.M{"_id": "logger"},
-		bson.M{"$set": bson.M{"status": status}},
-		options.UpdateOne().SetUpsert(true),
-	)
+	err := db.store.SetLoggerStatus(ctx, status)
 	if err == nil {
 		db.loggerCache.Set("logger", status)
 	}
@@ -0,0 +1,276 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// Migration is a single schema change to the MongoDB database.
+// Migrations run in ID order and are recorded once applied, but Up must still be idempotent:
+// a migration interrupted before it is recorded runs again on the next start.
+type Migration struct {
+	ID   int
+	Name string
+	Up   func(ctx context.Context, db *mongo.Database) error
+}
+
+// migrations is the ordered list of schema changes. Append new migrations with the next ID; never renumber or remove one.
+var migrations = []Migration{
+	{ID: 1, Name: "create indexes", Up: createIndexes},
+	{ID: 2, Name: "default empty playlist songs", Up: defaultPlaylistSongs},
+	{ID: 3, Name: "drop legacy assistant numbers", Up: dropAssistantNumbers},
+	{ID: 4, Name: "index shared playlists", Up: indexSharedPlaylists},
+	{ID: 5, Name: "index likes", Up: indexLikes},
+}
+
+const (
+	migrationsCollection = "migrations"
+	migrationLockID      = "lock"
+	// migrationLockTTL bounds how long a crashed instance can hold the lock. A running instance renews it.
+	migrationLockTTL = 10 * time.Minute
+)
+
+// migrator is implemented by backends that have a schema to migrate.
+type migrator interface {
+	migrate(ctx context.Context, dryRun bool) error
+}
+
+// migrationRecord is stored in the migrations collection for each applied migration.
+type migrationRecord struct {
+	ID        int       `bson:"_id"`
+	Name      string    `bson:"name"`
+	AppliedAt time.Time `bson:"applied_at"`
+}
+
+// migrate applies pending migrations while holding the migration lock, so only one instance migrates at a time.
+// With dryRun set it only logs the migrations that would run.
+func (s *mongoStore) migrate(ctx context.Context, dryRun bool) error {
+	if err := checkMigrations(migrations); err != nil {
+		return err
+	}
+
+	coll := s.db.Collection(migrationsCollection)
+	if dryRun {
+		pending, err := pendingMigrations(ctx, coll)
+		if err != nil {
+			return err
+		}
+		for _, m := range pending {
+			slog.Info("[DB] Dry run: migration would be applied", "id", m.ID, "name", m.Name)
+		}
+		slog.Info("[DB] Dry run: no migrations were applied", "pending", len(pending))
+		return nil
+	}
+
+	owner, err := acquireMigrationLock(ctx, coll)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Release even if ctx has expired, otherwise other instances wait for the TTL.
+		releaseCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := coll.DeleteOne(releaseCtx, bson.M{"_id": migrationLockID, "owner": owner}); err != nil {
+			slog.Warn("[DB] Failed to release the migration lock", "error", err)
+		}
+	}()
+
+	// Keep the lock alive for migrations that run longer than its TTL; stopped before the lock is released.
+	renewCtx, stopRenew := context.WithCancel(ctx)
+	defer stopRenew()
+	go renewMigrationLock(renewCtx, coll, owner, migrationLockTTL/3)
+
+	// Read the applied set under the lock so migrations run by another instance are not repeated.
+	pending, err := pendingMigrations(ctx, coll)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range pending {
+		slog.Info("[DB] Applying migration", "id", m.ID, "name", m.Name)
+		start := time.Now()
+		if err := m.Up(ctx, s.db); err != nil {
+			return fmt.Errorf("migration %d (%s): %w", m.ID, m.Name, err)
+		}
+
+		record := migrationRecord{ID: m.ID, Name: m.Name, AppliedAt: time.Now()}
+		if _, err := coll.InsertOne(ctx, record); err != nil {
+			return fmt.Errorf("recording migration %d (%s): %w", m.ID, m.Name, err)
+		}
+		slog.Info("[DB] Migration applied", "id", m.ID, "name", m.Name, "took", time.Since(start))
+	}
+	return nil
+}
+
+// checkMigrations rejects a migration list that is not in strictly increasing ID order.
+func checkMigrations(list []Migration) error {
+	last := 0
+	for _, m := range list {
+		if m.ID <= last {
+			return fmt.Errorf("migration %d (%s) is out of order", m.ID, m.Name)
+		}
+		if m.Up == nil {
+			return fmt.Errorf("migration %d (%s) has no Up function", m.ID, m.Name)
+		}
+		last = m.ID
+	}
+	return nil
+}
+
+// pendingMigrations returns the migrations that have not been recorded yet, in order.
+func pendingMigrations(ctx context.Context, coll *mongo.Collection) ([]Migration, error) {
+	cursor, err := coll.Find(ctx, bson.M{"_id": bson.M{"$type": "number"}})
+	if err != nil {
+		return nil, err
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		_ = cursor.Close(ctx)
+	}(cursor, ctx)
+
+	var records []migrationRecord
+	if err = cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]bool, len(records))
+	for _, r := range records {
+		applied[r.ID] = true
+	}
+
+	var pending []Migration
+	for _, m := range migrations {
+		if !applied[m.ID] {
+			pending = append(pending, m)
+		}
+	}
+	return pending, nil
+}
+
+// acquireMigrationLock waits until it holds the lock document and returns the owner token to release it with.
+// A lock older than migrationLockTTL is taken over.
+func acquireMigrationLock(ctx context.Context, coll *mongo.Collection) (string, error) {
+	host, _ := os.Hostname()
+	owner := fmt.Sprintf("%s/%d/%d", host, os.Getpid(), time.Now().UnixNano())
+
+	for {
+		now := time.Now()
+		_, err := coll.UpdateOne(ctx,
+			bson.M{"_id": migrationLockID, "expires_at": bson.M{"$lt": now}},
+			bson.M{"$set": bson.M{"owner": owner, "expires_at": now.Add(migrationLockTTL)}},
+			options.UpdateOne().SetUpsert(true),
+		)
+		if err == nil {
+			return owner, nil
+		}
+		// A duplicate key means the upsert found a live lock held by another instance.
+		if !mongo.IsDuplicateKeyError(err) {
+			return "", fmt.Errorf("acquiring the migration lock: %w", err)
+		}
+
+		slog.Info("[DB] Waiting for another instance to finish migrating...")
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("waiting for the migration lock: %w", ctx.Err())
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
+
+// renewMigrationLock extends the lock held by owner every interval until ctx is done.
+func renewMigrationLock(ctx context.Context, coll *mongo.Collection, owner string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := extendMigrationLock(ctx, coll, owner); err != nil && ctx.Err() == nil {
+				slog.Warn("[DB] Failed to renew the migration lock", "error", err)
+			}
+		}
+	}
+}
+
+// extendMigrationLock pushes the expiry of the lock held by owner to migrationLockTTL from now.
+func extendMigrationLock(ctx context.Context, coll *mongo.Collection, owner string) error {
+	res, err := coll.UpdateOne(ctx,
+		bson.M{"_id": migrationLockID, "owner": owner},
+		bson.M{"$set": bson.M{"expires_at": time.Now().Add(migrationLockTTL)}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return errors.New("the migration lock is no longer held")
+	}
+	return nil
+}
+
+// createIndexes indexes the fields the bot queries by besides _id.
+func createIndexes(ctx context.Context, db *mongo.Database) error {
+	indexes := map[string]mongo.IndexModel{
+		"playlists": {Keys: bson.D{{Key: "user_id", Value: 1}}},
+		"assistant": {Keys: bson.D{{Key: "assistant_id", Value: 1}}},
+	}
+	for coll, index := range indexes {
+		if _, err := db.Collection(coll).Indexes().CreateOne(ctx, index); err != nil {
+			return fmt.Errorf("%s: %w", coll, err)
+		}
+	}
+	return nil
+}
+
+// defaultPlaylistSongs gives playlists saved without songs an empty list, which $push requires.
+func defaultPlaylistSongs(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("playlists").UpdateMany(ctx,
+		bson.M{"songs": nil},
+		bson.M{"$set": bson.M{"songs": bson.A{}}},
+	)
+	return err
+}
+
+// dropAssistantNumbers removes assignments stored as an index into STRING1..STRING10 before assistants had stable IDs.
+// Chats that only have the old number are reassigned on their next play.
+func dropAssistantNumbers(ctx context.Context, db *mongo.Database) error {
+	coll := db.Collection("assistant")
+	if _, err := coll.DeleteMany(ctx, bson.M{"assistant_id": bson.M{"$exists": false}}); err != nil {
+		return err
+	}
+	_, err := coll.UpdateMany(ctx, bson.M{"num": bson.M{"$exists": true}}, bson.M{"$unset": bson.M{"num": ""}})
+	return err
+}
+
+// indexSharedPlaylists indexes the fields group playlist listing and public search filter on.
+func indexSharedPlaylists(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("playlists").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "chat_id", Value: 1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "visibility", Value: 1}, {Key: "name", Value: 1}}},
+	})
+	return err
+}
+
+// indexLikes supports listing a user's likes newest first and counting the likes of a track.
+func indexLikes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("likes").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "liked_at", Value: -1}}},
+		{Keys: bson.D{{Key: "song.track_id", Value: 1}}},
+	})
+	return err
+}
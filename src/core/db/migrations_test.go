@@ -0,0 +1,148 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func TestMigrationsOrdered(t *testing.T) {
+	if err := checkMigrations(migrations); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckMigrations(t *testing.T) {
+	up := func(context.Context, *mongo.Database) error { return nil }
+	tests := []struct {
+		name    string
+		list    []Migration
+		wantErr bool
+	}{
+		{"empty", nil, false},
+		{"ordered", []Migration{{ID: 1, Up: up}, {ID: 2, Up: up}, {ID: 5, Up: up}}, false},
+		{"duplicate", []Migration{{ID: 1, Up: up}, {ID: 1, Up: up}}, true},
+		{"descending", []Migration{{ID: 2, Up: up}, {ID: 1, Up: up}}, true},
+		{"zero ID", []Migration{{ID: 0, Up: up}}, true},
+		{"missing Up", []Migration{{ID: 1}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkMigrations(tt.list)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkMigrations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPendingMigrations(t *testing.T) {
+	s := openTestMongo(t)
+	ctx := context.Background()
+	coll := s.db.Collection(migrationsCollection)
+
+	pending, err := pendingMigrations(ctx, coll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != len(migrations) {
+		t.Fatalf("pending = %d migrations on a fresh database, want %d", len(pending), len(migrations))
+	}
+
+	// The lock document shares the collection but is not a migration record.
+	if _, err := coll.InsertOne(ctx, bson.M{"_id": migrationLockID, "owner": "test"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []int{1, 3} {
+		if _, err := coll.InsertOne(ctx, migrationRecord{ID: id, AppliedAt: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pending, err = pendingMigrations(ctx, coll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want []int
+	for _, m := range migrations {
+		if m.ID != 1 && m.ID != 3 {
+			want = append(want, m.ID)
+		}
+	}
+	if len(pending) != len(want) {
+		t.Fatalf("pending = %d migrations, want %d", len(pending), len(want))
+	}
+	for i, m := range pending {
+		if m.ID != want[i] {
+			t.Errorf("pending[%d] = %d, want %d", i, m.ID, want[i])
+		}
+	}
+}
+
+func TestMigrationLock(t *testing.T) {
+	s := openTestMongo(t)
+	ctx := context.Background()
+	coll := s.db.Collection(migrationsCollection)
+
+	owner, err := acquireMigrationLock(ctx, coll)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+	if _, err := acquireMigrationLock(waitCtx, coll); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("acquiring a held lock: err = %v, want a deadline error", err)
+	}
+
+	if err := extendMigrationLock(ctx, coll, owner); err != nil {
+		t.Fatalf("renewing a held lock: %v", err)
+	}
+
+	// An expired lock is taken over, after which the old owner can no longer renew it.
+	if _, err := coll.UpdateOne(ctx, bson.M{"_id": migrationLockID}, bson.M{"$set": bson.M{"expires_at": time.Now().Add(-time.Minute)}}); err != nil {
+		t.Fatal(err)
+	}
+	next, err := acquireMigrationLock(ctx, coll)
+	if err != nil {
+		t.Fatalf("taking over an expired lock: %v", err)
+	}
+	if next == owner {
+		t.Fatal("the new lock has the old owner")
+	}
+	if err := extendMigrationLock(ctx, coll, owner); err == nil {
+		t.Error("the old owner renewed a lock that was taken over")
+	}
+}
+
+func TestMigrateDryRun(t *testing.T) {
+	s := openTestMongo(t)
+	ctx := context.Background()
+	coll := s.db.Collection(migrationsCollection)
+
+	if err := s.migrate(ctx, true); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := coll.CountDocuments(ctx, bson.M{}); err != nil || n != 0 {
+		t.Fatalf("after a dry run the migrations collection has %d documents (err %v), want 0", n, err)
+	}
+
+	if err := s.migrate(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	pending, err := pendingMigrations(ctx, coll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("%d migrations are still pending after migrating", len(pending))
+	}
+	if n, err := coll.CountDocuments(ctx, bson.M{"_id": migrationLockID}); err != nil || n != 0 {
+		t.Errorf("the migration lock was not released (count %d, err %v)", n, err)
+	}
+}
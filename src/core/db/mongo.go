@@ -9,22 +9,21 @@
 package db
 
 import (
-	"ashokshau/tgmusic/config"
 	"context"
 	"errors"
-	"log/slog"
+	"regexp"
+	"strconv"
 	"time"
 
-	"ashokshau/tgmusic/src/core/cache"
-
+	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
-// Database encapsulates the MongoDB connection, database, collections, and caches.
-type Database struct {
+// mongoStore is the MongoDB Storage backend.
+type mongoStore struct {
 	client      *mongo.Client
-	DB          *mongo.Database
+	db          *mongo.Database
 	chatDB      *mongo.Collection
 	userDB      *mongo.Collection
 	playlistDB  *mongo.Collection
@@ -32,39 +31,27 @@ type Database struct {
 	authDB      *mongo.Collection
 	langDB      *mongo.Collection
 	cacheDB     *mongo.Collection
-
-	chatCache      *cache.Cache[*Chats]
-	userCache      *cache.Cache[*Users]
-	assistantCache *cache.Cache[int]
-	authCache      *cache.Cache[[]int64]
-	langCache      *cache.Cache[string]
-	loggerCache    *cache.Cache[bool]
-	blChatsCache   *cache.Cache[[]int64]
-	blUsersCache   *cache.Cache[[]int64]
+	settingsDB  *mongo.Collection
+	sessionsDB  *mongo.Collection
+	likesDB     *mongo.Collection
 }
 
-// Instance is the global singleton for the database.
-var Instance *Database
-
-// InitDatabase initializes the database connection and sets up the global instance.
-func InitDatabase() error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	opts := options.Client().ApplyURI(config.Conf.MongoUri).
+// openMongo connects to MongoDB and uses the named database.
+func openMongo(ctx context.Context, uri, name string) (*mongoStore, error) {
+	opts := options.Client().ApplyURI(uri).
 		SetMinPoolSize(10).
 		SetMaxConnIdleTime(10 * time.Minute).
 		SetConnectTimeout(20 * time.Second)
 
 	client, err := mongo.Connect(opts)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	db := client.Database(config.Conf.DbName)
-	Instance = &Database{
+	db := client.Database(name)
+	s := &mongoStore{
 		client:      client,
-		DB:          db,
+		db:          db,
 		chatDB:      db.Collection("chats"),
 		userDB:      db.Collection("users"),
 		playlistDB:  db.Collection("playlists"),
@@ -72,34 +59,707 @@ func InitDatabase() error {
 		authDB:      db.Collection("auth"),
 		langDB:      db.Collection("lang"),
 		cacheDB:     db.Collection("cache"),
+		settingsDB:  db.Collection("settings"),
+		sessionsDB:  db.Collection("sessions"),
+		likesDB:     db.Collection("likes"),
+	}
+
+	if err := s.Ping(ctx); err != nil {
+		_ = client.Disconnect(ctx)
+		return nil, errors.New("failed to ping database: " + err.Error())
+	}
+	return s, nil
+}
+
+func (s *mongoStore) Ping(ctx context.Context) error {
+	return s.client.Ping(ctx, nil)
+}
+
+func (s *mongoStore) Close(ctx context.Context) error {
+	return s.client.Disconnect(ctx)
+}
+
+// findOne decodes a single document, returning ErrNotFound if there is none.
+func findOne(ctx context.Context, coll *mongo.Collection, filter any, v any) error {
+	err := coll.FindOne(ctx, filter).Decode(v)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrNotFound
+	}
+	return err
+}
+
+func (s *mongoStore) GetChat(ctx context.Context, chatID int64) (*Chats, error) {
+	var chat Chats
+	if err := findOne(ctx, s.chatDB, bson.M{"_id": chatID}, &chat); err != nil {
+		return nil, err
+	}
+	return &chat, nil
+}
+
+func (s *mongoStore) AddChat(ctx context.Context, chatID int64) error {
+	_, err := s.chatDB.UpdateOne(ctx, bson.M{"_id": chatID}, bson.M{"$setOnInsert": bson.M{}}, options.UpdateOne().SetUpsert(true))
+	return err
+}
+
+func (s *mongoStore) SetChatField(ctx context.Context, chatID int64, field string, value any) error {
+	_, err := s.chatDB.UpdateOne(ctx, bson.M{"_id": chatID}, bson.M{"$set": bson.M{field: value}}, options.UpdateOne().SetUpsert(true))
+	return err
+}
+
+func (s *mongoStore) ListChats(ctx context.Context) ([]Chats, error) {
+	cursor, err := s.chatDB.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		_ = cursor.Close(ctx)
+	}(cursor, ctx)
+
+	var chats []Chats
+	if err = cursor.All(ctx, &chats); err != nil {
+		return nil, err
+	}
+	return chats, nil
+}
+
+func (s *mongoStore) AddUser(ctx context.Context, userID int64) error {
+	_, err := s.userDB.UpdateOne(ctx,
+		bson.M{"_id": userID},
+		bson.M{"$setOnInsert": bson.M{}},
+		options.UpdateOne().SetUpsert(true),
+	)
+	return err
+}
+
+func (s *mongoStore) RemoveUser(ctx context.Context, userID int64) error {
+	_, err := s.userDB.DeleteOne(ctx, bson.M{"_id": userID})
+	return err
+}
+
+func (s *mongoStore) HasUser(ctx context.Context, userID int64) (bool, error) {
+	var user Users
+	err := findOne(ctx, s.userDB, bson.M{"_id": userID}, &user)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s *mongoStore) ListUsers(ctx context.Context) ([]int64, error) {
+	cursor, err := s.userDB.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		_ = cursor.Close(ctx)
+	}(cursor, ctx)
+
+	var users []int64
+	for cursor.Next(ctx) {
+		var doc Users
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		users = append(users, doc.ID)
+	}
+	return users, cursor.Err()
+}
+
+func (s *mongoStore) InsertPlaylist(ctx context.Context, playlist Playlist) error {
+	_, err := s.playlistDB.InsertOne(ctx, playlist)
+	return err
+}
+
+func (s *mongoStore) GetPlaylist(ctx context.Context, id string) (*Playlist, error) {
+	var playlist Playlist
+	if err := findOne(ctx, s.playlistDB, bson.M{"_id": id}, &playlist); err != nil {
+		return nil, err
+	}
+	return &playlist, nil
+}
+
+func (s *mongoStore) DeletePlaylist(ctx context.Context, id string, userID int64) error {
+	_, err := s.playlistDB.DeleteOne(ctx, bson.M{"_id": id, "user_id": userID})
+	return err
+}
+
+func (s *mongoStore) AddPlaylistSong(ctx context.Context, id string, song Song) error {
+	_, err := s.playlistDB.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$push": bson.M{"songs": song},
+		"$inc":  bson.M{"version": 1},
+	})
+	return err
+}
+
+func (s *mongoStore) RemovePlaylistSong(ctx context.Context, id string, trackID string) error {
+	_, err := s.playlistDB.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$pull": bson.M{"songs": bson.M{"track_id": trackID}},
+		"$inc":  bson.M{"version": 1},
+	})
+	return err
+}
+
+func (s *mongoStore) RenamePlaylist(ctx context.Context, id string, name string) error {
+	_, err := s.playlistDB.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"name": name}})
+	return err
+}
+
+func (s *mongoStore) SetPlaylistSongs(ctx context.Context, id string, version int64, songs []Song) (bool, error) {
+	filter := bson.M{"_id": id, "version": version}
+	if version == 0 {
+		// Playlists saved before versions were added have no version field.
+		filter["version"] = bson.M{"$in": bson.A{0, nil}}
+	}
+	res, err := s.playlistDB.UpdateOne(ctx, filter, bson.M{
+		"$set": bson.M{"songs": songs},
+		"$inc": bson.M{"version": 1},
+	})
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount == 1, nil
+}
+
+func (s *mongoStore) ListUserPlaylists(ctx context.Context, userID int64) ([]Playlist, error) {
+	cursor, err := s.playlistDB.Find(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		_ = cursor.Close(ctx)
+	}(cursor, ctx)
+
+	var playlists []Playlist
+	if err = cursor.All(ctx, &playlists); err != nil {
+		return nil, err
+	}
+	return playlists, nil
+}
+
+func (s *mongoStore) ListChatPlaylists(ctx context.Context, chatID int64) ([]Playlist, error) {
+	return findAll[Playlist](ctx, s.playlistDB, bson.M{"chat_id": chatID})
+}
+
+func (s *mongoStore) SearchPublicPlaylists(ctx context.Context, query string, limit int) ([]Playlist, error) {
+	filter := bson.M{
+		"visibility": VisibilityPublic,
+		"name":       bson.M{"$regex": regexp.QuoteMeta(query), "$options": "i"},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}}).SetLimit(int64(limit))
+	return findAll[Playlist](ctx, s.playlistDB, filter, opts)
+}
+
+func (s *mongoStore) SetPlaylistVisibility(ctx context.Context, id string, visibility PlaylistVisibility) error {
+	_, err := s.playlistDB.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"visibility": visibility}})
+	return err
+}
+
+func (s *mongoStore) AddPlaylistCollaborator(ctx context.Context, id string, userID int64) error {
+	_, err := s.playlistDB.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$addToSet": bson.M{"collaborators": userID}})
+	return err
+}
+
+func (s *mongoStore) RemovePlaylistCollaborator(ctx context.Context, id string, userID int64) error {
+	_, err := s.playlistDB.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$pull": bson.M{"collaborators": userID}})
+	return err
+}
+
+func (s *mongoStore) GetAssistant(ctx context.Context, chatID int64) (int64, error) {
+	var doc struct {
+		AssistantID int64 `bson:"assistant_id"`
+	}
+	err := findOne(ctx, s.assistantDB, bson.M{"_id": chatID}, &doc)
+	if errors.Is(err, ErrNotFound) {
+		return 0, nil
+	}
+	return doc.AssistantID, err
+}
+
+func (s *mongoStore) SetAssistant(ctx context.Context, chatID, assistantID int64) error {
+	_, err := s.assistantDB.UpdateOne(ctx,
+		bson.M{"_id": chatID},
+		bson.M{"$set": bson.M{"assistant_id": assistantID}, "$unset": bson.M{"num": ""}},
+		options.UpdateOne().SetUpsert(true),
+	)
+	return err
+}
+
+func (s *mongoStore) RemoveAssistant(ctx context.Context, chatID int64) error {
+	_, err := s.assistantDB.DeleteOne(ctx, bson.M{"_id": chatID})
+	return err
+}
+
+// AssignAssistant treats chats still holding a positional index from older versions as unassigned.
+func (s *mongoStore) AssignAssistant(ctx context.Context, chatID, proposed int64) (int64, error) {
+	filter := bson.M{
+		"_id": chatID,
+		"$or": bson.A{
+			bson.M{"assistant_id": bson.M{"$exists": false}},
+			bson.M{"assistant_id": 0},
+		},
+	}
+	update := bson.M{"$set": bson.M{"assistant_id": proposed}, "$unset": bson.M{"num": ""}}
+
+	result, err := s.assistantDB.UpdateOne(ctx, filter, update, options.UpdateOne().SetUpsert(true))
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return s.GetAssistant(ctx, chatID)
+		}
+		return 0, err
+	}
+
+	if result.ModifiedCount > 0 || result.UpsertedCount > 0 {
+		return proposed, nil
+	}
+	return s.GetAssistant(ctx, chatID)
+}
 
-		chatCache:      cache.NewCache[*Chats](20 * time.Minute),
-		userCache:      cache.NewCache[*Users](20 * time.Minute),
-		assistantCache: cache.NewCache[int](20 * time.Minute),
-		authCache:      cache.NewCache[[]int64](20 * time.Minute),
-		langCache:      cache.NewCache[string](20 * time.Minute),
-		loggerCache:    cache.NewCache[bool](20 * time.Minute),
-		blChatsCache:   cache.NewCache[[]int64](20 * time.Minute),
-		blUsersCache:   cache.NewCache[[]int64](20 * time.Minute),
+func (s *mongoStore) UnassignAssistant(ctx context.Context, assistantID int64) (int64, error) {
+	result, err := s.assistantDB.DeleteMany(ctx, bson.M{"assistant_id": assistantID})
+	if err != nil {
+		return 0, err
 	}
+	return result.DeletedCount, nil
+}
 
-	if err := client.Ping(ctx, nil); err != nil {
-		return errors.New("failed to ping database: " + err.Error())
+func (s *mongoStore) CountAssistantChats(ctx context.Context) (map[int64]int64, error) {
+	cursor, err := s.assistantDB.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"assistant_id": bson.M{"$gt": 0}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$assistant_id", "count": bson.M{"$sum": 1}}}},
+	})
+	if err != nil {
+		return nil, err
 	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		_ = cursor.Close(ctx)
+	}(cursor, ctx)
 
-	slog.Info("[DB] The database connection has been successfully established.")
-	return nil
+	var docs []struct {
+		ID    int64 `bson:"_id"`
+		Count int64 `bson:"count"`
+	}
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int64]int64, len(docs))
+	for _, doc := range docs {
+		counts[doc.ID] = doc.Count
+	}
+	return counts, nil
 }
 
-// Close gracefully closes the database connection.
-func (db *Database) Close() error {
-	ctx, cancel := db.ctx()
-	defer cancel()
+func (s *mongoStore) ListAssistantChats(ctx context.Context, assistantID int64) ([]int64, error) {
+	cursor, err := s.assistantDB.Find(ctx, bson.M{"assistant_id": assistantID}, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		_ = cursor.Close(ctx)
+	}(cursor, ctx)
 
-	slog.Info("[DB] Closing the database connection...")
-	return db.client.Disconnect(ctx)
+	var docs []struct {
+		ID int64 `bson:"_id"`
+	}
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	chats := make([]int64, 0, len(docs))
+	for _, doc := range docs {
+		chats = append(chats, doc.ID)
+	}
+	return chats, nil
+}
+
+func (s *mongoStore) ClearAssistants(ctx context.Context) (int64, error) {
+	result, err := s.assistantDB.DeleteMany(ctx, bson.M{})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
 }
 
-func (db *Database) ctx() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), 5*time.Second)
+func (s *mongoStore) AddAuthUser(ctx context.Context, chatID, userID int64) error {
+	_, err := s.authDB.UpdateOne(ctx,
+		bson.M{"_id": chatID},
+		bson.M{"$addToSet": bson.M{"user_ids": userID}},
+		options.UpdateOne().SetUpsert(true),
+	)
+	return err
+}
+
+func (s *mongoStore) RemoveAuthUser(ctx context.Context, chatID, userID int64) error {
+	_, err := s.authDB.UpdateOne(ctx,
+		bson.M{"_id": chatID},
+		bson.M{"$pull": bson.M{"user_ids": userID}},
+	)
+	return err
+}
+
+func (s *mongoStore) GetAuthUsers(ctx context.Context, chatID int64) ([]int64, error) {
+	var doc struct {
+		UserIDs []int64 `bson:"user_ids"`
+	}
+	err := findOne(ctx, s.authDB, bson.M{"_id": chatID}, &doc)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	return doc.UserIDs, err
+}
+
+func (s *mongoStore) GetLanguage(ctx context.Context, chatID int64) (string, error) {
+	var doc struct {
+		Lang string `bson:"lang"`
+	}
+	if err := findOne(ctx, s.langDB, bson.M{"_id": chatID}, &doc); err != nil {
+		return "", err
+	}
+	return doc.Lang, nil
+}
+
+func (s *mongoStore) SetLanguage(ctx context.Context, chatID int64, langCode string) error {
+	_, err := s.langDB.UpdateOne(ctx,
+		bson.M{"_id": chatID},
+		bson.M{"$set": bson.M{"lang": langCode}},
+		options.UpdateOne().SetUpsert(true),
+	)
+	return err
+}
+
+// blacklistField is the ID list field of a blacklist document.
+func blacklistField(kind BlacklistKind) string {
+	if kind == BlacklistUsers {
+		return "user_ids"
+	}
+	return "chat_ids"
+}
+
+// AddBlacklisted adds the ID to the kind's list field and stores its details under entries.
+func (s *mongoStore) AddBlacklisted(ctx context.Context, kind BlacklistKind, entry BlacklistEntry) error {
+	_, err := s.cacheDB.UpdateOne(ctx,
+		bson.M{"_id": string(kind)},
+		bson.M{
+			"$addToSet": bson.M{blacklistField(kind): entry.ID},
+			"$set":      bson.M{"entries." + strconv.FormatInt(entry.ID, 10): entry},
+		},
+		options.UpdateOne().SetUpsert(true),
+	)
+	return err
+}
+
+func (s *mongoStore) RemoveBlacklisted(ctx context.Context, kind BlacklistKind, id int64) error {
+	_, err := s.cacheDB.UpdateOne(ctx,
+		bson.M{"_id": string(kind)},
+		bson.M{
+			"$pull":  bson.M{blacklistField(kind): id},
+			"$unset": bson.M{"entries." + strconv.FormatInt(id, 10): ""},
+		},
+	)
+	return err
+}
+
+func (s *mongoStore) GetBlacklisted(ctx context.Context, kind BlacklistKind) ([]BlacklistEntry, error) {
+	var doc struct {
+		ChatIDs []int64                   `bson:"chat_ids"`
+		UserIDs []int64                   `bson:"user_ids"`
+		Entries map[string]BlacklistEntry `bson:"entries"`
+	}
+	err := findOne(ctx, s.cacheDB, bson.M{"_id": string(kind)}, &doc)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	ids := doc.ChatIDs
+	if kind == BlacklistUsers {
+		ids = doc.UserIDs
+	}
+
+	entries := make([]BlacklistEntry, 0, len(ids))
+	for _, id := range ids {
+		entry, ok := doc.Entries[strconv.FormatInt(id, 10)]
+		if !ok {
+			entry = BlacklistEntry{ID: id}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Likes are stored with the ID "userID:trackID", so a user can like each track once.
+func (s *mongoStore) AddLike(ctx context.Context, like Like) (bool, error) {
+	res, err := s.likesDB.UpdateOne(ctx,
+		bson.M{"_id": likeID(like.UserID, like.Song.TrackID)},
+		bson.M{"$setOnInsert": like},
+		options.UpdateOne().SetUpsert(true),
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.UpsertedCount > 0, nil
+}
+
+func (s *mongoStore) RemoveLike(ctx context.Context, userID int64, trackID string) (bool, error) {
+	res, err := s.likesDB.DeleteOne(ctx, bson.M{"_id": likeID(userID, trackID)})
+	if err != nil {
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
+
+func (s *mongoStore) ListLikes(ctx context.Context, userID int64) ([]Like, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "liked_at", Value: -1}})
+	return findAll[Like](ctx, s.likesDB, bson.M{"user_id": userID}, opts)
+}
+
+func (s *mongoStore) CountLikes(ctx context.Context, trackID string) (int, error) {
+	n, err := s.likesDB.CountDocuments(ctx, bson.M{"song.track_id": trackID})
+	return int(n), err
+}
+
+func (s *mongoStore) TopLikes(ctx context.Context, limit int) ([]SongLikes, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$song.track_id"},
+			{Key: "song", Value: bson.D{{Key: "$first", Value: "$song"}}},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	cursor, err := s.likesDB.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		_ = cursor.Close(ctx)
+	}(cursor, ctx)
+
+	var top []SongLikes
+	if err = cursor.All(ctx, &top); err != nil {
+		return nil, err
+	}
+	return top, nil
+}
+
+func (s *mongoStore) GetLoggerStatus(ctx context.Context) (bool, error) {
+	var doc struct {
+		Status bool `bson:"status"`
+	}
+	err := findOne(ctx, s.cacheDB, bson.M{"_id": "logger"}, &doc)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	return doc.Status, err
+}
+
+func (s *mongoStore) SetLoggerStatus(ctx context.Context, status bool) error {
+	_, err := s.cacheDB.UpdateOne(ctx,
+		bson.M{"_id": "logger"},
+		bson.M{"$set": bson.M{"status": status}},
+		options.UpdateOne().SetUpsert(true),
+	)
+	return err
+}
+
+func (s *mongoStore) GetSudoers(ctx context.Context) ([]int64, error) {
+	var doc struct {
+		UserIDs []int64 `bson:"user_ids"`
+	}
+	err := findOne(ctx, s.cacheDB, bson.M{"_id": "sudoers"}, &doc)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	return doc.UserIDs, err
+}
+
+func (s *mongoStore) AddSudo(ctx context.Context, userID int64) error {
+	_, err := s.cacheDB.UpdateOne(ctx,
+		bson.M{"_id": "sudoers"},
+		bson.M{"$addToSet": bson.M{"user_ids": userID}},
+		options.UpdateOne().SetUpsert(true),
+	)
+	return err
+}
+
+func (s *mongoStore) RemoveSudo(ctx context.Context, userID int64) error {
+	_, err := s.cacheDB.UpdateOne(ctx,
+		bson.M{"_id": "sudoers"},
+		bson.M{"$pull": bson.M{"user_ids": userID}},
+	)
+	return err
+}
+
+func (s *mongoStore) GetSettings(ctx context.Context) (map[string]string, error) {
+	cursor, err := s.settingsDB.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		_ = cursor.Close(ctx)
+	}(cursor, ctx)
+
+	var docs []struct {
+		Key   string `bson:"_id"`
+		Value string `bson:"value"`
+	}
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	settings := make(map[string]string, len(docs))
+	for _, doc := range docs {
+		settings[doc.Key] = doc.Value
+	}
+	return settings, nil
+}
+
+func (s *mongoStore) SetSetting(ctx context.Context, key, value string) error {
+	_, err := s.settingsDB.UpdateOne(ctx,
+		bson.M{"_id": key},
+		bson.M{"$set": bson.M{"value": value}},
+		options.UpdateOne().SetUpsert(true),
+	)
+	return err
+}
+
+func (s *mongoStore) DeleteSetting(ctx context.Context, key string) error {
+	_, err := s.settingsDB.DeleteOne(ctx, bson.M{"_id": key})
+	return err
+}
+
+func (s *mongoStore) SaveAssistantSession(ctx context.Context, session AssistantSession) error {
+	_, err := s.sessionsDB.UpdateOne(ctx,
+		bson.M{"_id": session.ID},
+		bson.M{"$set": bson.M{"session": session.Session, "added_by": session.AddedBy, "added_at": session.AddedAt}},
+		options.UpdateOne().SetUpsert(true),
+	)
+	return err
+}
+
+func (s *mongoStore) RemoveAssistantSession(ctx context.Context, assistantID int64) error {
+	_, err := s.sessionsDB.DeleteOne(ctx, bson.M{"_id": assistantID})
+	return err
+}
+
+func (s *mongoStore) HasAssistantSession(ctx context.Context, assistantID int64) (bool, error) {
+	err := s.sessionsDB.FindOne(ctx, bson.M{"_id": assistantID}).Err()
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s *mongoStore) ListAssistantSessions(ctx context.Context) ([]AssistantSession, error) {
+	cursor, err := s.sessionsDB.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		_ = cursor.Close(ctx)
+	}(cursor, ctx)
+
+	var sessions []AssistantSession
+	if err = cursor.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// findAll decodes every document matching filter.
+func findAll[T any](ctx context.Context, coll *mongo.Collection, filter any, opts ...options.Lister[options.FindOptions]) ([]T, error) {
+	cursor, err := coll.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		_ = cursor.Close(ctx)
+	}(cursor, ctx)
+
+	var docs []T
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+func (s *mongoStore) ListPlaylists(ctx context.Context) ([]Playlist, error) {
+	return findAll[Playlist](ctx, s.playlistDB, bson.M{})
+}
+
+func (s *mongoStore) ListAssignments(ctx context.Context) (map[int64]int64, error) {
+	docs, err := findAll[struct {
+		ChatID      int64 `bson:"_id"`
+		AssistantID int64 `bson:"assistant_id"`
+	}](ctx, s.assistantDB, bson.M{"assistant_id": bson.M{"$gt": 0}})
+	if err != nil {
+		return nil, err
+	}
+
+	assignments := make(map[int64]int64, len(docs))
+	for _, doc := range docs {
+		assignments[doc.ChatID] = doc.AssistantID
+	}
+	return assignments, nil
+}
+
+func (s *mongoStore) ListAuth(ctx context.Context) (map[int64][]int64, error) {
+	docs, err := findAll[struct {
+		ChatID  int64   `bson:"_id"`
+		UserIDs []int64 `bson:"user_ids"`
+	}](ctx, s.authDB, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	auth := make(map[int64][]int64, len(docs))
+	for _, doc := range docs {
+		if len(doc.UserIDs) > 0 {
+			auth[doc.ChatID] = doc.UserIDs
+		}
+	}
+	return auth, nil
+}
+
+func (s *mongoStore) ListLanguages(ctx context.Context) (map[int64]string, error) {
+	docs, err := findAll[struct {
+		ChatID int64  `bson:"_id"`
+		Lang   string `bson:"lang"`
+	}](ctx, s.langDB, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	langs := make(map[int64]string, len(docs))
+	for _, doc := range docs {
+		langs[doc.ChatID] = doc.Lang
+	}
+	return langs, nil
+}
+
+func (s *mongoStore) ListAllLikes(ctx context.Context) ([]Like, error) {
+	return findAll[Like](ctx, s.likesDB, bson.M{})
+}
+
+func (s *mongoStore) PutChat(ctx context.Context, chat Chats) error {
+	_, err := s.chatDB.ReplaceOne(ctx, bson.M{"_id": chat.ID}, chat, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (s *mongoStore) PutPlaylist(ctx context.Context, playlist Playlist) error {
+	_, err := s.playlistDB.ReplaceOne(ctx, bson.M{"_id": playlist.ID}, playlist, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (s *mongoStore) ClearBackupData(ctx context.Context) error {
+	for _, coll := range []*mongo.Collection{s.chatDB, s.userDB, s.playlistDB, s.assistantDB, s.authDB, s.langDB, s.likesDB} {
+		if _, err := coll.DeleteMany(ctx, bson.M{}); err != nil {
+			return err
+		}
+	}
+	_, err := s.cacheDB.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": bson.A{string(BlacklistChats), string(BlacklistUsers)}}})
+	return err
 }
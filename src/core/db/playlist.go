@@ -10,14 +10,23 @@ package db
 
 import (
 	"ashokshau/tgmusic/src/utils"
-	"context"
 	"crypto/rand"
+	"errors"
 	"fmt"
+	"hash/fnv"
+	"slices"
+)
 
-	"go.mongodb.org/mongo-driver/v2/bson"
-	"go.mongodb.org/mongo-driver/v2/mongo"
+var (
+	// ErrSongPosition is returned when a song position is outside the playlist.
+	ErrSongPosition = errors.New("song position out of range")
+	// ErrPlaylistChanged is returned when the songs of a playlist changed since the caller last saw them.
+	ErrPlaylistChanged = errors.New("the playlist was changed meanwhile")
 )
 
+// playlistEditRetries is how often a song edit is retried when another edit was saved in between.
+const playlistEditRetries = 3
+
 // Song represents a single song in a playlist.
 type Song struct {
 	URL      string `json:"url" bson:"url"`
@@ -27,12 +36,26 @@ type Song struct {
 	Platform string `json:"platform" bson:"platform"`
 }
 
-// Playlist represents a user's playlist.
+// Playlist represents a user's playlist. A group playlist also has the ChatID of the group that owns it;
+// UserID is then the member who created it.
 type Playlist struct {
-	ID     string `bson:"_id"`
-	Name   string `bson:"name"`
-	UserID int64  `bson:"user_id"`
-	Songs  []Song `bson:"songs"`
+	ID            string             `json:"id" bson:"_id"`
+	Name          string             `json:"name" bson:"name"`
+	UserID        int64              `json:"user_id" bson:"user_id"`
+	Songs         []Song             `json:"songs" bson:"songs"`
+	Visibility    PlaylistVisibility `json:"visibility,omitempty" bson:"visibility,omitempty"`
+	Collaborators []int64            `json:"collaborators,omitempty" bson:"collaborators,omitempty"`
+	ChatID        int64              `json:"chat_id,omitempty" bson:"chat_id,omitempty"`
+	// Version counts the changes to Songs, so an edit based on an old copy of the songs is not saved over a newer one.
+	Version int64 `json:"version,omitempty" bson:"version,omitempty"`
+}
+
+// SongTag returns a short fingerprint of a song, used to check that the song at a position is still the one a
+// user saw before acting on it.
+func SongTag(song Song) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(song.TrackID + "\x00" + song.URL))
+	return fmt.Sprintf("%08x", h.Sum32())
 }
 
 // generateUniquePlaylistID generates a unique ID for a playlist.
@@ -44,124 +67,259 @@ func generateUniquePlaylistID() string {
 
 // CreatePlaylist creates a new playlist for a user.
 func (db *Database) CreatePlaylist(name string, userID int64) (string, error) {
+	return db.insertPlaylist(Playlist{Name: name, UserID: userID})
+}
+
+func (db *Database) insertPlaylist(playlist Playlist) (string, error) {
 	ctx, cancel := db.ctx()
 	defer cancel()
 
-	id := generateUniquePlaylistID()
-	playlist := Playlist{
-		ID:     id,
-		Name:   name,
-		UserID: userID,
-		Songs:  []Song{},
+	playlist.ID = generateUniquePlaylistID()
+	playlist.Songs = []Song{}
+	if playlist.Visibility == "" {
+		playlist.Visibility = VisibilityUnlisted
 	}
-	_, err := db.playlistDB.InsertOne(ctx, playlist)
-	if err != nil {
+	if err := db.store.InsertPlaylist(ctx, playlist); err != nil {
 		return "", err
 	}
-	return id, nil
+	return playlist.ID, nil
+}
+
+// GetPlaylist retrieves a playlist by its ID, or ErrPlaylistForbidden if it is private and the actor cannot see it.
+func (db *Database) GetPlaylist(a Actor, id string) (*Playlist, error) {
+	playlist, err := db.getPlaylist(id)
+	if err != nil {
+		return nil, err
+	}
+	if !db.canView(playlist, a) {
+		return nil, ErrPlaylistForbidden
+	}
+	return playlist, nil
 }
 
-// GetPlaylist retrieves a playlist by its ID.
-func (db *Database) GetPlaylist(id string) (*Playlist, error) {
+// getPlaylist loads a playlist without a permission check.
+func (db *Database) getPlaylist(id string) (*Playlist, error) {
 	ctx, cancel := db.ctx()
 	defer cancel()
 
-	var playlist Playlist
-	err := db.playlistDB.FindOne(ctx, bson.M{"_id": id}).Decode(&playlist)
+	playlist, err := db.store.GetPlaylist(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	return &playlist, nil
+	playlist.applyDefaults()
+	return playlist, nil
 }
 
-// DeletePlaylist deletes a playlist by its ID.
-func (db *Database) DeletePlaylist(id string, userID int64) error {
+// DeletePlaylist deletes a playlist. Only its owner, or an admin of the group that owns it, can delete it.
+func (db *Database) DeletePlaylist(a Actor, id string) error {
+	playlist, err := db.managedPlaylist(a, id)
+	if err != nil {
+		return err
+	}
+
 	ctx, cancel := db.ctx()
 	defer cancel()
 
-	_, err := db.playlistDB.DeleteOne(ctx, bson.M{"_id": id, "user_id": userID})
-	return err
+	return db.store.DeletePlaylist(ctx, id, playlist.UserID)
 }
 
-func (db *Database) songExists(id string, trackID string) bool {
+// AddSongToPlaylist adds a song to a playlist unless it is already there.
+func (db *Database) AddSongToPlaylist(a Actor, id string, song Song) error {
+	playlist, err := db.editablePlaylist(a, id)
+	if err != nil {
+		return err
+	}
+	if slices.ContainsFunc(playlist.Songs, func(s Song) bool { return s.TrackID == song.TrackID }) {
+		return nil
+	}
+
 	ctx, cancel := db.ctx()
 	defer cancel()
 
-	var playlist Playlist
-	err := db.playlistDB.FindOne(ctx, bson.M{"_id": id}).Decode(&playlist)
-	if err != nil {
-		return false
-	}
+	return db.store.AddPlaylistSong(ctx, id, song)
+}
 
-	for _, song := range playlist.Songs {
-		if song.TrackID == trackID {
-			return true
+// AddSongsToPlaylist appends the songs that are not already in the playlist, in order, and returns how many were added.
+func (db *Database) AddSongsToPlaylist(a Actor, id string, songs []Song) (int, error) {
+	var added int
+	err := db.editSongs(a, id, func(current []Song) ([]Song, error) {
+		merged := appendNewSongs(current, songs)
+		added = len(merged) - len(current)
+		if added == 0 {
+			return nil, nil
 		}
+		return merged, nil
+	})
+	if err != nil {
+		return 0, err
 	}
-	return false
+	return added, nil
 }
 
-// AddSongToPlaylist adds a song to a playlist.
-func (db *Database) AddSongToPlaylist(id string, song Song) error {
-	if db.songExists(id, song.TrackID) {
-		return nil
+// RemoveSongFromPlaylist removes a song from a playlist by its track ID.
+func (db *Database) RemoveSongFromPlaylist(a Actor, id string, trackID string) error {
+	playlist, err := db.editablePlaylist(a, id)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(playlist.Songs, func(s Song) bool { return s.TrackID == trackID }) {
+		return fmt.Errorf("track with ID %s not found in playlist", trackID)
 	}
 
 	ctx, cancel := db.ctx()
 	defer cancel()
 
-	_, err := db.playlistDB.UpdateOne(
-		ctx,
-		bson.M{"_id": id},
-		bson.M{"$push": bson.M{"songs": song}},
-	)
-	return err
+	if err := db.store.RemovePlaylistSong(ctx, id, trackID); err != nil {
+		return fmt.Errorf("error removing song: %w", err)
+	}
+
+	return nil
 }
 
-// RemoveSongFromPlaylist removes a song from a playlist by its track ID.
-func (db *Database) RemoveSongFromPlaylist(id string, trackID string) error {
-	if !db.songExists(id, trackID) {
-		return fmt.Errorf("track with ID %s not found in playlist", trackID)
+// RenamePlaylist changes the name of a playlist.
+func (db *Database) RenamePlaylist(a Actor, id, name string) error {
+	if _, err := db.managedPlaylist(a, id); err != nil {
+		return err
 	}
 
 	ctx, cancel := db.ctx()
 	defer cancel()
 
-	_, err := db.playlistDB.UpdateOne(
-		ctx,
-		bson.M{"_id": id},
-		bson.M{"$pull": bson.M{"songs": bson.M{"track_id": trackID}}},
-	)
+	return db.store.RenamePlaylist(ctx, id, name)
+}
+
+// MovePlaylistSong moves the song at position from to position to, shifting the songs in between.
+// Positions are zero-based. A non-empty tag must match SongTag of the song at from, or ErrPlaylistChanged is returned.
+func (db *Database) MovePlaylistSong(a Actor, id string, from, to int, tag string) error {
+	return db.editSongs(a, id, func(songs []Song) ([]Song, error) {
+		if err := checkSongTag(songs, from, tag); err != nil {
+			return nil, err
+		}
+		return moveSong(songs, from, to)
+	})
+}
+
+// RemovePlaylistSongAt removes the song at a zero-based position. Unlike RemoveSongFromPlaylist, other copies of the
+// same track stay. A non-empty tag must match SongTag of the song at the position, or ErrPlaylistChanged is returned.
+func (db *Database) RemovePlaylistSongAt(a Actor, id string, index int, tag string) error {
+	return db.editSongs(a, id, func(songs []Song) ([]Song, error) {
+		if err := checkSongTag(songs, index, tag); err != nil {
+			return nil, err
+		}
+		return slices.Delete(slices.Clone(songs), index, index+1), nil
+	})
+}
 
+// DedupePlaylist removes repeated songs, keeping the first of each, and returns how many were removed.
+func (db *Database) DedupePlaylist(a Actor, id string) (int, error) {
+	var removed int
+	err := db.editSongs(a, id, func(current []Song) ([]Song, error) {
+		var songs []Song
+		songs, removed = dedupeSongs(current)
+		if removed == 0 {
+			return nil, nil
+		}
+		return songs, nil
+	})
 	if err != nil {
-		return fmt.Errorf("error removing song: %w", err)
+		return 0, err
+	}
+	return removed, nil
+}
+
+// editSongs replaces the songs of a playlist the actor can edit with the result of edit. The songs are only saved if
+// no other change was saved since they were read; otherwise edit runs again on the new songs, a few times at most.
+// edit returns nil songs and no error to leave the playlist unchanged.
+func (db *Database) editSongs(a Actor, id string, edit func(songs []Song) ([]Song, error)) error {
+	for range playlistEditRetries {
+		playlist, err := db.editablePlaylist(a, id)
+		if err != nil {
+			return err
+		}
+
+		songs, err := edit(playlist.Songs)
+		if err != nil || songs == nil {
+			return err
+		}
+
+		ctx, cancel := db.ctx()
+		saved, err := db.store.SetPlaylistSongs(ctx, id, playlist.Version, songs)
+		cancel()
+		if err != nil || saved {
+			return err
+		}
 	}
+	return ErrPlaylistChanged
+}
 
+// checkSongTag reports ErrSongPosition if index is outside songs, and ErrPlaylistChanged if a tag is given and the
+// song at index no longer has it.
+func checkSongTag(songs []Song, index int, tag string) error {
+	if index < 0 || index >= len(songs) {
+		return ErrSongPosition
+	}
+	if tag != "" && SongTag(songs[index]) != tag {
+		return ErrPlaylistChanged
+	}
 	return nil
 }
 
-// GetUserPlaylists retrieves all playlists for a user.
-func (db *Database) GetUserPlaylists(userID int64) ([]Playlist, error) {
-	ctx, cancel := db.ctx()
-	defer cancel()
+func moveSong(songs []Song, from, to int) ([]Song, error) {
+	if from < 0 || from >= len(songs) || to < 0 || to >= len(songs) {
+		return nil, ErrSongPosition
+	}
 
-	var playlists []Playlist
-	cursor, err := db.playlistDB.Find(ctx, bson.M{"user_id": userID})
-	if err != nil {
-		return nil, err
+	song := songs[from]
+	moved := slices.Delete(slices.Clone(songs), from, from+1)
+	return slices.Insert(moved, to, song), nil
+}
+
+// dedupeSongs drops songs whose track ID or URL appeared earlier in the list.
+func dedupeSongs(songs []Song) ([]Song, int) {
+	seen := make(map[string]bool, len(songs)*2)
+	kept := make([]Song, 0, len(songs))
+	for _, song := range songs {
+		if markSeen(seen, song) {
+			kept = append(kept, song)
+		}
 	}
-	defer func(cursor *mongo.Cursor, ctx context.Context) {
-		_ = cursor.Close(ctx)
-	}(cursor, ctx)
+	return kept, len(songs) - len(kept)
+}
 
-	for cursor.Next(ctx) {
-		var playlist Playlist
-		if err := cursor.Decode(&playlist); err != nil {
-			return nil, err
+// appendNewSongs returns existing followed by the songs from add that are in neither list yet.
+func appendNewSongs(existing, add []Song) []Song {
+	seen := make(map[string]bool, (len(existing)+len(add))*2)
+	for _, song := range existing {
+		markSeen(seen, song)
+	}
+
+	merged := slices.Clip(slices.Clone(existing))
+	for _, song := range add {
+		if markSeen(seen, song) {
+			merged = append(merged, song)
 		}
-		playlists = append(playlists, playlist)
 	}
-	return playlists, nil
+	return merged
+}
+
+// markSeen records the song's track ID and URL and reports whether neither had been seen before.
+func markSeen(seen map[string]bool, song Song) bool {
+	if seen["id:"+song.TrackID] || (song.URL != "" && seen["url:"+song.URL]) {
+		return false
+	}
+	seen["id:"+song.TrackID] = true
+	if song.URL != "" {
+		seen["url:"+song.URL] = true
+	}
+	return true
+}
+
+// GetUserPlaylists retrieves all playlists for a user.
+func (db *Database) GetUserPlaylists(userID int64) ([]Playlist, error) {
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	return listPlaylists(db.store.ListUserPlaylists(ctx, userID))
 }
 
 func ConvertSongsToTracks(songs []Song) []utils.MusicTrack {
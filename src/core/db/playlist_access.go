@@ -0,0 +1,206 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package db
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// PlaylistVisibility controls who can see and play a playlist.
+type PlaylistVisibility string
+
+const (
+	// VisibilityPrivate limits a playlist to its owner and collaborators, and for a group playlist, the group.
+	VisibilityPrivate PlaylistVisibility = "private"
+	// VisibilityUnlisted lets anyone with the ID see and play the playlist. Playlists created before visibility
+	// existed are unlisted, since that is how they behaved.
+	VisibilityUnlisted PlaylistVisibility = "unlisted"
+	// VisibilityPublic also lists the playlist in search.
+	VisibilityPublic PlaylistVisibility = "public"
+)
+
+const (
+	// MaxCollaborators caps the collaborators of one playlist.
+	MaxCollaborators = 20
+	// maxSearchResults caps the playlists SearchPublicPlaylists returns.
+	maxSearchResults = 20
+)
+
+var (
+	// ErrPlaylistForbidden is returned when the actor may not see or change a playlist.
+	ErrPlaylistForbidden = errors.New("you do not have permission for this playlist")
+	// ErrTooManyCollaborators is returned when a playlist already has MaxCollaborators collaborators.
+	ErrTooManyCollaborators = errors.New("the playlist has too many collaborators")
+)
+
+// Actor is the user acting on a playlist and the chat they act from.
+type Actor struct {
+	UserID int64
+	ChatID int64
+}
+
+// ParsePlaylistVisibility returns the visibility with the given name.
+func ParsePlaylistVisibility(s string) (PlaylistVisibility, bool) {
+	switch v := PlaylistVisibility(strings.ToLower(s)); v {
+	case VisibilityPrivate, VisibilityUnlisted, VisibilityPublic:
+		return v, true
+	}
+	return "", false
+}
+
+func (p *Playlist) applyDefaults() {
+	if p.Visibility == "" {
+		p.Visibility = VisibilityUnlisted
+	}
+}
+
+// listPlaylists applies defaults to the playlists a store returned.
+func listPlaylists(playlists []Playlist, err error) ([]Playlist, error) {
+	for i := range playlists {
+		playlists[i].applyDefaults()
+	}
+	return playlists, err
+}
+
+// canManage reports whether a can rename, delete or share the playlist: its owner, or an admin of the owning group.
+func (db *Database) canManage(p *Playlist, a Actor) bool {
+	return p.UserID == a.UserID || (p.ChatID != 0 && db.IsAdmin(p.ChatID, a.UserID))
+}
+
+// canEdit reports whether a can add, remove and reorder songs: anyone who can manage it, and its collaborators.
+func (db *Database) canEdit(p *Playlist, a Actor) bool {
+	return db.canManage(p, a) || slices.Contains(p.Collaborators, a.UserID)
+}
+
+// canView reports whether a can see and play the playlist. A private group playlist is visible from its group.
+func (db *Database) canView(p *Playlist, a Actor) bool {
+	if p.Visibility != VisibilityPrivate {
+		return true
+	}
+	return (p.ChatID != 0 && p.ChatID == a.ChatID) || db.canEdit(p, a)
+}
+
+// canList reports whether a playlist shows up when a browses its owner's playlists. Unlike canView, an unlisted
+// playlist is only listed to those who could see it if it were private, since its ID is all that protects it.
+func (db *Database) canList(p *Playlist, a Actor) bool {
+	return p.Visibility == VisibilityPublic || (p.ChatID != 0 && p.ChatID == a.ChatID) || db.canEdit(p, a)
+}
+
+// CanEditPlaylist reports whether a can change the songs of the playlist.
+func (db *Database) CanEditPlaylist(p *Playlist, a Actor) bool {
+	return db.canEdit(p, a)
+}
+
+// editablePlaylist loads a playlist whose songs a can change.
+func (db *Database) editablePlaylist(a Actor, id string) (*Playlist, error) {
+	playlist, err := db.getPlaylist(id)
+	if err != nil {
+		return nil, err
+	}
+	if !db.canEdit(playlist, a) {
+		return nil, ErrPlaylistForbidden
+	}
+	return playlist, nil
+}
+
+// managedPlaylist loads a playlist a can rename, delete or share.
+func (db *Database) managedPlaylist(a Actor, id string) (*Playlist, error) {
+	playlist, err := db.getPlaylist(id)
+	if err != nil {
+		return nil, err
+	}
+	if !db.canManage(playlist, a) {
+		return nil, ErrPlaylistForbidden
+	}
+	return playlist, nil
+}
+
+// CreateChatPlaylist creates a playlist owned by a group. Only the group's admins can create one; the admin
+// cache must be loaded for chatID, otherwise the check fails.
+func (db *Database) CreateChatPlaylist(name string, chatID, userID int64) (string, error) {
+	if !db.IsAdmin(chatID, userID) {
+		return "", ErrPlaylistForbidden
+	}
+	return db.insertPlaylist(Playlist{Name: name, UserID: userID, ChatID: chatID})
+}
+
+// GetChatPlaylists retrieves the playlists owned by a group.
+func (db *Database) GetChatPlaylists(chatID int64) ([]Playlist, error) {
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	return listPlaylists(db.store.ListChatPlaylists(ctx, chatID))
+}
+
+// SearchPublicPlaylists returns public playlists whose name contains query, ignoring case.
+func (db *Database) SearchPublicPlaylists(query string) ([]Playlist, error) {
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	return listPlaylists(db.store.SearchPublicPlaylists(ctx, strings.TrimSpace(query), maxSearchResults))
+}
+
+// ListPlaylistsFor returns the playlists of userID that a may browse: all of them for the user, and for anyone
+// else only those canList allows.
+func (db *Database) ListPlaylistsFor(a Actor, userID int64) ([]Playlist, error) {
+	playlists, err := db.GetUserPlaylists(userID)
+	if err != nil || a.UserID == userID {
+		return playlists, err
+	}
+	return slices.DeleteFunc(playlists, func(p Playlist) bool { return !db.canList(&p, a) }), nil
+}
+
+// SetPlaylistVisibility changes who can see and play a playlist.
+func (db *Database) SetPlaylistVisibility(a Actor, id string, visibility PlaylistVisibility) error {
+	if _, err := db.managedPlaylist(a, id); err != nil {
+		return err
+	}
+
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	return db.store.SetPlaylistVisibility(ctx, id, visibility)
+}
+
+// AddPlaylistCollaborator lets userID add and remove songs in the playlist.
+func (db *Database) AddPlaylistCollaborator(a Actor, id string, userID int64) error {
+	playlist, err := db.managedPlaylist(a, id)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(playlist.Collaborators, userID) {
+		return nil
+	}
+	if len(playlist.Collaborators) >= MaxCollaborators {
+		return ErrTooManyCollaborators
+	}
+
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	return db.store.AddPlaylistCollaborator(ctx, id, userID)
+}
+
+// RemovePlaylistCollaborator revokes a collaborator. Collaborators can also remove themselves.
+func (db *Database) RemovePlaylistCollaborator(a Actor, id string, userID int64) error {
+	playlist, err := db.getPlaylist(id)
+	if err != nil {
+		return err
+	}
+	if a.UserID != userID && !db.canManage(playlist, a) {
+		return ErrPlaylistForbidden
+	}
+
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	return db.store.RemovePlaylistCollaborator(ctx, id, userID)
+}
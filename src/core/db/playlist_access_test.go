@@ -0,0 +1,109 @@
+package db
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPlaylistPermissions(t *testing.T) {
+	db := newTestDatabase(t)
+	owner := Actor{UserID: 1, ChatID: 1}
+	collaborator := Actor{UserID: 2, ChatID: -100}
+	stranger := Actor{UserID: 3, ChatID: -100}
+
+	id, err := db.CreatePlaylist("Mine", owner.UserID)
+	must(t, err)
+	must(t, db.AddPlaylistCollaborator(owner, id, collaborator.UserID))
+
+	// New playlists are unlisted: anyone with the ID can see them, only the owner and collaborators can edit.
+	if _, err = db.GetPlaylist(stranger, id); err != nil {
+		t.Errorf("GetPlaylist on an unlisted playlist: %v", err)
+	}
+	song := Song{TrackID: "1", URL: "https://example.com/1"}
+	if err = db.AddSongToPlaylist(stranger, id, song); !errors.Is(err, ErrPlaylistForbidden) {
+		t.Errorf("AddSongToPlaylist by a stranger: got %v, want ErrPlaylistForbidden", err)
+	}
+	must(t, db.AddSongToPlaylist(collaborator, id, song))
+
+	// Collaborators edit songs but cannot manage the playlist.
+	if err = db.RenamePlaylist(collaborator, id, "Ours"); !errors.Is(err, ErrPlaylistForbidden) {
+		t.Errorf("RenamePlaylist by a collaborator: got %v, want ErrPlaylistForbidden", err)
+	}
+	if err = db.SetPlaylistVisibility(collaborator, id, VisibilityPublic); !errors.Is(err, ErrPlaylistForbidden) {
+		t.Errorf("SetPlaylistVisibility by a collaborator: got %v, want ErrPlaylistForbidden", err)
+	}
+
+	must(t, db.SetPlaylistVisibility(owner, id, VisibilityPrivate))
+	if _, err = db.GetPlaylist(stranger, id); !errors.Is(err, ErrPlaylistForbidden) {
+		t.Errorf("GetPlaylist on a private playlist by a stranger: got %v, want ErrPlaylistForbidden", err)
+	}
+	if _, err = db.GetPlaylist(collaborator, id); err != nil {
+		t.Errorf("GetPlaylist on a private playlist by a collaborator: %v", err)
+	}
+
+	// Collaborators can leave on their own.
+	must(t, db.RemovePlaylistCollaborator(collaborator, id, collaborator.UserID))
+	if err = db.RemoveSongFromPlaylist(collaborator, id, "1"); !errors.Is(err, ErrPlaylistForbidden) {
+		t.Errorf("RemoveSongFromPlaylist by a former collaborator: got %v, want ErrPlaylistForbidden", err)
+	}
+
+	if err = db.DeletePlaylist(stranger, id); !errors.Is(err, ErrPlaylistForbidden) {
+		t.Errorf("DeletePlaylist by a stranger: got %v, want ErrPlaylistForbidden", err)
+	}
+	must(t, db.DeletePlaylist(owner, id))
+}
+
+func TestSearchPublicPlaylists(t *testing.T) {
+	db := newTestDatabase(t)
+	owner := Actor{UserID: 1}
+
+	public, err := db.CreatePlaylist("Road Trip", owner.UserID)
+	must(t, err)
+	must(t, db.SetPlaylistVisibility(owner, public, VisibilityPublic))
+	_, err = db.CreatePlaylist("Road Unlisted", owner.UserID)
+	must(t, err)
+
+	playlists, err := db.SearchPublicPlaylists(" road ")
+	must(t, err)
+	if len(playlists) != 1 || playlists[0].ID != public {
+		t.Errorf("SearchPublicPlaylists = %+v, want only %s", playlists, public)
+	}
+}
+
+func TestListPlaylistsFor(t *testing.T) {
+	db := newTestDatabase(t)
+	owner := Actor{UserID: 1, ChatID: 1}
+	collaborator := Actor{UserID: 2, ChatID: -100}
+	stranger := Actor{UserID: 3, ChatID: -100}
+
+	private, err := db.CreatePlaylist("Private", owner.UserID)
+	must(t, err)
+	must(t, db.SetPlaylistVisibility(owner, private, VisibilityPrivate))
+	must(t, db.AddPlaylistCollaborator(owner, private, collaborator.UserID))
+	_, err = db.CreatePlaylist("Unlisted", owner.UserID)
+	must(t, err)
+	public, err := db.CreatePlaylist("Public", owner.UserID)
+	must(t, err)
+	must(t, db.SetPlaylistVisibility(owner, public, VisibilityPublic))
+
+	for _, tt := range []struct {
+		name  string
+		actor Actor
+		want  int
+	}{
+		{"owner", owner, 3},
+		{"collaborator", collaborator, 2},
+		{"stranger", stranger, 1},
+	} {
+		playlists, err := db.ListPlaylistsFor(tt.actor, owner.UserID)
+		must(t, err)
+		if len(playlists) != tt.want {
+			t.Errorf("ListPlaylistsFor(%s) returned %d playlists, want %d", tt.name, len(playlists), tt.want)
+		}
+		for _, p := range playlists {
+			if tt.actor != owner && p.ID != public && p.ID != private {
+				t.Errorf("ListPlaylistsFor(%s) listed %s", tt.name, p.Name)
+			}
+		}
+	}
+}
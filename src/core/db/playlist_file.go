@@ -0,0 +1,221 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package db
+
+import (
+	"ashokshau/tgmusic/src/utils"
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// PlaylistFormat is a file format playlists can be exported to and imported from.
+type PlaylistFormat string
+
+const (
+	FormatM3U  PlaylistFormat = "m3u"
+	FormatJSON PlaylistFormat = "json"
+	FormatCSV  PlaylistFormat = "csv"
+)
+
+// csvHeader is the column order of exported CSV files. Imports match columns by name, so any order works.
+var csvHeader = []string{"name", "url", "track_id", "duration", "platform"}
+
+// ParsePlaylistFormat returns the format for a format name or file extension, such as "csv" or ".m3u8".
+func ParsePlaylistFormat(s string) (PlaylistFormat, bool) {
+	switch strings.ToLower(strings.TrimPrefix(s, ".")) {
+	case "m3u", "m3u8":
+		return FormatM3U, true
+	case "json":
+		return FormatJSON, true
+	case "csv":
+		return FormatCSV, true
+	}
+	return "", false
+}
+
+// PlaylistFile is a playlist read from a file. Songs without a TrackID only have a URL and must be resolved before saving.
+// ReadPlaylist also clears the TrackID of songs it does not trust, so they are resolved like the others.
+type PlaylistFile struct {
+	Name  string `json:"name"`
+	Songs []Song `json:"songs"`
+}
+
+// WritePlaylist writes a playlist in the given format.
+func WritePlaylist(w io.Writer, p *Playlist, format PlaylistFormat) error {
+	switch format {
+	case FormatM3U:
+		return writeM3U(w, p)
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(PlaylistFile{Name: p.Name, Songs: p.Songs})
+	case FormatCSV:
+		return writeCSV(w, p)
+	}
+	return fmt.Errorf("unsupported playlist format %q", format)
+}
+
+// ReadPlaylist reads a playlist file in the given format. Only songs that trustedSong accepts keep their track ID
+// and platform; entries left with neither a URL nor a track ID are skipped.
+func ReadPlaylist(r io.Reader, format PlaylistFormat) (*PlaylistFile, error) {
+	var (
+		file *PlaylistFile
+		err  error
+	)
+	switch format {
+	case FormatM3U:
+		file, err = readM3U(r)
+	case FormatJSON:
+		file = &PlaylistFile{}
+		err = json.NewDecoder(r).Decode(file)
+	case FormatCSV:
+		file, err = readCSV(r)
+	default:
+		return nil, fmt.Errorf("unsupported playlist format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	songs := file.Songs[:0]
+	for _, song := range file.Songs {
+		song.URL = strings.TrimSpace(song.URL)
+		song.TrackID = strings.TrimSpace(song.TrackID)
+		if !trustedSong(song) {
+			song.TrackID, song.Platform = "", ""
+		}
+		if song.URL != "" || song.TrackID != "" {
+			songs = append(songs, song)
+		}
+	}
+	file.Songs = songs
+	file.Name = strings.TrimSpace(file.Name)
+	return file, nil
+}
+
+// trustedSong reports whether an imported song can be saved as it is. Direct links and Telegram files are played
+// from the URL or file ID as given, so they, and songs without a web URL, must be looked up again instead.
+func trustedSong(song Song) bool {
+	if song.Platform == utils.DirectLink || song.Platform == utils.Telegram {
+		return false
+	}
+	return strings.HasPrefix(song.URL, "https://") || strings.HasPrefix(song.URL, "http://")
+}
+
+func writeM3U(w io.Writer, p *Playlist) error {
+	bw := bufio.NewWriter(w)
+	_, _ = fmt.Fprintf(bw, "#EXTM3U\n#PLAYLIST:%s\n", oneLine(p.Name))
+	for _, song := range p.Songs {
+		_, _ = fmt.Fprintf(bw, "#EXTINF:%d,%s\n%s\n", song.Duration, oneLine(song.Name), song.URL)
+	}
+	return bw.Flush()
+}
+
+// readM3U reads an extended or plain M3U file. Each non-comment line is a URL; a preceding #EXTINF gives its duration and title.
+func readM3U(r io.Reader) (*PlaylistFile, error) {
+	file := &PlaylistFile{}
+	var pending Song
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "#PLAYLIST:"):
+			file.Name = strings.TrimPrefix(line, "#PLAYLIST:")
+		case strings.HasPrefix(line, "#EXTINF:"):
+			info, title, _ := strings.Cut(strings.TrimPrefix(line, "#EXTINF:"), ",")
+			// Attributes such as tvg-id may follow the duration.
+			duration, _, _ := strings.Cut(info, " ")
+			pending.Duration, _ = strconv.Atoi(duration)
+			pending.Duration = max(pending.Duration, 0)
+			pending.Name = strings.TrimSpace(title)
+		case strings.HasPrefix(line, "#"):
+		default:
+			pending.URL = line
+			file.Songs = append(file.Songs, pending)
+			pending = Song{}
+		}
+	}
+	return file, scanner.Err()
+}
+
+func writeCSV(w io.Writer, p *Playlist) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write(csvHeader)
+	for _, song := range p.Songs {
+		_ = cw.Write([]string{song.Name, song.URL, song.TrackID, strconv.Itoa(song.Duration), song.Platform})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// readCSV reads a CSV file with a header row. A url or track_id column is required; the others are optional.
+func readCSV(r io.Reader) (*PlaylistFile, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("the file is empty")
+		}
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	_, hasURL := columns["url"]
+	_, hasID := columns["track_id"]
+	if !hasURL && !hasID {
+		return nil, errors.New("the header needs a url or track_id column")
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	file := &PlaylistFile{}
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		duration, _ := strconv.Atoi(field(record, "duration"))
+		file.Songs = append(file.Songs, Song{
+			URL:      field(record, "url"),
+			Name:     field(record, "name"),
+			TrackID:  field(record, "track_id"),
+			Duration: max(duration, 0),
+			Platform: field(record, "platform"),
+		})
+	}
+	return file, nil
+}
+
+// oneLine replaces line breaks so a value fits on a single M3U line.
+func oneLine(s string) string {
+	return strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(s)
+}
@@ -0,0 +1,113 @@
+package db
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPlaylistFileRoundTrip(t *testing.T) {
+	playlist := &Playlist{
+		ID:     "tgpl_test",
+		Name:   "Road trip",
+		UserID: 1,
+		Songs: []Song{
+			{URL: "https://youtu.be/a", Name: "First, with a comma", TrackID: "a", Duration: 200, Platform: "youtube"},
+			{URL: "https://open.spotify.com/track/b", Name: "Second \"quoted\"", TrackID: "b", Duration: 95, Platform: "spotify"},
+		},
+	}
+
+	for _, format := range []PlaylistFormat{FormatJSON, FormatCSV} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WritePlaylist(&buf, playlist, format); err != nil {
+				t.Fatal(err)
+			}
+			file, err := ReadPlaylist(&buf, format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(file.Songs, playlist.Songs) {
+				t.Errorf("songs = %+v, want %+v", file.Songs, playlist.Songs)
+			}
+		})
+	}
+
+	// M3U only keeps the URL, title and duration.
+	var buf bytes.Buffer
+	if err := WritePlaylist(&buf, playlist, FormatM3U); err != nil {
+		t.Fatal(err)
+	}
+	file, err := ReadPlaylist(&buf, FormatM3U)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.Name != playlist.Name {
+		t.Errorf("name = %q, want %q", file.Name, playlist.Name)
+	}
+	for i, song := range file.Songs {
+		want := playlist.Songs[i]
+		if song.URL != want.URL || song.Name != want.Name || song.Duration != want.Duration || song.TrackID != "" {
+			t.Errorf("song %d = %+v, want %+v without a track ID", i, song, want)
+		}
+	}
+}
+
+func TestReadPlaylist(t *testing.T) {
+	m3u := "#EXTM3U\n\n#EXTINF:-1 tvg-id=\"x\",Live radio\nhttps://example.com/live\n# a comment\nhttps://example.com/plain\n"
+	file, err := ReadPlaylist(strings.NewReader(m3u), FormatM3U)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Song{{URL: "https://example.com/live", Name: "Live radio"}, {URL: "https://example.com/plain"}}
+	if !slices.Equal(file.Songs, want) {
+		t.Errorf("m3u songs = %+v, want %+v", file.Songs, want)
+	}
+
+	csvFile := "URL, Name\nhttps://example.com/a,A\n,Missing URL\n"
+	file, err = ReadPlaylist(strings.NewReader(csvFile), FormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []Song{{URL: "https://example.com/a", Name: "A"}}
+	if !slices.Equal(file.Songs, want) {
+		t.Errorf("csv songs = %+v, want %+v", file.Songs, want)
+	}
+
+	// Direct links, Telegram files and songs without a web URL lose their track ID and must be looked up again.
+	jsonFile := `{"songs": [
+		{"url": "https://youtu.be/a", "track_id": "a", "platform": "youtube"},
+		{"url": "file:///etc/passwd", "track_id": "b", "platform": "direct_link"},
+		{"url": "http://127.0.0.1/admin", "track_id": "c", "platform": "direct_link"},
+		{"url": "https://t.me/c/1/2", "track_id": "d", "platform": "telegram"},
+		{"url": "/music/e.mp3", "track_id": "e", "platform": "youtube"},
+		{"track_id": "f", "platform": "spotify"}
+	]}`
+	file, err = ReadPlaylist(strings.NewReader(jsonFile), FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []Song{
+		{URL: "https://youtu.be/a", TrackID: "a", Platform: "youtube"},
+		{URL: "file:///etc/passwd"},
+		{URL: "http://127.0.0.1/admin"},
+		{URL: "https://t.me/c/1/2"},
+		{URL: "/music/e.mp3"},
+	}
+	if !slices.Equal(file.Songs, want) {
+		t.Errorf("json songs = %+v, want %+v", file.Songs, want)
+	}
+
+	if _, err = ReadPlaylist(strings.NewReader("name,duration\nA,10\n"), FormatCSV); err == nil {
+		t.Error("expected an error for a CSV file without url or track_id")
+	}
+}
+
+func TestParsePlaylistFormat(t *testing.T) {
+	for in, want := range map[string]PlaylistFormat{".M3U8": FormatM3U, "json": FormatJSON, ".csv": FormatCSV, "txt": ""} {
+		if got, _ := ParsePlaylistFormat(in); got != want {
+			t.Errorf("ParsePlaylistFormat(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func songs(ids ...string) []Song {
+	out := make([]Song, len(ids))
+	for i, id := range ids {
+		out[i] = Song{TrackID: id, URL: "https://example.com/" + id}
+	}
+	return out
+}
+
+func trackIDs(list []Song) []string {
+	ids := make([]string, len(list))
+	for i, s := range list {
+		ids[i] = s.TrackID
+	}
+	return ids
+}
+
+func TestMoveSong(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to int
+		want     []string
+		wantErr  bool
+	}{
+		{"down", 0, 2, []string{"b", "c", "a", "d"}, false},
+		{"up", 3, 1, []string{"a", "d", "b", "c"}, false},
+		{"to last", 1, 3, []string{"a", "c", "d", "b"}, false},
+		{"same place", 2, 2, []string{"a", "b", "c", "d"}, false},
+		{"from out of range", 4, 0, nil, true},
+		{"to out of range", 0, -1, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := songs("a", "b", "c", "d")
+			got, err := moveSong(original, tt.from, tt.to)
+			if tt.wantErr {
+				if !errors.Is(err, ErrSongPosition) {
+					t.Fatalf("moveSong() error = %v, want ErrSongPosition", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(trackIDs(got), tt.want) {
+				t.Errorf("moveSong() = %v, want %v", trackIDs(got), tt.want)
+			}
+			if !slices.Equal(trackIDs(original), []string{"a", "b", "c", "d"}) {
+				t.Errorf("moveSong() modified its input: %v", trackIDs(original))
+			}
+		})
+	}
+}
+
+func TestDedupeSongs(t *testing.T) {
+	list := songs("a", "b", "a", "c", "b")
+	// Same URL under a different track ID.
+	list = append(list, Song{TrackID: "d", URL: "https://example.com/c"})
+
+	got, removed := dedupeSongs(list)
+	if removed != 3 {
+		t.Errorf("removed = %d, want 3", removed)
+	}
+	if !slices.Equal(trackIDs(got), []string{"a", "b", "c"}) {
+		t.Errorf("dedupeSongs() = %v, want [a b c]", trackIDs(got))
+	}
+}
+
+func TestAppendNewSongs(t *testing.T) {
+	existing := songs("a", "b")
+	got := appendNewSongs(existing, songs("b", "c", "c", "d"))
+	if !slices.Equal(trackIDs(got), []string{"a", "b", "c", "d"}) {
+		t.Errorf("appendNewSongs() = %v, want [a b c d]", trackIDs(got))
+	}
+	if len(existing) != 2 {
+		t.Errorf("appendNewSongs() modified its input: %v", trackIDs(existing))
+	}
+}
+
+func TestPlaylistPositionEdits(t *testing.T) {
+	db := newTestDatabase(t)
+	owner := Actor{UserID: 1}
+	id, err := db.CreatePlaylist("Mix", owner.UserID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.AddSongsToPlaylist(owner, id, songs("a", "b", "c")); err != nil {
+		t.Fatal(err)
+	}
+	// The same track twice, as older playlists can have: removing one copy by position must keep the other.
+	if err := db.store.AddPlaylistSong(context.Background(), id, Song{TrackID: "a", URL: "https://example.com/a2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	current := func() []Song {
+		t.Helper()
+		p, err := db.GetPlaylist(owner, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p.Songs
+	}
+
+	list := current()
+	if len(list) != 4 {
+		t.Fatalf("setup: playlist has %v", trackIDs(list))
+	}
+	if err := db.RemovePlaylistSongAt(owner, id, 3, SongTag(list[3])); err != nil {
+		t.Fatalf("RemovePlaylistSongAt: %v", err)
+	}
+	if got := trackIDs(current()); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("after removing position 3: %v, want [a b c]", got)
+	}
+
+	// A button made before the list changed carries the tag of the song that used to be there.
+	staleTag := SongTag(list[1])
+	if err := db.MovePlaylistSong(owner, id, 0, 2, ""); err != nil {
+		t.Fatalf("MovePlaylistSong: %v", err)
+	}
+	if err := db.RemovePlaylistSongAt(owner, id, 1, staleTag); !errors.Is(err, ErrPlaylistChanged) {
+		t.Errorf("RemovePlaylistSongAt with a stale tag: got %v, want ErrPlaylistChanged", err)
+	}
+	if err := db.MovePlaylistSong(owner, id, 1, 0, staleTag); !errors.Is(err, ErrPlaylistChanged) {
+		t.Errorf("MovePlaylistSong with a stale tag: got %v, want ErrPlaylistChanged", err)
+	}
+	if err := db.RemovePlaylistSongAt(owner, id, 5, ""); !errors.Is(err, ErrSongPosition) {
+		t.Errorf("RemovePlaylistSongAt out of range: got %v, want ErrSongPosition", err)
+	}
+	if got := trackIDs(current()); !slices.Equal(got, []string{"b", "c", "a"}) {
+		t.Errorf("after the refused edits: %v, want [b c a]", got)
+	}
+}
@@ -0,0 +1,133 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package db
+
+import (
+	"ashokshau/tgmusic/config"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// AssistantSession is an assistant account added at runtime with /assistants.
+// Session holds the decrypted session string; it is stored encrypted with SESSION_KEY.
+type AssistantSession struct {
+	ID      int64     `bson:"_id"`
+	Session string    `bson:"session"`
+	AddedBy int64     `bson:"added_by"`
+	AddedAt time.Time `bson:"added_at"`
+}
+
+// AddAssistantSession stores the session string of an assistant, keyed by its Telegram user ID.
+func (db *Database) AddAssistantSession(assistantID int64, session string, addedBy int64) error {
+	sealed, err := sealSession(session)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	return db.store.SaveAssistantSession(ctx, AssistantSession{
+		ID:      assistantID,
+		Session: sealed,
+		AddedBy: addedBy,
+		AddedAt: time.Now(),
+	})
+}
+
+// RemoveAssistantSession deletes a stored assistant session.
+func (db *Database) RemoveAssistantSession(assistantID int64) error {
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	return db.store.RemoveAssistantSession(ctx, assistantID)
+}
+
+// HasAssistantSession reports whether an assistant was added at runtime rather than through STRING1..STRING10.
+func (db *Database) HasAssistantSession(assistantID int64) bool {
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	found, err := db.store.HasAssistantSession(ctx, assistantID)
+	return err == nil && found
+}
+
+// GetAssistantSessions returns every stored assistant session, decrypted.
+// Sessions that cannot be decrypted, for example after SESSION_KEY changed, are skipped.
+func (db *Database) GetAssistantSessions() ([]AssistantSession, error) {
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	stored, err := db.store.ListAssistantSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]AssistantSession, 0, len(stored))
+	for _, s := range stored {
+		s.Session, err = openSession(s.Session)
+		if err != nil {
+			slog.Warn("[DB] Skipping an assistant session that could not be decrypted", "assistant", s.ID, "error", err)
+			continue
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, nil
+}
+
+// sessionCipher returns an AES-GCM cipher keyed with SESSION_KEY.
+func sessionCipher() (cipher.AEAD, error) {
+	key := sha256.Sum256([]byte(config.Conf.SessionKey))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func sealSession(session string) (string, error) {
+	gcm, err := sessionCipher()
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(session), nil)), nil
+}
+
+func openSession(sealed string) (string, error) {
+	gcm, err := sessionCipher()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return "", fmt.Errorf("invalid session encoding: %w", err)
+	}
+	if len(data) < gcm.NonceSize() {
+		return "", errors.New("session is too short")
+	}
+
+	plain, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", errors.New("wrong SESSION_KEY or corrupted session")
+	}
+	return string(plain), nil
+}
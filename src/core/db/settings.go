@@ -0,0 +1,33 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package db
+
+// GetSettings returns every runtime setting override, keyed by setting name.
+func (db *Database) GetSettings() (map[string]string, error) {
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	return db.store.GetSettings(ctx)
+}
+
+// SetSetting stores a runtime setting override.
+func (db *Database) SetSetting(key, value string) error {
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	return db.store.SetSetting(ctx, key, value)
+}
+
+// DeleteSetting removes a runtime setting override so the environment value applies again.
+func (db *Database) DeleteSetting(key string) error {
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	return db.store.DeleteSetting(ctx, key)
+}
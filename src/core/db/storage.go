@@ -0,0 +1,203 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package db
+
+import (
+	"ashokshau/tgmusic/config"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound is returned by a Storage when the requested record does not exist.
+var ErrNotFound = errors.New("not found")
+
+// Storage is a database backend. Database wraps it with caching; backends only store and load records.
+// Every backend must pass the conformance suite in storage_test.go.
+type Storage interface {
+	ChatStore
+	UserStore
+	PlaylistStore
+	AssistantStore
+	AuthStore
+	LangStore
+	BlacklistStore
+	LikeStore
+	BotStore
+	BackupStore
+
+	// Ping checks that the backend is reachable.
+	Ping(ctx context.Context) error
+	// Close releases the backend's connection or file.
+	Close(ctx context.Context) error
+}
+
+// Chat fields that can be updated with SetChatField, named as they are stored.
+const (
+	chatPlayType     = "play_type"
+	chatAdminPlay    = "admin_play"
+	chatAdminMode    = "admin_mode"
+	chatCmdDelete    = "cmd_delete"
+	chatVideoQuality = "video_quality"
+	chatAudioProfile = "audio_profile"
+)
+
+// ChatStore stores per-chat settings.
+type ChatStore interface {
+	// GetChat returns the chat, or ErrNotFound.
+	GetChat(ctx context.Context, chatID int64) (*Chats, error)
+	// AddChat creates the chat with default settings if it does not exist yet.
+	AddChat(ctx context.Context, chatID int64) error
+	// SetChatField sets one of the chat* fields, creating the chat if needed.
+	SetChatField(ctx context.Context, chatID int64, field string, value any) error
+	ListChats(ctx context.Context) ([]Chats, error)
+}
+
+// UserStore stores the users that have started the bot.
+type UserStore interface {
+	AddUser(ctx context.Context, userID int64) error
+	RemoveUser(ctx context.Context, userID int64) error
+	HasUser(ctx context.Context, userID int64) (bool, error)
+	ListUsers(ctx context.Context) ([]int64, error)
+}
+
+// PlaylistStore stores user playlists.
+type PlaylistStore interface {
+	InsertPlaylist(ctx context.Context, playlist Playlist) error
+	// GetPlaylist returns the playlist, or ErrNotFound.
+	GetPlaylist(ctx context.Context, id string) (*Playlist, error)
+	// DeletePlaylist deletes the playlist if it belongs to the user.
+	DeletePlaylist(ctx context.Context, id string, userID int64) error
+	AddPlaylistSong(ctx context.Context, id string, song Song) error
+	RemovePlaylistSong(ctx context.Context, id string, trackID string) error
+	RenamePlaylist(ctx context.Context, id string, name string) error
+	// SetPlaylistSongs replaces the playlist's songs if its Version is still version, and reports whether it did.
+	// Every change to the songs increments the version.
+	SetPlaylistSongs(ctx context.Context, id string, version int64, songs []Song) (bool, error)
+	ListUserPlaylists(ctx context.Context, userID int64) ([]Playlist, error)
+	ListChatPlaylists(ctx context.Context, chatID int64) ([]Playlist, error)
+	// SearchPublicPlaylists returns up to limit public playlists whose name contains query, ignoring case.
+	SearchPublicPlaylists(ctx context.Context, query string, limit int) ([]Playlist, error)
+	SetPlaylistVisibility(ctx context.Context, id string, visibility PlaylistVisibility) error
+	AddPlaylistCollaborator(ctx context.Context, id string, userID int64) error
+	RemovePlaylistCollaborator(ctx context.Context, id string, userID int64) error
+}
+
+// AssistantStore stores which assistant serves each chat.
+type AssistantStore interface {
+	// GetAssistant returns the chat's assistant ID, or 0 if none is assigned.
+	GetAssistant(ctx context.Context, chatID int64) (int64, error)
+	SetAssistant(ctx context.Context, chatID, assistantID int64) error
+	RemoveAssistant(ctx context.Context, chatID int64) error
+	// AssignAssistant sets the chat's assistant only if it has none, and returns the assistant the chat ends up with.
+	AssignAssistant(ctx context.Context, chatID, proposed int64) (int64, error)
+	// UnassignAssistant removes every assignment of an assistant and returns how many chats lost it.
+	UnassignAssistant(ctx context.Context, assistantID int64) (int64, error)
+	CountAssistantChats(ctx context.Context) (map[int64]int64, error)
+	ListAssistantChats(ctx context.Context, assistantID int64) ([]int64, error)
+	// ClearAssistants removes every assignment and returns how many there were.
+	ClearAssistants(ctx context.Context) (int64, error)
+}
+
+// AuthStore stores the users authorized to control playback in each chat.
+type AuthStore interface {
+	AddAuthUser(ctx context.Context, chatID, userID int64) error
+	RemoveAuthUser(ctx context.Context, chatID, userID int64) error
+	GetAuthUsers(ctx context.Context, chatID int64) ([]int64, error)
+}
+
+// LangStore stores the language of each chat.
+type LangStore interface {
+	// GetLanguage returns the chat's language code, or ErrNotFound.
+	GetLanguage(ctx context.Context, chatID int64) (string, error)
+	SetLanguage(ctx context.Context, chatID int64, langCode string) error
+}
+
+// BlacklistKind is the list a blacklist entry belongs to.
+type BlacklistKind string
+
+const (
+	BlacklistChats BlacklistKind = "bl_chats"
+	BlacklistUsers BlacklistKind = "bl_users"
+)
+
+// BlacklistStore stores blacklisted chats and users.
+type BlacklistStore interface {
+	// AddBlacklisted adds the entry, replacing the details of an existing one.
+	AddBlacklisted(ctx context.Context, kind BlacklistKind, entry BlacklistEntry) error
+	RemoveBlacklisted(ctx context.Context, kind BlacklistKind, id int64) error
+	// GetBlacklisted lists the entries in the order they were first added.
+	// IDs added before details were stored get an entry with only the ID set.
+	GetBlacklisted(ctx context.Context, kind BlacklistKind) ([]BlacklistEntry, error)
+}
+
+// LikeStore stores the songs each user has liked, one record per user and track.
+type LikeStore interface {
+	// AddLike stores the like and reports whether the user had not liked the track before.
+	AddLike(ctx context.Context, like Like) (bool, error)
+	// RemoveLike deletes the like and reports whether there was one.
+	RemoveLike(ctx context.Context, userID int64, trackID string) (bool, error)
+	// ListLikes returns the user's likes, newest first.
+	ListLikes(ctx context.Context, userID int64) ([]Like, error)
+	CountLikes(ctx context.Context, trackID string) (int, error)
+	// TopLikes returns up to limit of the most liked songs, most liked first.
+	TopLikes(ctx context.Context, limit int) ([]SongLikes, error)
+}
+
+// BotStore stores bot-wide state: the logger switch, runtime developers, setting overrides and assistant sessions.
+type BotStore interface {
+	GetLoggerStatus(ctx context.Context) (bool, error)
+	SetLoggerStatus(ctx context.Context, status bool) error
+
+	GetSudoers(ctx context.Context) ([]int64, error)
+	AddSudo(ctx context.Context, userID int64) error
+	RemoveSudo(ctx context.Context, userID int64) error
+
+	GetSettings(ctx context.Context) (map[string]string, error)
+	SetSetting(ctx context.Context, key, value string) error
+	DeleteSetting(ctx context.Context, key string) error
+
+	// SaveAssistantSession stores a session, which the caller has already encrypted.
+	SaveAssistantSession(ctx context.Context, session AssistantSession) error
+	RemoveAssistantSession(ctx context.Context, assistantID int64) error
+	HasAssistantSession(ctx context.Context, assistantID int64) (bool, error)
+	ListAssistantSessions(ctx context.Context) ([]AssistantSession, error)
+}
+
+// BackupStore reads and writes whole collections for backups.
+type BackupStore interface {
+	ListPlaylists(ctx context.Context) ([]Playlist, error)
+	// ListAssignments maps each assigned chat to its assistant ID.
+	ListAssignments(ctx context.Context) (map[int64]int64, error)
+	// ListAuth maps each chat with authorized users to their IDs.
+	ListAuth(ctx context.Context) (map[int64][]int64, error)
+	ListLanguages(ctx context.Context) (map[int64]string, error)
+	ListAllLikes(ctx context.Context) ([]Like, error)
+
+	// PutChat stores the chat, replacing all of its settings.
+	PutChat(ctx context.Context, chat Chats) error
+	// PutPlaylist stores the playlist, replacing one with the same ID.
+	PutPlaylist(ctx context.Context, playlist Playlist) error
+	// ClearBackupData deletes every chat, user, playlist, assistant assignment, auth user, language, like and
+	// blacklist entry.
+	// Sudoers, settings, the logger switch and assistant sessions are kept.
+	ClearBackupData(ctx context.Context) error
+}
+
+// openStorage opens the backend selected by DB_BACKEND.
+func openStorage(ctx context.Context) (Storage, error) {
+	switch config.Conf.DbBackend {
+	case config.StorageMongo:
+		return openMongo(ctx, config.Conf.MongoUri, config.Conf.DbName)
+	case config.StorageBolt:
+		return openBolt(config.Conf.DbPath)
+	default:
+		return nil, fmt.Errorf("unknown DB_BACKEND %q", config.Conf.DbBackend)
+	}
+}
@@ -0,0 +1,672 @@
+package db
+
+import (
+	"ashokshau/tgmusic/src/utils"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestBoltStorage runs the conformance suite against the bolt backend.
+func TestBoltStorage(t *testing.T) {
+	testStorage(t, func(t *testing.T) Storage {
+		s, err := openBolt(filepath.Join(t.TempDir(), "test.db"))
+		if err != nil {
+			t.Fatalf("openBolt: %v", err)
+		}
+		return s
+	})
+}
+
+// TestMongoStorage runs the conformance suite against MongoDB when TEST_MONGO_URI is set.
+func TestMongoStorage(t *testing.T) {
+	skipWithoutMongo(t)
+	testStorage(t, func(t *testing.T) Storage {
+		return openTestMongo(t)
+	})
+}
+
+func skipWithoutMongo(t *testing.T) {
+	t.Helper()
+	if os.Getenv("TEST_MONGO_URI") == "" {
+		t.Skip("TEST_MONGO_URI is not set")
+	}
+}
+
+// openTestMongo opens a throwaway database on TEST_MONGO_URI that is dropped after the test.
+func openTestMongo(t *testing.T) *mongoStore {
+	t.Helper()
+	skipWithoutMongo(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	name := fmt.Sprintf("tgmusic_test_%d", time.Now().UnixNano())
+	s, err := openMongo(ctx, os.Getenv("TEST_MONGO_URI"), name)
+	if err != nil {
+		t.Fatalf("openMongo: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = s.client.Database(name).Drop(context.Background())
+		_ = s.Close(context.Background())
+	})
+	return s
+}
+
+// testStorage checks the behaviour every Storage backend must share. open returns a fresh, empty store.
+func testStorage(t *testing.T, open func(t *testing.T) Storage) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, ctx context.Context, s Storage)
+	}{
+		{"Chats", testChats},
+		{"Users", testUsers},
+		{"Playlists", testPlaylists},
+		{"Assistants", testAssistants},
+		{"Auth", testAuth},
+		{"Lang", testLang},
+		{"Blacklist", testBlacklist},
+		{"BotState", testBotState},
+		{"Sessions", testSessions},
+		{"Likes", testLikes},
+		{"BackupData", testBackupData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := open(t)
+			ctx := context.Background()
+			t.Cleanup(func() { _ = s.Close(ctx) })
+
+			if err := s.Ping(ctx); err != nil {
+				t.Fatalf("Ping: %v", err)
+			}
+			tt.fn(t, ctx, s)
+		})
+	}
+}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func sorted(ids []int64) []int64 {
+	ids = slices.Clone(ids)
+	slices.Sort(ids)
+	return ids
+}
+
+func testChats(t *testing.T, ctx context.Context, s Storage) {
+	if _, err := s.GetChat(ctx, -100); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetChat on a missing chat: got %v, want ErrNotFound", err)
+	}
+
+	must(t, s.AddChat(ctx, -100))
+	chat, err := s.GetChat(ctx, -100)
+	must(t, err)
+	if chat.ID != -100 || chat.PlayType != 0 || chat.AdminPlay || chat.AdminMode != "" {
+		t.Errorf("new chat has non-default settings: %+v", chat)
+	}
+
+	quality := utils.VideoQuality{Height: 720, Fps: 30}
+	must(t, s.SetChatField(ctx, -100, chatPlayType, 1))
+	must(t, s.SetChatField(ctx, -100, chatAdminPlay, true))
+	must(t, s.SetChatField(ctx, -100, chatAdminMode, "auth"))
+	must(t, s.SetChatField(ctx, -100, chatCmdDelete, true))
+	must(t, s.SetChatField(ctx, -100, chatVideoQuality, quality))
+	must(t, s.SetChatField(ctx, -100, chatAudioProfile, "mono48"))
+
+	// AddChat must not reset an existing chat.
+	must(t, s.AddChat(ctx, -100))
+
+	chat, err = s.GetChat(ctx, -100)
+	must(t, err)
+	want := Chats{ID: -100, PlayType: 1, AdminPlay: true, AdminMode: "auth", CmdDelete: true, VideoQuality: quality, AudioProfile: "mono48"}
+	if *chat != want {
+		t.Errorf("GetChat = %+v, want %+v", *chat, want)
+	}
+
+	// Setting a field creates the chat.
+	must(t, s.SetChatField(ctx, -200, chatCmdDelete, true))
+	chat, err = s.GetChat(ctx, -200)
+	must(t, err)
+	if !chat.CmdDelete {
+		t.Errorf("SetChatField on a new chat did not store the field")
+	}
+
+	chats, err := s.ListChats(ctx)
+	must(t, err)
+	var ids []int64
+	for _, c := range chats {
+		ids = append(ids, c.ID)
+	}
+	if got := sorted(ids); !slices.Equal(got, []int64{-200, -100}) {
+		t.Errorf("ListChats IDs = %v, want [-200 -100]", got)
+	}
+}
+
+func testUsers(t *testing.T, ctx context.Context, s Storage) {
+	found, err := s.HasUser(ctx, 1)
+	must(t, err)
+	if found {
+		t.Fatal("HasUser on an empty store = true")
+	}
+
+	must(t, s.AddUser(ctx, 1))
+	must(t, s.AddUser(ctx, 2))
+	must(t, s.AddUser(ctx, 1))
+
+	found, err = s.HasUser(ctx, 1)
+	must(t, err)
+	if !found {
+		t.Error("HasUser after AddUser = false")
+	}
+
+	users, err := s.ListUsers(ctx)
+	must(t, err)
+	if got := sorted(users); !slices.Equal(got, []int64{1, 2}) {
+		t.Errorf("ListUsers = %v, want [1 2]", got)
+	}
+
+	must(t, s.RemoveUser(ctx, 1))
+	must(t, s.RemoveUser(ctx, 99))
+	found, err = s.HasUser(ctx, 1)
+	must(t, err)
+	if found {
+		t.Error("HasUser after RemoveUser = true")
+	}
+}
+
+func testPlaylists(t *testing.T, ctx context.Context, s Storage) {
+	if _, err := s.GetPlaylist(ctx, "tgpl_missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetPlaylist on a missing playlist: got %v, want ErrNotFound", err)
+	}
+
+	must(t, s.InsertPlaylist(ctx, Playlist{ID: "tgpl_a", Name: "A", UserID: 1, Songs: []Song{}}))
+	must(t, s.InsertPlaylist(ctx, Playlist{ID: "tgpl_b", Name: "B", UserID: 1, Songs: []Song{}}))
+	must(t, s.InsertPlaylist(ctx, Playlist{ID: "tgpl_c", Name: "C", UserID: 2, Songs: []Song{}}))
+	if err := s.InsertPlaylist(ctx, Playlist{ID: "tgpl_a", Name: "Again", UserID: 3}); err == nil {
+		t.Error("InsertPlaylist with a duplicate ID succeeded")
+	}
+
+	first := Song{URL: "https://example.com/1", Name: "One", TrackID: "1", Duration: 60, Platform: "youtube"}
+	second := Song{URL: "https://example.com/2", Name: "Two", TrackID: "2", Duration: 120, Platform: "spotify"}
+	must(t, s.AddPlaylistSong(ctx, "tgpl_a", first))
+	must(t, s.AddPlaylistSong(ctx, "tgpl_a", second))
+
+	playlist, err := s.GetPlaylist(ctx, "tgpl_a")
+	must(t, err)
+	if playlist.Name != "A" || playlist.UserID != 1 || !slices.Equal(playlist.Songs, []Song{first, second}) {
+		t.Errorf("GetPlaylist = %+v", playlist)
+	}
+
+	must(t, s.RemovePlaylistSong(ctx, "tgpl_a", "1"))
+	playlist, err = s.GetPlaylist(ctx, "tgpl_a")
+	must(t, err)
+	if !slices.Equal(playlist.Songs, []Song{second}) {
+		t.Errorf("songs after RemovePlaylistSong = %+v, want [%+v]", playlist.Songs, second)
+	}
+
+	if playlist.Version != 3 {
+		t.Errorf("Version after two adds and a remove = %d, want 3", playlist.Version)
+	}
+
+	must(t, s.RenamePlaylist(ctx, "tgpl_a", "Renamed"))
+	saved, err := s.SetPlaylistSongs(ctx, "tgpl_a", playlist.Version, []Song{second, first})
+	must(t, err)
+	if !saved {
+		t.Error("SetPlaylistSongs with the current version was not saved")
+	}
+	// The version moved on, so an edit based on the old songs is refused.
+	if saved, err = s.SetPlaylistSongs(ctx, "tgpl_a", playlist.Version, []Song{first}); err != nil || saved {
+		t.Errorf("SetPlaylistSongs with a stale version = %v, %v; want false, nil", saved, err)
+	}
+	playlist, err = s.GetPlaylist(ctx, "tgpl_a")
+	must(t, err)
+	if playlist.Name != "Renamed" || !slices.Equal(playlist.Songs, []Song{second, first}) || playlist.Version != 4 {
+		t.Errorf("GetPlaylist after RenamePlaylist and SetPlaylistSongs = %+v", playlist)
+	}
+	must(t, s.RenamePlaylist(ctx, "tgpl_missing", "Nothing"))
+	if saved, err = s.SetPlaylistSongs(ctx, "tgpl_missing", 0, nil); err != nil || saved {
+		t.Errorf("SetPlaylistSongs on a missing playlist = %v, %v; want false, nil", saved, err)
+	}
+
+	playlists, err := s.ListUserPlaylists(ctx, 1)
+	must(t, err)
+	var ids []string
+	for _, p := range playlists {
+		ids = append(ids, p.ID)
+	}
+	slices.Sort(ids)
+	if !slices.Equal(ids, []string{"tgpl_a", "tgpl_b"}) {
+		t.Errorf("ListUserPlaylists = %v, want [tgpl_a tgpl_b]", ids)
+	}
+
+	must(t, s.InsertPlaylist(ctx, Playlist{ID: "tgpl_g", Name: "Group Mix", UserID: 3, ChatID: -100, Songs: []Song{}}))
+	playlists, err = s.ListChatPlaylists(ctx, -100)
+	must(t, err)
+	if len(playlists) != 1 || playlists[0].ID != "tgpl_g" {
+		t.Errorf("ListChatPlaylists = %+v, want [tgpl_g]", playlists)
+	}
+
+	must(t, s.SetPlaylistVisibility(ctx, "tgpl_g", VisibilityPublic))
+	must(t, s.SetPlaylistVisibility(ctx, "tgpl_b", VisibilityPublic))
+	playlists, err = s.SearchPublicPlaylists(ctx, "MIX", 10)
+	must(t, err)
+	if len(playlists) != 1 || playlists[0].ID != "tgpl_g" {
+		t.Errorf("SearchPublicPlaylists(MIX) = %+v, want [tgpl_g]", playlists)
+	}
+	playlists, err = s.SearchPublicPlaylists(ctx, "", 1)
+	must(t, err)
+	if len(playlists) != 1 || playlists[0].ID != "tgpl_b" {
+		t.Errorf("SearchPublicPlaylists with limit 1 = %+v, want [tgpl_b]", playlists)
+	}
+
+	must(t, s.AddPlaylistCollaborator(ctx, "tgpl_a", 5))
+	must(t, s.AddPlaylistCollaborator(ctx, "tgpl_a", 5))
+	must(t, s.AddPlaylistCollaborator(ctx, "tgpl_a", 6))
+	must(t, s.RemovePlaylistCollaborator(ctx, "tgpl_a", 5))
+	playlist, err = s.GetPlaylist(ctx, "tgpl_a")
+	must(t, err)
+	if !slices.Equal(playlist.Collaborators, []int64{6}) {
+		t.Errorf("collaborators = %v, want [6]", playlist.Collaborators)
+	}
+
+	// Only the owner can delete a playlist.
+	must(t, s.DeletePlaylist(ctx, "tgpl_c", 1))
+	if _, err := s.GetPlaylist(ctx, "tgpl_c"); err != nil {
+		t.Errorf("DeletePlaylist by another user removed the playlist: %v", err)
+	}
+	must(t, s.DeletePlaylist(ctx, "tgpl_c", 2))
+	if _, err := s.GetPlaylist(ctx, "tgpl_c"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetPlaylist after DeletePlaylist: got %v, want ErrNotFound", err)
+	}
+}
+
+func testAssistants(t *testing.T, ctx context.Context, s Storage) {
+	id, err := s.GetAssistant(ctx, -1)
+	must(t, err)
+	if id != 0 {
+		t.Fatalf("GetAssistant on an unassigned chat = %d, want 0", id)
+	}
+
+	// The first proposal wins; later ones get the existing assistant back.
+	id, err = s.AssignAssistant(ctx, -1, 10)
+	must(t, err)
+	if id != 10 {
+		t.Errorf("AssignAssistant on a new chat = %d, want 10", id)
+	}
+	id, err = s.AssignAssistant(ctx, -1, 20)
+	must(t, err)
+	if id != 10 {
+		t.Errorf("AssignAssistant on an assigned chat = %d, want 10", id)
+	}
+
+	must(t, s.SetAssistant(ctx, -2, 10))
+	must(t, s.SetAssistant(ctx, -3, 20))
+	must(t, s.SetAssistant(ctx, -3, 20))
+
+	counts, err := s.CountAssistantChats(ctx)
+	must(t, err)
+	if len(counts) != 2 || counts[10] != 2 || counts[20] != 1 {
+		t.Errorf("CountAssistantChats = %v, want map[10:2 20:1]", counts)
+	}
+
+	chats, err := s.ListAssistantChats(ctx, 10)
+	must(t, err)
+	if got := sorted(chats); !slices.Equal(got, []int64{-2, -1}) {
+		t.Errorf("ListAssistantChats(10) = %v, want [-2 -1]", got)
+	}
+
+	deleted, err := s.UnassignAssistant(ctx, 10)
+	must(t, err)
+	if deleted != 2 {
+		t.Errorf("UnassignAssistant = %d, want 2", deleted)
+	}
+	id, err = s.GetAssistant(ctx, -1)
+	must(t, err)
+	if id != 0 {
+		t.Errorf("GetAssistant after UnassignAssistant = %d, want 0", id)
+	}
+
+	must(t, s.RemoveAssistant(ctx, -3))
+	id, err = s.GetAssistant(ctx, -3)
+	must(t, err)
+	if id != 0 {
+		t.Errorf("GetAssistant after RemoveAssistant = %d, want 0", id)
+	}
+
+	must(t, s.SetAssistant(ctx, -4, 30))
+	must(t, s.SetAssistant(ctx, -5, 40))
+	deleted, err = s.ClearAssistants(ctx)
+	must(t, err)
+	if deleted != 2 {
+		t.Errorf("ClearAssistants = %d, want 2", deleted)
+	}
+	counts, err = s.CountAssistantChats(ctx)
+	must(t, err)
+	if len(counts) != 0 {
+		t.Errorf("CountAssistantChats after ClearAssistants = %v, want empty", counts)
+	}
+}
+
+func testAuth(t *testing.T, ctx context.Context, s Storage) {
+	users, err := s.GetAuthUsers(ctx, -1)
+	must(t, err)
+	if len(users) != 0 {
+		t.Fatalf("GetAuthUsers on a new chat = %v, want empty", users)
+	}
+
+	must(t, s.AddAuthUser(ctx, -1, 1))
+	must(t, s.AddAuthUser(ctx, -1, 2))
+	must(t, s.AddAuthUser(ctx, -1, 1))
+	must(t, s.AddAuthUser(ctx, -2, 3))
+
+	users, err = s.GetAuthUsers(ctx, -1)
+	must(t, err)
+	if got := sorted(users); !slices.Equal(got, []int64{1, 2}) {
+		t.Errorf("GetAuthUsers = %v, want [1 2]", got)
+	}
+
+	must(t, s.RemoveAuthUser(ctx, -1, 1))
+	must(t, s.RemoveAuthUser(ctx, -9, 1))
+	users, err = s.GetAuthUsers(ctx, -1)
+	must(t, err)
+	if !slices.Equal(users, []int64{2}) {
+		t.Errorf("GetAuthUsers after RemoveAuthUser = %v, want [2]", users)
+	}
+}
+
+func testLang(t *testing.T, ctx context.Context, s Storage) {
+	if _, err := s.GetLanguage(ctx, -1); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetLanguage on a new chat: got %v, want ErrNotFound", err)
+	}
+
+	must(t, s.SetLanguage(ctx, -1, "hi"))
+	must(t, s.SetLanguage(ctx, -1, "es"))
+	lang, err := s.GetLanguage(ctx, -1)
+	must(t, err)
+	if lang != "es" {
+		t.Errorf("GetLanguage = %q, want es", lang)
+	}
+}
+
+func testBlacklist(t *testing.T, ctx context.Context, s Storage) {
+	entries, err := s.GetBlacklisted(ctx, BlacklistChats)
+	must(t, err)
+	if len(entries) != 0 {
+		t.Fatalf("GetBlacklisted on an empty store = %v", entries)
+	}
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	first := BlacklistEntry{ID: -1, Reason: "spam", AddedBy: 7, AddedAt: now}
+	second := BlacklistEntry{ID: -2, Reason: "abuse", AddedBy: 8, AddedAt: now.Add(time.Second)}
+	must(t, s.AddBlacklisted(ctx, BlacklistChats, first))
+	must(t, s.AddBlacklisted(ctx, BlacklistChats, second))
+	must(t, s.AddBlacklisted(ctx, BlacklistUsers, BlacklistEntry{ID: 5, AddedAt: now}))
+
+	// Adding an ID again updates its details without duplicating it.
+	first.Reason = "more spam"
+	must(t, s.AddBlacklisted(ctx, BlacklistChats, first))
+
+	entries, err = s.GetBlacklisted(ctx, BlacklistChats)
+	must(t, err)
+	if len(entries) != 2 {
+		t.Fatalf("GetBlacklisted = %+v, want 2 entries", entries)
+	}
+	for i, want := range []BlacklistEntry{first, second} {
+		got := entries[i]
+		if got.ID != want.ID || got.Reason != want.Reason || got.AddedBy != want.AddedBy || !got.AddedAt.Equal(want.AddedAt) {
+			t.Errorf("entry %d = %+v, want %+v", i, got, want)
+		}
+	}
+
+	must(t, s.RemoveBlacklisted(ctx, BlacklistChats, -1))
+	entries, err = s.GetBlacklisted(ctx, BlacklistChats)
+	must(t, err)
+	if len(entries) != 1 || entries[0].ID != -2 {
+		t.Errorf("GetBlacklisted after RemoveBlacklisted = %+v, want only -2", entries)
+	}
+
+	users, err := s.GetBlacklisted(ctx, BlacklistUsers)
+	must(t, err)
+	if len(users) != 1 || users[0].ID != 5 {
+		t.Errorf("user blacklist = %+v, want only 5", users)
+	}
+}
+
+func testBotState(t *testing.T, ctx context.Context, s Storage) {
+	status, err := s.GetLoggerStatus(ctx)
+	must(t, err)
+	if status {
+		t.Error("GetLoggerStatus on an empty store = true")
+	}
+	must(t, s.SetLoggerStatus(ctx, true))
+	status, err = s.GetLoggerStatus(ctx)
+	must(t, err)
+	if !status {
+		t.Error("GetLoggerStatus after SetLoggerStatus(true) = false")
+	}
+
+	sudoers, err := s.GetSudoers(ctx)
+	must(t, err)
+	if len(sudoers) != 0 {
+		t.Errorf("GetSudoers on an empty store = %v", sudoers)
+	}
+	must(t, s.AddSudo(ctx, 1))
+	must(t, s.AddSudo(ctx, 2))
+	must(t, s.AddSudo(ctx, 1))
+	must(t, s.RemoveSudo(ctx, 2))
+	sudoers, err = s.GetSudoers(ctx)
+	must(t, err)
+	if !slices.Equal(sudoers, []int64{1}) {
+		t.Errorf("GetSudoers = %v, want [1]", sudoers)
+	}
+
+	must(t, s.SetSetting(ctx, "AUTO_LEAVE", "true"))
+	must(t, s.SetSetting(ctx, "PROXY", "socks5://a"))
+	must(t, s.SetSetting(ctx, "PROXY", "socks5://b"))
+	must(t, s.DeleteSetting(ctx, "AUTO_LEAVE"))
+	must(t, s.DeleteSetting(ctx, "MISSING"))
+	settings, err := s.GetSettings(ctx)
+	must(t, err)
+	if len(settings) != 1 || settings["PROXY"] != "socks5://b" {
+		t.Errorf("GetSettings = %v, want map[PROXY:socks5://b]", settings)
+	}
+}
+
+func testSessions(t *testing.T, ctx context.Context, s Storage) {
+	found, err := s.HasAssistantSession(ctx, 1)
+	must(t, err)
+	if found {
+		t.Fatal("HasAssistantSession on an empty store = true")
+	}
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	must(t, s.SaveAssistantSession(ctx, AssistantSession{ID: 1, Session: "sealed-1", AddedBy: 9, AddedAt: now}))
+	must(t, s.SaveAssistantSession(ctx, AssistantSession{ID: 2, Session: "sealed-2", AddedBy: 9, AddedAt: now}))
+	must(t, s.SaveAssistantSession(ctx, AssistantSession{ID: 1, Session: "sealed-1b", AddedBy: 9, AddedAt: now}))
+
+	found, err = s.HasAssistantSession(ctx, 1)
+	must(t, err)
+	if !found {
+		t.Error("HasAssistantSession after SaveAssistantSession = false")
+	}
+
+	must(t, s.RemoveAssistantSession(ctx, 2))
+	sessions, err := s.ListAssistantSessions(ctx)
+	must(t, err)
+	if len(sessions) != 1 {
+		t.Fatalf("ListAssistantSessions = %+v, want one session", sessions)
+	}
+	got := sessions[0]
+	if got.ID != 1 || got.Session != "sealed-1b" || got.AddedBy != 9 || !got.AddedAt.Equal(now) {
+		t.Errorf("ListAssistantSessions = %+v", got)
+	}
+}
+
+func testLikes(t *testing.T, ctx context.Context, s Storage) {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	song := func(id string) Song { return Song{TrackID: id, Name: "song " + id, Platform: "youtube"} }
+
+	for _, like := range []Like{
+		{UserID: 1, Song: song("a"), LikedAt: now.Add(-2 * time.Minute)},
+		{UserID: 1, Song: song("b"), LikedAt: now},
+		{UserID: 2, Song: song("b"), LikedAt: now},
+		{UserID: 12, Song: song("c"), LikedAt: now},
+	} {
+		added, err := s.AddLike(ctx, like)
+		must(t, err)
+		if !added {
+			t.Errorf("AddLike(%d, %s) reported an existing like", like.UserID, like.Song.TrackID)
+		}
+	}
+	added, err := s.AddLike(ctx, Like{UserID: 1, Song: song("a"), LikedAt: now})
+	must(t, err)
+	if added {
+		t.Error("liking a track twice reported a new like")
+	}
+
+	// User 12 shares the "1" prefix and must not show up in user 1's likes.
+	likes, err := s.ListLikes(ctx, 1)
+	must(t, err)
+	if len(likes) != 2 || likes[0].Song.TrackID != "b" || likes[1].Song.TrackID != "a" {
+		t.Fatalf("ListLikes = %+v, want b then a", likes)
+	}
+	if !likes[1].LikedAt.Equal(now.Add(-2*time.Minute)) || likes[1].Song.Name != "song a" {
+		t.Errorf("ListLikes changed the first like: %+v", likes[1])
+	}
+
+	count, err := s.CountLikes(ctx, "b")
+	must(t, err)
+	if count != 2 {
+		t.Errorf("CountLikes(b) = %d, want 2", count)
+	}
+
+	top, err := s.TopLikes(ctx, 2)
+	must(t, err)
+	if len(top) != 2 || top[0].Song.TrackID != "b" || top[0].Count != 2 || top[1].Song.TrackID != "a" || top[1].Count != 1 {
+		t.Errorf("TopLikes = %+v, want b:2 then a:1", top)
+	}
+
+	removed, err := s.RemoveLike(ctx, 1, "b")
+	must(t, err)
+	if !removed {
+		t.Error("RemoveLike reported no like")
+	}
+	removed, err = s.RemoveLike(ctx, 1, "b")
+	must(t, err)
+	if removed {
+		t.Error("RemoveLike of a missing like reported one")
+	}
+	all, err := s.ListAllLikes(ctx)
+	must(t, err)
+	if len(all) != 3 {
+		t.Errorf("ListAllLikes = %+v, want 3 likes", all)
+	}
+}
+
+func testBackupData(t *testing.T, ctx context.Context, s Storage) {
+	must(t, s.PutChat(ctx, Chats{ID: -1, CmdDelete: true}))
+	must(t, s.SetChatField(ctx, -1, chatAdminMode, "auth"))
+	// PutChat replaces every setting.
+	must(t, s.PutChat(ctx, Chats{ID: -1, PlayType: 1}))
+	chat, err := s.GetChat(ctx, -1)
+	must(t, err)
+	if *chat != (Chats{ID: -1, PlayType: 1}) {
+		t.Errorf("GetChat after PutChat = %+v", *chat)
+	}
+
+	must(t, s.InsertPlaylist(ctx, Playlist{ID: "tgpl_a", Name: "A", UserID: 1, Songs: []Song{}}))
+	must(t, s.PutPlaylist(ctx, Playlist{ID: "tgpl_a", Name: "Renamed", UserID: 1, Songs: []Song{{TrackID: "1"}}}))
+	must(t, s.PutPlaylist(ctx, Playlist{ID: "tgpl_b", Name: "B", UserID: 2, Songs: []Song{}}))
+	playlists, err := s.ListPlaylists(ctx)
+	must(t, err)
+	if len(playlists) != 2 {
+		t.Fatalf("ListPlaylists = %+v, want 2 playlists", playlists)
+	}
+	playlist, err := s.GetPlaylist(ctx, "tgpl_a")
+	must(t, err)
+	if playlist.Name != "Renamed" || len(playlist.Songs) != 1 {
+		t.Errorf("GetPlaylist after PutPlaylist = %+v", playlist)
+	}
+
+	must(t, s.SetAssistant(ctx, -1, 10))
+	must(t, s.SetAssistant(ctx, -2, 20))
+	assignments, err := s.ListAssignments(ctx)
+	must(t, err)
+	if len(assignments) != 2 || assignments[-1] != 10 || assignments[-2] != 20 {
+		t.Errorf("ListAssignments = %v, want map[-2:20 -1:10]", assignments)
+	}
+
+	must(t, s.AddAuthUser(ctx, -1, 5))
+	must(t, s.AddAuthUser(ctx, -2, 6))
+	must(t, s.RemoveAuthUser(ctx, -2, 6))
+	auth, err := s.ListAuth(ctx)
+	must(t, err)
+	if len(auth) != 1 || !slices.Equal(auth[-1], []int64{5}) {
+		t.Errorf("ListAuth = %v, want map[-1:[5]]", auth)
+	}
+
+	must(t, s.SetLanguage(ctx, -1, "hi"))
+	langs, err := s.ListLanguages(ctx)
+	must(t, err)
+	if len(langs) != 1 || langs[-1] != "hi" {
+		t.Errorf("ListLanguages = %v, want map[-1:hi]", langs)
+	}
+
+	must(t, s.AddUser(ctx, 1))
+	_, err = s.AddLike(ctx, Like{UserID: 1, Song: Song{TrackID: "1"}})
+	must(t, err)
+	must(t, s.AddBlacklisted(ctx, BlacklistChats, BlacklistEntry{ID: -3}))
+	must(t, s.AddBlacklisted(ctx, BlacklistUsers, BlacklistEntry{ID: 3}))
+	must(t, s.AddSudo(ctx, 9))
+	must(t, s.SetSetting(ctx, "PROXY", "socks5://a"))
+	must(t, s.SetLoggerStatus(ctx, true))
+	must(t, s.SaveAssistantSession(ctx, AssistantSession{ID: 1, Session: "sealed"}))
+
+	must(t, s.ClearBackupData(ctx))
+
+	chats, err := s.ListChats(ctx)
+	must(t, err)
+	users, err := s.ListUsers(ctx)
+	must(t, err)
+	playlists, err = s.ListPlaylists(ctx)
+	must(t, err)
+	assignments, err = s.ListAssignments(ctx)
+	must(t, err)
+	auth, err = s.ListAuth(ctx)
+	must(t, err)
+	langs, err = s.ListLanguages(ctx)
+	must(t, err)
+	blChats, err := s.GetBlacklisted(ctx, BlacklistChats)
+	must(t, err)
+	blUsers, err := s.GetBlacklisted(ctx, BlacklistUsers)
+	must(t, err)
+	likes, err := s.ListAllLikes(ctx)
+	must(t, err)
+	if n := len(chats) + len(users) + len(playlists) + len(assignments) + len(auth) + len(langs) + len(blChats) + len(blUsers) + len(likes); n != 0 {
+		t.Errorf("ClearBackupData left %d records", n)
+	}
+
+	// Bot-wide state is not part of a backup and must survive.
+	sudoers, err := s.GetSudoers(ctx)
+	must(t, err)
+	settings, err := s.GetSettings(ctx)
+	must(t, err)
+	status, err := s.GetLoggerStatus(ctx)
+	must(t, err)
+	found, err := s.HasAssistantSession(ctx, 1)
+	must(t, err)
+	if !slices.Equal(sudoers, []int64{9}) || settings["PROXY"] != "socks5://a" || !status || !found {
+		t.Errorf("ClearBackupData removed bot state: sudoers=%v settings=%v logger=%v session=%v", sudoers, settings, status, found)
+	}
+}
@@ -0,0 +1,33 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package db
+
+// GetSudoers returns the developer IDs added at runtime with /addsudo.
+func (db *Database) GetSudoers() ([]int64, error) {
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	return db.store.GetSudoers(ctx)
+}
+
+// AddSudo stores a runtime developer ID.
+func (db *Database) AddSudo(userID int64) error {
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	return db.store.AddSudo(ctx, userID)
+}
+
+// RemoveSudo deletes a runtime developer ID.
+func (db *Database) RemoveSudo(userID int64) error {
+	ctx, cancel := db.ctx()
+	defer cancel()
+
+	return db.store.RemoveSudo(ctx, userID)
+}
@@ -10,12 +10,7 @@ package db
 
 import (
 	"context"
-	"errors"
 	"time"
-
-	"go.mongodb.org/mongo-driver/v2/bson"
-	"go.mongodb.org/mongo-driver/v2/mongo"
-	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
 // Users represents a user document in the database.
@@ -33,12 +28,7 @@ func (db *Database) AddUser(userID int64) error {
 	ctx, cancel := db.ctx()
 	defer cancel()
 
-	_, err := db.userDB.UpdateOne(ctx,
-		bson.M{"_id": userID},
-		bson.M{"$setOnInsert": bson.M{}},
-		options.UpdateOne().SetUpsert(true),
-	)
-	if err != nil {
+	if err := db.store.AddUser(ctx, userID); err != nil {
 		return err
 	}
 
@@ -51,8 +41,7 @@ func (db *Database) RemoveUser(userID int64) error {
 	ctx, cancel := db.ctx()
 	defer cancel()
 
-	_, err := db.userDB.DeleteOne(ctx, bson.M{"_id": userID})
-	if err != nil {
+	if err := db.store.RemoveUser(ctx, userID); err != nil {
 		return err
 	}
 
@@ -70,15 +59,12 @@ func (db *Database) IsUserExist(userID int64) (bool, error) {
 	ctx, cancel := db.ctx()
 	defer cancel()
 
-	var user Users
-	err := db.userDB.FindOne(ctx, bson.M{"_id": userID}).Decode(&user)
-	if errors.Is(err, mongo.ErrNoDocuments) {
-		return false, nil
-	} else if err != nil {
+	found, err := db.store.HasUser(ctx, userID)
+	if err != nil || !found {
 		return false, err
 	}
 
-	db.userCache.Set(key, &user)
+	db.userCache.Set(key, &Users{ID: userID})
 	return true, nil
 }
 
@@ -87,25 +73,13 @@ func (db *Database) GetAllUsers() ([]int64, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	cursor, err := db.userDB.Find(ctx, bson.M{})
+	users, err := db.store.ListUsers(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer func(cursor *mongo.Cursor, ctx context.Context) {
-		_ = cursor.Close(ctx)
-	}(cursor, ctx)
-
-	var users []int64
-	for cursor.Next(ctx) {
-		var doc Users
-		if err := cursor.Decode(&doc); err != nil {
-			return nil, err
-		}
-		users = append(users, doc.ID)
-		db.userCache.Set(toKey(doc.ID), &doc)
-	}
-	if err := cursor.Err(); err != nil {
-		return nil, err
+
+	for _, id := range users {
+		db.userCache.Set(toKey(id), &Users{ID: id})
 	}
 	return users, nil
 }
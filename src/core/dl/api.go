@@ -57,8 +57,8 @@ var apiPatterns = map[string]*regexp.Regexp{
 func newApiData(query string) *apiData {
 	return &apiData{
 		Query:    strings.TrimSpace(query),
-		ApiUrl:   strings.TrimRight(config.Conf.ApiUrl, "/"),
-		APIKey:   config.Conf.ApiKey,
+		ApiUrl:   strings.TrimRight(config.Conf.ApiUrl(), "/"),
+		APIKey:   config.Conf.ApiKey(),
 		Patterns: apiPatterns,
 	}
 }
@@ -167,11 +167,11 @@ func (a *apiData) getTrack() (utils.TrackInfo, error) {
 }
 
 // downloadTrack downloads a track using the API. If the track is a YouTube video and video format is requested,
-func (a *apiData) downloadTrack(info utils.TrackInfo, video bool) (string, error) {
+func (a *apiData) downloadTrack(info utils.TrackInfo, video bool, quality utils.VideoQuality) (string, error) {
 	// if the track is from YouTube and video:true
 	yt := newYouTubeData(a.Query)
 	if info.Platform == utils.YouTube && video {
-		return yt.downloadTrack(info, video)
+		return yt.downloadTrack(info, video, quality)
 	}
 
 	downloader, err := newDownload(info)
@@ -182,7 +182,7 @@ func (a *apiData) downloadTrack(info utils.TrackInfo, video bool) (string, error
 	filePath, err := downloader.Process()
 	if err != nil {
 		if info.Platform == utils.YouTube {
-			return yt.downloadTrack(info, video)
+			return yt.downloadTrack(info, video, quality)
 		}
 		return "", fmt.Errorf("the download process failed: %w", err)
 	}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"net/url"
 	"os/exec"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -67,7 +68,16 @@ func (d *directLink) getInfo() (utils.PlatformTracks, error) {
 		}
 	}
 
+	live := isLiveStream(info.Format.FormatName, info.Format.Tags.IcyName, duration)
+	if live {
+		duration = 0
+	}
+
 	title := info.Format.Tags.Title
+	if title == "" {
+		title = info.Format.Tags.IcyName
+	}
+
 	if title == "" {
 		parts := strings.Split(d.query, "/")
 		if len(parts) > 0 {
@@ -91,12 +101,23 @@ func (d *directLink) getInfo() (utils.PlatformTracks, error) {
 		Duration: duration,
 		Url:      d.query,
 		Id:       d.query,
+		IsLive:   live,
 		Platform: utils.DirectLink,
 	}
 
 	return utils.PlatformTracks{Results: []utils.MusicTrack{track}}, nil
 }
 
+// isLiveStream reports whether a probed link is an endless stream: an Icecast/Shoutcast radio (icy-* metadata)
+// or an HLS playlist without a duration. Other files whose duration is unknown, for example because the server
+// sent no Content-Length, are ordinary files and stay seekable.
+func isLiveStream(formatName, icyName string, duration int) bool {
+	if icyName != "" {
+		return true
+	}
+	return duration <= 0 && slices.Contains(strings.Split(formatName, ","), "hls")
+}
+
 func (d *directLink) search() (utils.PlatformTracks, error) {
 	return d.getInfo()
 }
@@ -120,6 +141,6 @@ func (d *directLink) getTrack() (utils.TrackInfo, error) {
 	}, nil
 }
 
-func (d *directLink) downloadTrack(_ utils.TrackInfo, _ bool) (string, error) {
+func (d *directLink) downloadTrack(_ utils.TrackInfo, _ bool, _ utils.VideoQuality) (string, error) {
 	return d.query, nil
 }
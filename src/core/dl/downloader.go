@@ -16,6 +16,10 @@ import (
 )
 
 func DownloadCachedTrack(cached *utils.CachedTrack, bot *td.Client) (string, error) {
+	if cached.IsLive {
+		return resolveLiveStream(cached)
+	}
+
 	if cached.Platform == utils.DirectLink {
 		return cached.URL, nil
 	}
@@ -38,7 +42,7 @@ func downloadViaWrapper(cached *utils.CachedTrack, bot *td.Client) (string, erro
 		return "", fmt.Errorf("get track info: %w", err)
 	}
 
-	path, err := wrapper.DownloadTrack(track, cached.IsVideo)
+	path, err := wrapper.DownloadTrack(track, cached.IsVideo, cached.Quality.Or(utils.DefaultVideoQuality))
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,70 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package dl
+
+import (
+	"time"
+
+	"ashokshau/tgmusic/config"
+	"ashokshau/tgmusic/src/utils"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// resolveLiveStream returns a playable stream URL for a live track.
+// Live streams cannot be downloaded, so the manifest URL is handed to ffmpeg directly.
+func resolveLiveStream(cached *utils.CachedTrack) (string, error) {
+	if cached.Platform == utils.DirectLink {
+		return cached.URL, nil
+	}
+
+	format := "bestaudio/best[height<=480]/best"
+	if cached.IsVideo {
+		format = fmt.Sprintf("best[height<=%d]/best", cached.Quality.Or(utils.DefaultVideoQuality).Height)
+	}
+
+	params := []string{
+		"--no-warnings",
+		"--quiet",
+		"--geo-bypass",
+		"--socket-timeout", "10",
+		"-f", format,
+		"-g",
+	}
+
+	yt := newYouTubeData(cached.URL)
+	if cookieFile := yt.getCookieFile(); cookieFile != "" {
+		params = append(params, "--cookies", cookieFile)
+	} else if proxy := config.Conf.Proxy(); proxy != "" {
+		params = append(params, "--proxy", proxy)
+	}
+
+	params = append(params, cached.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "yt-dlp", params...).Output()
+	if err != nil {
+		if exitErr, ok := errors.AsType[*exec.ExitError](err); ok {
+			return "", fmt.Errorf("yt-dlp failed to resolve the live stream: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("failed to resolve the live stream %s: %w", cached.URL, err)
+	}
+
+	streamURL := strings.TrimSpace(strings.SplitN(string(output), "\n", 2)[0])
+	if streamURL == "" {
+		return "", fmt.Errorf("no stream URL was returned for %s", cached.URL)
+	}
+
+	return streamURL, nil
+}
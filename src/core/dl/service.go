@@ -25,7 +25,7 @@ type musicService interface {
 	// getTrack fetches detailed information for a single track.
 	getTrack() (utils.TrackInfo, error)
 	// downloadTrack handles the download of a track.
-	downloadTrack(trackInfo utils.TrackInfo, video bool) (string, error)
+	downloadTrack(trackInfo utils.TrackInfo, video bool, quality utils.VideoQuality) (string, error)
 }
 
 // DownloaderWrapper provides a unified interface for music service interactions.
@@ -47,7 +47,7 @@ func NewDownloaderWrapper(query string) *DownloaderWrapper {
 	} else if direct.isValid() {
 		chosen = direct
 	} else {
-		switch config.Conf.DefaultService {
+		switch config.Conf.DefaultService() {
 		case "spotify":
 			chosen = api
 		default:
@@ -82,6 +82,6 @@ func (d *DownloaderWrapper) GetTrack() (utils.TrackInfo, error) {
 
 // DownloadTrack downloads a track by delegating the call to the wrapped service.
 // It returns the file path of the downloaded track or an error if the download fails.
-func (d *DownloaderWrapper) DownloadTrack(info utils.TrackInfo, video bool) (string, error) {
-	return d.service.downloadTrack(info, video)
+func (d *DownloaderWrapper) DownloadTrack(info utils.TrackInfo, video bool, quality utils.VideoQuality) (string, error) {
+	return d.service.downloadTrack(info, video, quality)
 }
@@ -45,8 +45,8 @@ var youtubePatterns = map[string]*regexp.Regexp{
 func newYouTubeData(query string) *youTubeData {
 	return &youTubeData{
 		Query:    strings.TrimSpace(query),
-		ApiUrl:   strings.TrimRight(config.Conf.ApiUrl, "/"),
-		APIKey:   config.Conf.ApiKey,
+		ApiUrl:   strings.TrimRight(config.Conf.ApiUrl(), "/"),
+		APIKey:   config.Conf.ApiKey(),
 		Patterns: youtubePatterns,
 	}
 }
@@ -163,20 +163,24 @@ func (y *youTubeData) getTrack() (utils.TrackInfo, error) {
 }
 
 // downloadTrack handles the download of a track from YouTube.
-func (y *youTubeData) downloadTrack(info utils.TrackInfo, video bool) (string, error) {
+func (y *youTubeData) downloadTrack(info utils.TrackInfo, video bool, quality utils.VideoQuality) (string, error) {
 	if !video && y.ApiUrl != "" && y.APIKey != "" {
 		if filePath, err := y.downloadWithApi(info.Id, video); err == nil {
 			return filePath, nil
 		}
 	}
 
-	filePath, err := y.downloadWithYtDlp(info.Id, video)
+	filePath, err := y.downloadWithYtDlp(info.Id, video, quality.Or(utils.DefaultVideoQuality))
 	return filePath, err
 }
 
 // buildYtdlpParams constructs the command-line parameters for yt-dlp to download media.
-func (y *youTubeData) buildYtdlpParams(videoID string, video bool) []string {
+// Video downloads are capped at the requested quality and cached per resolution.
+func (y *youTubeData) buildYtdlpParams(videoID string, video bool, quality utils.VideoQuality) []string {
 	outputTemplate := filepath.Join(config.Conf.DownloadsDir, "%(id)s.%(ext)s")
+	if video {
+		outputTemplate = filepath.Join(config.Conf.DownloadsDir, fmt.Sprintf("%%(id)s_%dp.%%(ext)s", quality.Height))
+	}
 
 	params := []string{
 		"yt-dlp",
@@ -200,16 +204,18 @@ func (y *youTubeData) buildYtdlpParams(videoID string, video bool) []string {
 	}
 
 	if video {
-		formatSelector := "bestvideo[height<=720]+bestaudio/best[height<=720]"
+		formatSelector := fmt.Sprintf("bestvideo[height<=%[1]d][fps<=%[2]d]+bestaudio/bestvideo[height<=%[1]d]+bestaudio/best[height<=%[1]d]", quality.Height, quality.Fps)
 		params = append(params, "-f", formatSelector, "--merge-output-format", "mp4")
 	} else {
-		params = append(params, "-f", "bestaudio[ext=m4a]/bestaudio")
+		// Downloads are shared between chats, so the source bitrate follows the global profile.
+		bitrate := utils.DefaultAudioProfile().Bitrate
+		params = append(params, "-f", fmt.Sprintf("bestaudio[ext=m4a][abr<=%[1]d]/bestaudio[abr<=%[1]d]/bestaudio[ext=m4a]/bestaudio", bitrate))
 	}
 
 	if cookieFile := y.getCookieFile(); cookieFile != "" {
 		params = append(params, "--cookies", cookieFile)
-	} else if config.Conf.Proxy != "" {
-		params = append(params, "--proxy", config.Conf.Proxy)
+	} else if proxy := config.Conf.Proxy(); proxy != "" {
+		params = append(params, "--proxy", proxy)
 	}
 
 	videoURL := "https://www.youtube.com/watch?v=" + videoID
@@ -219,12 +225,12 @@ func (y *youTubeData) buildYtdlpParams(videoID string, video bool) []string {
 }
 
 // downloadWithYtDlp downloads media from YouTube using the yt-dlp command-line tool.
-func (y *youTubeData) downloadWithYtDlp(videoID string, video bool) (string, error) {
+func (y *youTubeData) downloadWithYtDlp(videoID string, video bool, quality utils.VideoQuality) (string, error) {
 	if videoID == "" {
 		return "", errors.New("videoID is empty")
 	}
 
-	ytdlpParams := y.buildYtdlpParams(videoID, video)
+	ytdlpParams := y.buildYtdlpParams(videoID, video, quality)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 	defer cancel()
@@ -259,7 +265,7 @@ func (y *youTubeData) downloadWithYtDlp(videoID string, video bool) (string, err
 
 // getCookieFile retrieves the path to a cookie file from the configured list.
 func (y *youTubeData) getCookieFile() string {
-	cookiesPath := config.Conf.CookiesPath
+	cookiesPath := config.Conf.CookiesPath()
 	if len(cookiesPath) == 0 {
 		return ""
 	}
@@ -160,23 +160,28 @@ func parseResults(node any, tracks *[]utils.MusicTrack, limit int) {
 
 	case map[string]any:
 		if vr, ok := dig(v, "videoRenderer").(map[string]any); ok {
-			if isLiveNow(vr) {
-				return
-			}
+			live := isLiveNow(vr)
 			id := safeString(vr["videoId"])
 			title := safeString(dig(vr, "title", "runs", 0, "text"))
 			durationText := safeString(dig(vr, "lengthText", "simpleText"))
-			if id == "" || title == "" || durationText == "" {
+			if id == "" || title == "" || (durationText == "" && !live) {
 				return
 			}
+
+			duration := 0
+			if !live {
+				duration = parseDuration(durationText)
+			}
+
 			*tracks = append(*tracks, utils.MusicTrack{
 				Id:        id,
 				Url:       ytWatchURL + id,
 				Title:     title,
 				Thumbnail: safeString(dig(vr, "thumbnail", "thumbnails", 0, "url")),
-				Duration:  parseDuration(durationText),
+				Duration:  duration,
 				Views:     safeString(dig(vr, "viewCountText", "simpleText")),
 				Channel:   safeString(dig(vr, "ownerText", "runs", 0, "text")),
+				IsLive:    live,
 				Platform:  utils.YouTube,
 			})
 			return
@@ -307,14 +312,21 @@ func mapMixVideo(v map[string]any) utils.MusicTrack {
 
 func mapPlayerToTrack(src map[string]any) utils.MusicTrack {
 	id := digStr(src, "videoDetails", "videoId")
+	live, _ := dig(src, "videoDetails", "isLive").(bool)
+	duration := atoi(digStr(src, "videoDetails", "lengthSeconds"))
+	if live {
+		duration = 0
+	}
+
 	return utils.MusicTrack{
 		Id:        id,
 		Title:     digStr(src, "videoDetails", "title"),
 		Url:       ytWatchURL + id,
 		Thumbnail: pickYTPlayerThumb(src),
 		Channel:   digStr(src, "videoDetails", "author"),
-		Duration:  atoi(digStr(src, "videoDetails", "lengthSeconds")),
+		Duration:  duration,
 		Views:     digStr(src, "videoDetails", "viewCount"),
+		IsLive:    live,
 		Platform:  utils.YouTube,
 	}
 }
@@ -0,0 +1,123 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package lang
+
+import (
+	"ashokshau/tgmusic/src/core/db"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// DefaultLanguage is used for chats without a language and for keys missing from a catalog.
+const DefaultLanguage = "en"
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+// catalogs maps a language code to its messages, loaded once from the embedded locale files.
+var catalogs = mustLoadCatalogs()
+
+// Language describes a supported language.
+type Language struct {
+	Code string
+	Name string
+}
+
+func mustLoadCatalogs() map[string]map[string]string {
+	entries, err := localeFS.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("lang: read locales: %v", err))
+	}
+
+	loaded := make(map[string]map[string]string, len(entries))
+	for _, entry := range entries {
+		data, err := localeFS.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("lang: read %s: %v", entry.Name(), err))
+		}
+
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("lang: parse %s: %v", entry.Name(), err))
+		}
+		loaded[strings.TrimSuffix(entry.Name(), ".json")] = messages
+	}
+
+	if _, ok := loaded[DefaultLanguage]; !ok {
+		panic("lang: the default catalog is missing")
+	}
+	return loaded
+}
+
+// T returns the message for key in the chat's language, formatted with args.
+func T(chatID int64, key string, args ...any) string {
+	return Tr(ChatLanguage(chatID), key, args...)
+}
+
+// Tr returns the message for key in the given language.
+// Missing keys fall back to English, then to the key itself.
+func Tr(code, key string, args ...any) string {
+	msg, ok := catalogs[code][key]
+	if !ok {
+		msg, ok = catalogs[DefaultLanguage][key]
+	}
+	if !ok {
+		return key
+	}
+
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// ChatLanguage returns the language code for a chat, or DefaultLanguage when unset or unsupported.
+func ChatLanguage(chatID int64) string {
+	if db.Instance == nil {
+		return DefaultLanguage
+	}
+
+	code, err := db.Instance.GetLanguage(chatID)
+	if err != nil || !IsSupported(code) {
+		return DefaultLanguage
+	}
+	return code
+}
+
+// IsSupported reports whether a catalog exists for the language code.
+func IsSupported(code string) bool {
+	_, ok := catalogs[code]
+	return ok
+}
+
+// Name returns the native name of a language.
+func Name(code string) string {
+	return Tr(code, "lang.name")
+}
+
+// Languages returns the supported languages, English first and the rest sorted by code.
+func Languages() []Language {
+	codes := make([]string, 0, len(catalogs))
+	for code := range catalogs {
+		if code != DefaultLanguage {
+			codes = append(codes, code)
+		}
+	}
+	slices.Sort(codes)
+
+	languages := []Language{{Code: DefaultLanguage, Name: Name(DefaultLanguage)}}
+	for _, code := range codes {
+		languages = append(languages, Language{Code: code, Name: Name(code)})
+	}
+	return languages
+}
@@ -0,0 +1,68 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package core
+
+import (
+	"ashokshau/tgmusic/src/core/card"
+	"ashokshau/tgmusic/src/utils"
+	"strconv"
+	"time"
+
+	"ashokshau/tgmusic/src/core/cache"
+
+	"github.com/AshokShau/gotdbot"
+)
+
+// panelTracks remembers the track each now-playing panel or queued-song message shows, so a like on an older
+// message goes to its song rather than to whatever is playing now.
+var panelTracks = cache.NewCache[*utils.CachedTrack](12 * time.Hour)
+
+func panelKey(chatID, messageID int64) string {
+	return strconv.FormatInt(chatID, 10) + ":" + strconv.FormatInt(messageID, 10)
+}
+
+// PanelTrack returns the track shown by the now-playing panel with the given message ID.
+func PanelTrack(chatID, messageID int64) (*utils.CachedTrack, bool) {
+	return panelTracks.Get(panelKey(chatID, messageID))
+}
+
+// SetPanelTrack records that a message with playback buttons shows track.
+func SetPanelTrack(chatID, messageID int64, track *utils.CachedTrack) {
+	panelTracks.Set(panelKey(chatID, messageID), track)
+}
+
+// SendNowPlaying replaces the status message with the now-playing panel for a track.
+// The panel is sent as a card image with the text as its caption, or as plain text when no card can be rendered.
+func SendNowPlaying(c *gotdbot.Client, status *gotdbot.Message, track *utils.CachedTrack, text string) error {
+	path, err := card.Get(track)
+	if err == nil {
+		var panel *gotdbot.Message
+		panel, err = c.SendPhoto(status.ChatId, gotdbot.InputFileLocal{Path: path}, &gotdbot.SendPhotoOpts{
+			Caption:     text,
+			ParseMode:   "HTML",
+			ReplyMarkup: ControlButtons("play"),
+		})
+		if err == nil {
+			SetPanelTrack(panel.ChatId, panel.Id, track)
+			_ = c.DeleteMessages(status.ChatId, []int64{status.Id}, &gotdbot.DeleteMessagesOpts{Revoke: true})
+			return nil
+		}
+	}
+
+	c.Logger.Debug("Falling back to a text now-playing panel", "error", err)
+	_, err = status.EditText(c, text, &gotdbot.EditTextMessageOpts{
+		ReplyMarkup:           ControlButtons("play"),
+		ParseMode:             "HTML",
+		DisableWebPagePreview: true,
+	})
+	if err == nil {
+		SetPanelTrack(status.ChatId, status.Id, track)
+	}
+	return err
+}
@@ -9,6 +9,7 @@
 package handlers
 
 import (
+	"ashokshau/tgmusic/src/core/lang"
 	"ashokshau/tgmusic/src/utils"
 	"ashokshau/tgmusic/src/vc"
 	"fmt"
@@ -34,14 +35,14 @@ func reloadAdminCacheHandler(c *gotdbot.Client, ctx *gotdbot.Context) error {
 		timePassed := time.Since(lastUsed)
 		if timePassed < reloadCooldown {
 			remaining := int((reloadCooldown - timePassed).Seconds())
-			_, _ = m.ReplyText(c, fmt.Sprintf("Please wait %s before using this command again.", utils.SecToMin(remaining)), nil)
+			_, _ = m.ReplyText(c, lang.T(m.ChatId, "common.cooldown", utils.SecToMin(remaining)), nil)
 			return nil
 		}
 	}
 
 	reloadRateLimit.Set(reloadKey, time.Now())
 
-	reply, err := m.ReplyText(c, "Reloading administrator cache...", nil)
+	reply, err := m.ReplyText(c, lang.T(m.ChatId, "reload.start"), nil)
 	if err != nil {
 		c.Logger.Warn("Failed to send reloading message for chat", "chat_id", m.ChatId, "error", err)
 		return gotdbot.EndGroups
@@ -53,12 +54,12 @@ func reloadAdminCacheHandler(c *gotdbot.Client, ctx *gotdbot.Context) error {
 	admins, err := cache.GetAdmins(c, m.ChatId, true)
 	if err != nil {
 		c.Logger.Warn("Failed to reload the admin cache for chat", "chat_id", m.ChatId, "error", err)
-		_, _ = reply.EditText(c, "Failed to reload administrator cache.", nil)
+		_, _ = reply.EditText(c, lang.T(m.ChatId, "reload.failed"), nil)
 		return gotdbot.EndGroups
 	}
 
 	c.Logger.Info("Reloaded admins for chat", "count", len(admins), "chat_id", m.ChatId)
-	_, _ = reply.EditText(c, "Administrator cache reloaded successfully.", nil)
+	_, _ = reply.EditText(c, lang.T(m.ChatId, "reload.done"), nil)
 	return gotdbot.EndGroups
 }
 
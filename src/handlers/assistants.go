@@ -0,0 +1,158 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package handlers
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	"ashokshau/tgmusic/src/core/db"
+	"ashokshau/tgmusic/src/vc"
+
+	td "github.com/AshokShau/gotdbot"
+)
+
+const assistantsUsage = "<b>Usage:</b>\n" +
+	"• <code>/assistants</code> — List running assistants and their health\n" +
+	"• <code>/assistants add SESSION</code> — Start a new assistant (send it in private)\n" +
+	"• <code>/assistants remove ID</code> — Stop an assistant added with this command"
+
+// assistantsHandler handles the /assistants command.
+// Developers can view the assistants and their health; only the owner can add and remove them without a restart.
+func assistantsHandler(c *td.Client, ctx *td.Context) error {
+	if !isDev(ctx) {
+		return td.EndGroups
+	}
+
+	m := ctx.EffectiveMessage
+	args := strings.Fields(Args(m))
+	if len(args) == 0 {
+		return assistantsList(c, m)
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "list", "status":
+		return assistantsList(c, m)
+	}
+
+	if !isOwner(ctx) {
+		_, err := m.ReplyText(c, "Only the owner can add or remove assistants.", nil)
+		return err
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "add":
+		if len(args) == 2 {
+			return assistantsAdd(c, m, args[1])
+		}
+	case "remove", "rm", "del":
+		if len(args) == 2 {
+			return assistantsRemove(c, m, args[1])
+		}
+	}
+
+	_, err := m.ReplyText(c, assistantsUsage, replyOpts)
+	return err
+}
+
+func assistantsList(c *td.Client, m *td.Message) error {
+	assistants := vc.Calls.Assistants()
+	if len(assistants) == 0 {
+		_, err := m.ReplyText(c, "No assistants are running.", nil)
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<b>Assistants</b> (%d)\n\n", len(assistants)))
+	for i, a := range assistants {
+		sb.WriteString(fmt.Sprintf("%d. <a href=\"tg://user?id=%d\">%s</a>", i+1, a.ID, html.EscapeString(a.Name)))
+		if a.Username != "" {
+			sb.WriteString(" @" + a.Username)
+		}
+
+		source := "env"
+		if db.Instance.HasAssistantSession(a.ID) {
+			source = "runtime"
+		}
+		sb.WriteString(fmt.Sprintf("\n   ├ ID: <code>%d</code> • Calls: %d • %s\n", a.ID, a.ActiveCalls, source))
+
+		h := a.Health
+		status := "✅ Healthy"
+		if h.Draining {
+			status = "⚠️ Draining: " + html.EscapeString(h.Reason)
+		}
+		sb.WriteString(fmt.Sprintf("   └ %s • Failures: %d/%d", status, h.Failures, h.Attempts))
+		if !h.CheckedAt.IsZero() {
+			sb.WriteString(" • Checked " + h.CheckedAt.UTC().Format("15:04 UTC"))
+		}
+		sb.WriteString("\n")
+	}
+
+	_, err := m.ReplyText(c, sb.String(), replyOpts)
+	return err
+}
+
+func assistantsAdd(c *td.Client, m *td.Message, session string) error {
+	// The session string grants full access to the account, so it should not stay in the chat.
+	_ = c.DeleteMessages(m.ChatId, []int64{m.Id}, &td.DeleteMessagesOpts{Revoke: true})
+
+	status, err := c.SendTextMessage(m.ChatId, "Starting the assistant...", nil)
+	if err != nil {
+		return err
+	}
+
+	assistantID, err := vc.Calls.AddAssistant(session)
+	if err != nil {
+		_, err = status.EditText(c, fmt.Sprintf("Failed to start the assistant: %s", html.EscapeString(err.Error())), editOpts)
+		return err
+	}
+
+	if err = db.Instance.AddAssistantSession(assistantID, session, m.SenderID()); err != nil {
+		_, err = status.EditText(c, fmt.Sprintf("Assistant <code>%d</code> started, but saving its session failed: %s\nIt will not be restored after a restart.", assistantID, html.EscapeString(err.Error())), editOpts)
+		return err
+	}
+
+	_, err = status.EditText(c, fmt.Sprintf("Assistant <code>%d</code> has been added.", assistantID), editOpts)
+	return err
+}
+
+func assistantsRemove(c *td.Client, m *td.Message, arg string) error {
+	assistantID, err := strconv.ParseInt(strings.TrimPrefix(arg, "#"), 10, 64)
+	if err != nil {
+		_, _ = m.ReplyText(c, "Please provide a valid assistant ID.", nil)
+		return td.EndGroups
+	}
+
+	// Small numbers refer to the position shown in /assistants.
+	assistants := vc.Calls.Assistants()
+	if assistantID > 0 && assistantID <= int64(len(assistants)) {
+		assistantID = assistants[assistantID-1].ID
+	}
+
+	if !db.Instance.HasAssistantSession(assistantID) {
+		_, _ = m.ReplyText(c, "This assistant is set through a STRING variable or is not running. Remove it there and restart.", nil)
+		return td.EndGroups
+	}
+
+	moved, err := vc.Calls.RemoveAssistant(assistantID)
+	if err != nil {
+		_, _ = m.ReplyText(c, fmt.Sprintf("Failed to remove the assistant: %s", err.Error()), nil)
+		return td.EndGroups
+	}
+
+	if err = db.Instance.RemoveAssistantSession(assistantID); err != nil {
+		_, _ = m.ReplyText(c, fmt.Sprintf("Assistant stopped, but deleting its session failed: %s", err.Error()), nil)
+		return td.EndGroups
+	}
+
+	_, err = m.ReplyText(c, fmt.Sprintf("Assistant <code>%d</code> has been removed. %d active streams were moved.", assistantID, moved), replyOpts)
+	return err
+}
@@ -0,0 +1,263 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package handlers
+
+import (
+	"ashokshau/tgmusic/config"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"ashokshau/tgmusic/src/core"
+	"ashokshau/tgmusic/src/core/cache"
+	"ashokshau/tgmusic/src/core/db"
+
+	td "github.com/AshokShau/gotdbot"
+)
+
+// maxBackupSize is the largest archive /restore accepts.
+const maxBackupSize = 64 << 20
+
+// pendingRestore is a validated backup waiting for the merge/replace choice.
+type pendingRestore struct {
+	backup *db.Backup
+	userID int64
+}
+
+var (
+	pendingRestores   = cache.NewCache[*pendingRestore](10 * time.Minute)
+	pendingRestoresMu sync.Mutex
+)
+
+// takePendingRestore removes and returns the restore stored under token, so each preview is applied at most once.
+func takePendingRestore(token string) (*pendingRestore, bool) {
+	pendingRestoresMu.Lock()
+	defer pendingRestoresMu.Unlock()
+
+	p, ok := pendingRestores.Get(token)
+	if ok {
+		pendingRestores.Delete(token)
+	}
+	return p, ok
+}
+
+// backupSummary lists the record counts of a backup.
+func backupSummary(createdAt time.Time, n db.BackupCounts) string {
+	return fmt.Sprintf(
+		"<b>Created:</b> %s\n"+
+			"• Chats: %d\n• Users: %d\n• Playlists: %d\n• Auth lists: %d\n"+
+			"• Assistant assignments: %d\n• Languages: %d\n• Blacklisted chats: %d\n• Blacklisted users: %d\n• Likes: %d",
+		createdAt.UTC().Format("2006-01-02 15:04 UTC"),
+		n.Chats, n.Users, n.Playlists, n.Auth, n.Assistants, n.Languages, n.BlacklistedChats, n.BlacklistedUsers, n.Likes,
+	)
+}
+
+// backupHandler handles the /backup command.
+// It exports the bot's data to a compressed archive and uploads it to the owner.
+func backupHandler(c *td.Client, ctx *td.Context) error {
+	if !isDev(ctx) {
+		return td.EndGroups
+	}
+
+	m := ctx.EffectiveMessage
+	if config.Conf.OwnerId == 0 {
+		_, _ = m.ReplyText(c, "Please set OWNER_ID in .env first.", nil)
+		return td.EndGroups
+	}
+
+	reply, err := m.ReplyText(c, "Creating a backup...", nil)
+	if err != nil {
+		return err
+	}
+
+	counts, err := sendBackup(c, "Backup")
+	if err != nil {
+		_, _ = reply.EditText(c, fmt.Sprintf("Backup failed: %s", err.Error()), nil)
+		return td.EndGroups
+	}
+
+	c.Logger.Info("Backup created", "by", m.SenderID(), "chats", counts.Chats, "users", counts.Users)
+	_, err = reply.EditText(c, "Backup sent to the owner.", nil)
+	return err
+}
+
+// sendBackup exports the database to a temporary archive and uploads it to the owner with the given title.
+func sendBackup(c *td.Client, title string) (db.BackupCounts, error) {
+	dbCtx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	createdAt := time.Now().UTC()
+	file := filepath.Join(os.TempDir(), fmt.Sprintf("tgmusic-backup-%s.jsonl.gz", createdAt.Format("20060102-150405.000")))
+	defer os.Remove(file)
+
+	counts, err := writeBackupFile(dbCtx, file, createdAt)
+	if err != nil {
+		return counts, fmt.Errorf("failed to write the backup: %w", err)
+	}
+
+	_, err = c.SendDocument(
+		config.Conf.OwnerId,
+		td.InputFileLocal{Path: file},
+		&td.SendDocumentOpts{Caption: "<b>" + title + "</b>\n" + backupSummary(createdAt, counts), ParseMode: "HTML"},
+	)
+	if err != nil {
+		return counts, fmt.Errorf("failed to send the backup to the owner: %w", err)
+	}
+	return counts, nil
+}
+
+func writeBackupFile(ctx context.Context, path string, createdAt time.Time) (db.BackupCounts, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return db.BackupCounts{}, err
+	}
+	counts, err := db.Instance.ExportBackup(ctx, f, createdAt)
+	if err != nil {
+		_ = f.Close()
+		return counts, err
+	}
+	return counts, f.Close()
+}
+
+// restoreHandler handles the /restore command, used as a reply to a /backup archive.
+// It validates the archive and shows its counts; nothing is written until merge or replace is chosen.
+func restoreHandler(c *td.Client, ctx *td.Context) error {
+	if !isDev(ctx) {
+		return td.EndGroups
+	}
+
+	m := ctx.EffectiveMessage
+	if m.ReplyToMessageID() == 0 {
+		_, _ = m.ReplyText(c, "Reply to a backup archive with /restore.", nil)
+		return td.EndGroups
+	}
+
+	r, err := m.GetRepliedMessage(c)
+	if err != nil {
+		_, _ = m.ReplyText(c, fmt.Sprintf("Failed to get the replied message: %s", err.Error()), nil)
+		return td.EndGroups
+	}
+
+	doc, ok := r.Content.(*td.MessageDocument)
+	if !ok || doc.Document == nil || doc.Document.Document == nil {
+		_, _ = m.ReplyText(c, "Reply to a backup archive with /restore.", nil)
+		return td.EndGroups
+	}
+	if doc.Document.Document.Size > maxBackupSize {
+		_, _ = m.ReplyText(c, fmt.Sprintf("The archive is too large (limit %d MB).", maxBackupSize>>20), nil)
+		return td.EndGroups
+	}
+
+	reply, err := m.ReplyText(c, "Checking the backup...", nil)
+	if err != nil {
+		return err
+	}
+
+	file, err := r.Download(c, 1, 0, 0, true)
+	if err != nil {
+		_, _ = reply.EditText(c, fmt.Sprintf("Failed to download the archive: %s", err.Error()), nil)
+		return td.EndGroups
+	}
+	// The backup is kept in memory until it is confirmed, so the download is not needed after reading it.
+	defer os.Remove(file.Local.Path)
+
+	backup, err := readBackupFile(file.Local.Path)
+	if err != nil {
+		_, _ = reply.EditText(c, fmt.Sprintf("Invalid backup: %s", err.Error()), nil)
+		return td.EndGroups
+	}
+
+	token := strconv.FormatInt(time.Now().UnixNano(), 36)
+	pendingRestores.Set(token, &pendingRestore{backup: backup, userID: m.SenderID()})
+
+	text := "<b>Restore this backup?</b>\n" + backupSummary(backup.CreatedAt, backup.Counts()) +
+		"\n\n<b>Merge</b> overwrites matching records and keeps the rest.\n" +
+		"<b>Replace</b> (owner only) deletes the current chats, users, playlists, auth lists, assignments, languages, " +
+		"blacklists and likes first. A backup of the current data is sent to the owner before anything is deleted."
+	_, err = reply.EditText(c, text, &td.EditTextMessageOpts{ParseMode: "HTML", ReplyMarkup: core.RestoreKeyboard(token)})
+	return err
+}
+
+func readBackupFile(path string) (*db.Backup, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return db.ReadBackup(f)
+}
+
+// restoreCallbackHandler applies or discards a previewed backup.
+func restoreCallbackHandler(c *td.Client, ctx *td.Context) error {
+	cb := ctx.Update.UpdateNewCallbackQuery
+	if !isDevID(cb.SenderUserId) {
+		return cb.Answer(c, 0, true, "Only developers can restore backups.", "")
+	}
+
+	action, token, _ := strings.Cut(strings.TrimPrefix(cb.DataString(), "restore_"), "_")
+	if action == "cancel" {
+		takePendingRestore(token)
+		_ = cb.Answer(c, 0, false, "", "")
+		_, err := cb.EditMessageText(c, "Restore cancelled.", nil)
+		return err
+	}
+
+	p, ok := pendingRestores.Get(token)
+	if !ok {
+		_ = cb.Answer(c, 0, true, "This preview has expired. Run /restore again.", "")
+		_, err := cb.EditMessageText(c, "Restore expired.", nil)
+		return err
+	}
+	if p.userID != cb.SenderUserId {
+		return cb.Answer(c, 0, true, "Only the developer who ran /restore can confirm it.", "")
+	}
+	replace := action == "replace"
+	// Replacing deletes data, so it is kept to the owner; other developers can still merge.
+	if replace && cb.SenderUserId != config.Conf.OwnerId {
+		return cb.Answer(c, 0, true, "Only the owner can replace the data. Choose merge instead.", "")
+	}
+	if p, ok = takePendingRestore(token); !ok {
+		return cb.Answer(c, 0, false, "", "")
+	}
+
+	_ = cb.Answer(c, 0, false, "", "")
+	if replace {
+		_, _ = cb.EditMessageText(c, "Backing up the current data...", nil)
+		if _, err := sendBackup(c, "Backup before restore"); err != nil {
+			_, err = cb.EditMessageText(c, fmt.Sprintf("Restore cancelled, nothing was changed: %s", err.Error()), nil)
+			return err
+		}
+	}
+	_, _ = cb.EditMessageText(c, "Restoring...", nil)
+
+	dbCtx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+	defer cancel()
+
+	if err := db.Instance.RestoreBackup(dbCtx, p.backup, replace); err != nil {
+		text := fmt.Sprintf("Restore failed: %s", err.Error())
+		if replace {
+			text += "\nRestore the backup sent to the owner before the restore to get the previous data back."
+		}
+		_, err = cb.EditMessageText(c, text, nil)
+		return err
+	}
+
+	mode := "merged"
+	if replace {
+		mode = "replaced"
+	}
+	c.Logger.Info("Backup restored", "by", cb.SenderUserId, "mode", mode)
+	_, err := cb.EditMessageText(c, fmt.Sprintf("Backup %s successfully.", mode), nil)
+	return err
+}
@@ -0,0 +1,265 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"sync"
+
+	"ashokshau/tgmusic/src/core/db"
+	"ashokshau/tgmusic/src/vc"
+
+	td "github.com/AshokShau/gotdbot"
+)
+
+// blacklistWatcher runs before every other message handler.
+// Messages from blacklisted users are dropped and blacklisted chats are left.
+func blacklistWatcher(c *td.Client, ctx *td.Context) error {
+	m := ctx.EffectiveMessage
+	if m == nil {
+		return nil
+	}
+
+	if userID := m.SenderID(); userID > 0 && !isDevID(userID) && db.Instance.IsBlacklistedUser(userID) {
+		return td.EndGroups
+	}
+
+	if chatID := ctx.EffectiveChatId; chatID < 0 && db.Instance.IsBlacklistedChat(chatID) {
+		leaveBlacklistedChat(c, chatID)
+		return td.EndGroups
+	}
+
+	return nil
+}
+
+// blacklistCallbackWatcher drops button presses from blacklisted users and chats.
+func blacklistCallbackWatcher(c *td.Client, ctx *td.Context) error {
+	cb := ctx.Update.UpdateNewCallbackQuery
+	if cb == nil {
+		return nil
+	}
+
+	if !isDevID(cb.SenderUserId) && db.Instance.IsBlacklistedUser(cb.SenderUserId) {
+		_ = cb.Answer(c, 0, false, "", "")
+		return td.EndGroups
+	}
+
+	if cb.ChatId < 0 && db.Instance.IsBlacklistedChat(cb.ChatId) {
+		_ = cb.Answer(c, 0, false, "", "")
+		leaveBlacklistedChat(c, cb.ChatId)
+		return td.EndGroups
+	}
+
+	return nil
+}
+
+// leavingChats holds the blacklisted chats being left, so a burst of updates starts only one leave per chat.
+var leavingChats sync.Map
+
+// leaveBlacklistedChat makes the assistant and the bot leave a blacklisted chat in the background.
+func leaveBlacklistedChat(c *td.Client, chatID int64) {
+	if _, loaded := leavingChats.LoadOrStore(chatID, struct{}{}); loaded {
+		return
+	}
+
+	go func() {
+		defer leavingChats.Delete(chatID)
+
+		if err := vc.Calls.LeaveChat(chatID); err != nil {
+			c.Logger.Warn("Assistant failed to leave a blacklisted chat", "chat_id", chatID, "error", err)
+		}
+
+		if err := c.LeaveChat(chatID); err != nil {
+			c.Logger.Warn("Failed to leave a blacklisted chat", "chat_id", chatID, "error", err)
+			return
+		}
+
+		c.Logger.Info("Left blacklisted chat", "chat_id", chatID)
+	}()
+}
+
+// blacklistHandler handles the /blacklist command.
+// Negative IDs blacklist a chat, anything else (including a replied message) blacklists a user.
+func blacklistHandler(c *td.Client, ctx *td.Context) error {
+	if !isDev(ctx) {
+		return td.EndGroups
+	}
+
+	m := ctx.EffectiveMessage
+	targetID, reason, err := blacklistTarget(c, m)
+	if err != nil {
+		_, _ = m.ReplyText(c, "<b>Usage:</b> /blacklist [id | @username | reply] [reason]\n\n"+html.EscapeString(err.Error()), replyOpts)
+		return td.EndGroups
+	}
+
+	if isDevID(targetID) {
+		_, _ = m.ReplyText(c, "Developers cannot be blacklisted.", nil)
+		return td.EndGroups
+	}
+
+	if targetID < 0 {
+		if db.Instance.IsBlacklistedChat(targetID) {
+			_, _ = m.ReplyText(c, "This chat is already blacklisted.", nil)
+			return td.EndGroups
+		}
+
+		if err = db.Instance.AddBlacklistedChat(targetID, m.SenderID(), reason); err != nil {
+			_, _ = m.ReplyText(c, fmt.Sprintf("Failed to blacklist the chat: %s", err.Error()), nil)
+			return td.EndGroups
+		}
+
+		leaveBlacklistedChat(c, targetID)
+		_, err = m.ReplyText(c, fmt.Sprintf("Chat <code>%d</code> has been blacklisted.", targetID), replyOpts)
+		return err
+	}
+
+	if db.Instance.IsBlacklistedUser(targetID) {
+		_, _ = m.ReplyText(c, "This user is already blacklisted.", nil)
+		return td.EndGroups
+	}
+
+	if err = db.Instance.AddBlacklistedUser(targetID, m.SenderID(), reason); err != nil {
+		_, _ = m.ReplyText(c, fmt.Sprintf("Failed to blacklist the user: %s", err.Error()), nil)
+		return td.EndGroups
+	}
+
+	_, err = m.ReplyText(c, fmt.Sprintf("User <code>%d</code> has been blacklisted.", targetID), replyOpts)
+	return err
+}
+
+// unblacklistHandler handles the /unblacklist command.
+func unblacklistHandler(c *td.Client, ctx *td.Context) error {
+	if !isDev(ctx) {
+		return td.EndGroups
+	}
+
+	m := ctx.EffectiveMessage
+	targetID, _, err := blacklistTarget(c, m)
+	if err != nil {
+		_, _ = m.ReplyText(c, "<b>Usage:</b> /unblacklist [id | @username | reply]\n\n"+html.EscapeString(err.Error()), replyOpts)
+		return td.EndGroups
+	}
+
+	if targetID < 0 {
+		if !db.Instance.IsBlacklistedChat(targetID) {
+			_, _ = m.ReplyText(c, "This chat is not blacklisted.", nil)
+			return td.EndGroups
+		}
+
+		if err = db.Instance.RemoveBlacklistedChat(targetID); err != nil {
+			_, _ = m.ReplyText(c, fmt.Sprintf("Failed to remove the chat from the blacklist: %s", err.Error()), nil)
+			return td.EndGroups
+		}
+
+		_, err = m.ReplyText(c, fmt.Sprintf("Chat <code>%d</code> has been removed from the blacklist.", targetID), replyOpts)
+		return err
+	}
+
+	if !db.Instance.IsBlacklistedUser(targetID) {
+		_, _ = m.ReplyText(c, "This user is not blacklisted.", nil)
+		return td.EndGroups
+	}
+
+	if err = db.Instance.RemoveBlacklistedUser(targetID); err != nil {
+		_, _ = m.ReplyText(c, fmt.Sprintf("Failed to remove the user from the blacklist: %s", err.Error()), nil)
+		return td.EndGroups
+	}
+
+	_, err = m.ReplyText(c, fmt.Sprintf("User <code>%d</code> has been removed from the blacklist.", targetID), replyOpts)
+	return err
+}
+
+// blacklistedHandler handles the /blacklisted command and lists every blacklisted chat and user.
+func blacklistedHandler(c *td.Client, ctx *td.Context) error {
+	if !isDev(ctx) {
+		return td.EndGroups
+	}
+
+	m := ctx.EffectiveMessage
+	chats, err := db.Instance.GetBlacklistedChatEntries()
+	if err != nil {
+		_, _ = m.ReplyText(c, fmt.Sprintf("Failed to fetch blacklisted chats: %s", err.Error()), nil)
+		return td.EndGroups
+	}
+
+	users, err := db.Instance.GetBlacklistedUserEntries()
+	if err != nil {
+		_, _ = m.ReplyText(c, fmt.Sprintf("Failed to fetch blacklisted users: %s", err.Error()), nil)
+		return td.EndGroups
+	}
+
+	if len(chats) == 0 && len(users) == 0 {
+		_, err = m.ReplyText(c, "The blacklist is empty.", nil)
+		return err
+	}
+
+	var sb strings.Builder
+	writeBlacklistSection(&sb, "Chats", chats)
+	writeBlacklistSection(&sb, "Users", users)
+
+	text := sb.String()
+	if len(text) > 4096 {
+		text = fmt.Sprintf("<b>Blacklisted Chats:</b> %d\n<b>Blacklisted Users:</b> %d", len(chats), len(users))
+	}
+
+	_, err = m.ReplyText(c, text, replyOpts)
+	return err
+}
+
+func writeBlacklistSection(sb *strings.Builder, title string, entries []db.BlacklistEntry) {
+	if len(entries) == 0 {
+		return
+	}
+
+	sb.WriteString(fmt.Sprintf("<b>Blacklisted %s</b> (%d)\n", title, len(entries)))
+	for _, entry := range entries {
+		sb.WriteString(fmt.Sprintf("• <code>%d</code>", entry.ID))
+		if !entry.AddedAt.IsZero() {
+			sb.WriteString(" — " + entry.AddedAt.UTC().Format("2006-01-02 15:04 UTC"))
+		}
+		if entry.AddedBy != 0 {
+			sb.WriteString(fmt.Sprintf(" by <code>%d</code>", entry.AddedBy))
+		}
+		sb.WriteString("\n")
+		if entry.Reason != "" {
+			sb.WriteString("  └ " + html.EscapeString(entry.Reason) + "\n")
+		}
+	}
+	sb.WriteString("\n")
+}
+
+// blacklistTarget resolves the chat or user a blacklist command refers to and the optional reason after it.
+func blacklistTarget(c *td.Client, m *td.Message) (int64, string, error) {
+	args := strings.TrimSpace(Args(m))
+
+	if m.ReplyToMessageID() != 0 {
+		userID, err := resolveFromReply(c, m)
+		return userID, args, err
+	}
+
+	fields := strings.Fields(args)
+	if len(fields) == 0 {
+		return 0, "", errors.New("no target specified")
+	}
+
+	reason := strings.TrimSpace(strings.TrimPrefix(args, fields[0]))
+	if id, err := strconv.ParseInt(fields[0], 10, 64); err == nil {
+		if id == 0 {
+			return 0, "", fmt.Errorf("invalid ID: %d", id)
+		}
+		return id, reason, nil
+	}
+
+	id, err := resolveUsername(c, fields[0])
+	return id, reason, err
+}
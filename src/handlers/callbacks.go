@@ -9,15 +9,14 @@
 package handlers
 
 import (
+	"ashokshau/tgmusic/src/core/lang"
 	"ashokshau/tgmusic/src/utils"
-	"fmt"
 	"html"
 	"log/slog"
 	"strings"
 
 	"ashokshau/tgmusic/src/core"
 	"ashokshau/tgmusic/src/core/cache"
-	"ashokshau/tgmusic/src/core/db"
 	"ashokshau/tgmusic/src/vc"
 
 	td "github.com/AshokShau/gotdbot"
@@ -37,147 +36,123 @@ func playCallbackHandler(c *td.Client, ctx *td.Context) error {
 	chatID := cb.ChatId
 	user, err := c.GetUser(cb.SenderUserId)
 	if err != nil {
-		user = &td.User{FirstName: "Unknown", Id: cb.SenderUserId}
+		user = &td.User{FirstName: lang.T(chatID, "common.unknown"), Id: cb.SenderUserId}
 	}
 
 	if !cache.ChatCache.IsActive(chatID) {
-		text := "There is no active playback."
+		text := lang.T(chatID, "callback.no_playback")
 		_ = cb.Answer(c, 0, false, text, "")
-		_, _ = cb.EditMessageText(c, text, &td.EditTextMessageOpts{ReplyMarkup: core.ControlButtons(""), ParseMode: "HTML", DisableWebPagePreview: true})
+		_ = editPanel(c, cb, text, core.ControlButtons(""))
 		return nil
 	}
 
 	currentTrack := cache.ChatCache.GetPlayingTrack(chatID)
 	if currentTrack == nil {
-		_ = cb.Answer(c, 0, false, "There is no active playback.", "")
-		_, _ = cb.EditMessageText(c, "There is no active playback.", &td.EditTextMessageOpts{ReplyMarkup: core.ControlButtons(""), ParseMode: "HTML", DisableWebPagePreview: true})
+		text := lang.T(chatID, "callback.no_playback")
+		_ = cb.Answer(c, 0, false, text, "")
+		_ = editPanel(c, cb, text, core.ControlButtons(""))
 		return nil
 	}
 
-	buildTrackMessage := func(status, emoji string) string {
+	// buildTrackMessage is used to redraw the panel with the current track, so likes on it go to that track from now on.
+	buildTrackMessage := func(statusKey, emoji string) string {
+		core.SetPanelTrack(chatID, cb.MessageId, currentTrack)
 		escURL := html.EscapeString(currentTrack.URL)
 		escName := html.EscapeString(currentTrack.Name)
 		escUser := html.EscapeString(currentTrack.User)
-		return fmt.Sprintf("%s <b>%s</b>\n\n<b>Track:</b> <a href='%s'>%s</a>\n<b>Duration:</b> %s\n<b>Requested by:</b> %s",
-			emoji, status,
+		return lang.T(chatID, "np.panel",
+			emoji, lang.T(chatID, statusKey),
 			escURL, escName,
-			utils.SecToMin(currentTrack.Duration),
+			utils.TrackDuration(currentTrack.Duration, currentTrack.IsLive),
 			escUser,
-		)
+		) + listenersLine(chatID)
 	}
 
 	switch {
 	case strings.Contains(data, "play_skip"):
 		if err := vc.Calls.PlayNext(chatID); err != nil {
-			_ = cb.Answer(c, 0, false, "Unable to skip the current track.", "")
-			_, _ = cb.EditMessageText(c, "Unable to skip the current track.", &td.EditTextMessageOpts{ReplyMarkup: core.ControlButtons(""), ParseMode: "HTML", DisableWebPagePreview: true})
+			_ = cb.Answer(c, 0, false, lang.T(chatID, "callback.skip_failed"), "")
+			_ = editPanel(c, cb, lang.T(chatID, "callback.skip_failed"), core.ControlButtons(""))
 			return nil
 		}
-		_ = cb.Answer(c, 0, false, "Track skipped.", "")
+		_ = cb.Answer(c, 0, false, lang.T(chatID, "callback.skipped"), "")
 		_ = c.DeleteMessages(chatID, []int64{cb.MessageId}, &td.DeleteMessagesOpts{Revoke: true})
 		return nil
 
 	case strings.Contains(data, "play_stop"):
 		if err := vc.Calls.Stop(chatID); err != nil {
-			_ = cb.Answer(c, 0, false, "Unable to stop playback.", "")
-			_, _ = cb.EditMessageText(c, "Unable to stop playback.", &td.EditTextMessageOpts{ReplyMarkup: core.ControlButtons(""), ParseMode: "HTML", DisableWebPagePreview: true})
+			_ = cb.Answer(c, 0, false, lang.T(chatID, "callback.stop_failed"), "")
+			_ = editPanel(c, cb, lang.T(chatID, "callback.stop_failed"), core.ControlButtons(""))
 			return nil
 		}
 
-		msg := fmt.Sprintf("<b>Playback stopped.</b>\nRequested by: %s", html.EscapeString(user.FirstName))
-		_ = cb.Answer(c, 0, false, "Playback stopped.", "")
-		_, err := cb.EditMessageText(c, msg, &td.EditTextMessageOpts{ReplyMarkup: core.ControlButtons(""), ParseMode: "HTML", DisableWebPagePreview: true})
+		msg := lang.T(chatID, "callback.stopped_by", html.EscapeString(user.FirstName))
+		_ = cb.Answer(c, 0, false, lang.T(chatID, "callback.stopped"), "")
+		err := editPanel(c, cb, msg, core.ControlButtons(""))
 		return err
 
 	case strings.Contains(data, "play_pause"):
 		if _, err = vc.Calls.Pause(chatID); err != nil {
-			_ = cb.Answer(c, 0, false, "Unable to pause playback.", "")
-			_, _ = cb.EditMessageText(c, "Unable to pause playback.", &td.EditTextMessageOpts{ReplyMarkup: core.ControlButtons(""), ParseMode: "HTML", DisableWebPagePreview: true})
+			_ = cb.Answer(c, 0, false, lang.T(chatID, "callback.pause_failed"), "")
+			_ = editPanel(c, cb, lang.T(chatID, "callback.pause_failed"), core.ControlButtons(""))
 			return nil
 		}
-		_ = cb.Answer(c, 0, false, "Playback paused.", "")
-		text := buildTrackMessage("Paused", "⏸") + fmt.Sprintf("\n\nPaused by %s", html.EscapeString(user.FirstName))
-		_, _ = cb.EditMessageText(c, text, &td.EditTextMessageOpts{ReplyMarkup: core.ControlButtons("pause"), ParseMode: "HTML", DisableWebPagePreview: true})
+		_ = cb.Answer(c, 0, false, lang.T(chatID, "callback.paused"), "")
+		text := buildTrackMessage("np.status_paused", "⏸") + lang.T(chatID, "callback.paused_by", html.EscapeString(user.FirstName))
+		_ = editPanel(c, cb, text, core.ControlButtons("pause"))
 		return nil
 
 	case strings.Contains(data, "play_resume"):
 		if _, err := vc.Calls.Resume(chatID); err != nil {
-			_ = cb.Answer(c, 0, false, "Unable to resume playback.", "")
-			_, _ = cb.EditMessageText(c, "Unable to resume playback.", &td.EditTextMessageOpts{ReplyMarkup: core.ControlButtons("pause"), ParseMode: "HTML", DisableWebPagePreview: true})
+			_ = cb.Answer(c, 0, false, lang.T(chatID, "callback.resume_failed"), "")
+			_ = editPanel(c, cb, lang.T(chatID, "callback.resume_failed"), core.ControlButtons("pause"))
 			return nil
 		}
-		_ = cb.Answer(c, 0, false, "Playback resumed.", "")
-		text := buildTrackMessage("Now Playing", "▶") + fmt.Sprintf("\n\nResumed by %s", html.EscapeString(user.FirstName))
-		_, _ = cb.EditMessageText(c, text, &td.EditTextMessageOpts{ReplyMarkup: core.ControlButtons("resume"), ParseMode: "HTML", DisableWebPagePreview: true})
+		_ = cb.Answer(c, 0, false, lang.T(chatID, "callback.resumed"), "")
+		text := buildTrackMessage("np.status_playing", "▶") + lang.T(chatID, "callback.resumed_by", html.EscapeString(user.FirstName))
+		_ = editPanel(c, cb, text, core.ControlButtons("resume"))
 		return nil
 
 	case strings.Contains(data, "play_mute"):
 		if _, err := vc.Calls.Mute(chatID); err != nil {
-			_ = cb.Answer(c, 0, false, "Unable to mute playback.", "")
-			_, _ = cb.EditMessageText(c, "Unable to mute playback.", &td.EditTextMessageOpts{ReplyMarkup: core.ControlButtons("mute"), ParseMode: "HTML", DisableWebPagePreview: true})
+			_ = cb.Answer(c, 0, false, lang.T(chatID, "callback.mute_failed"), "")
+			_ = editPanel(c, cb, lang.T(chatID, "callback.mute_failed"), core.ControlButtons("mute"))
 			return nil
 		}
-		_ = cb.Answer(c, 0, false, "Playback muted.", "")
-		text := buildTrackMessage("Muted", "🔇") + fmt.Sprintf("\n\nMuted by %s", html.EscapeString(user.FirstName))
-		_, _ = cb.EditMessageText(c, text, &td.EditTextMessageOpts{ReplyMarkup: core.ControlButtons("mute"), ParseMode: "HTML", DisableWebPagePreview: true})
+		_ = cb.Answer(c, 0, false, lang.T(chatID, "callback.muted"), "")
+		text := buildTrackMessage("np.status_muted", "🔇") + lang.T(chatID, "callback.muted_by", html.EscapeString(user.FirstName))
+		_ = editPanel(c, cb, text, core.ControlButtons("mute"))
 		return nil
 
 	case strings.Contains(data, "play_unmute"):
 		if _, err := vc.Calls.Unmute(chatID); err != nil {
-			_ = cb.Answer(c, 0, false, "Unable to unmute playback.", "")
-			_, _ = cb.EditMessageText(c, "Unable to unmute playback.", &td.EditTextMessageOpts{ReplyMarkup: core.ControlButtons("unmute"), ParseMode: "HTML"})
+			_ = cb.Answer(c, 0, false, lang.T(chatID, "callback.unmute_failed"), "")
+			_ = editPanel(c, cb, lang.T(chatID, "callback.unmute_failed"), core.ControlButtons("unmute"))
 			return nil
 		}
-		_ = cb.Answer(c, 0, false, "Playback unmuted.", "")
-		text := buildTrackMessage("Now Playing", "▶") + fmt.Sprintf("\n\nUnmuted by %s", html.EscapeString(user.FirstName))
-		_, _ = cb.EditMessageText(c, text, &td.EditTextMessageOpts{ReplyMarkup: core.ControlButtons("unmute"), DisableWebPagePreview: true})
+		_ = cb.Answer(c, 0, false, lang.T(chatID, "callback.unmuted"), "")
+		text := buildTrackMessage("np.status_playing", "▶") + lang.T(chatID, "callback.unmuted_by", html.EscapeString(user.FirstName))
+		_ = editPanel(c, cb, text, core.ControlButtons("unmute"))
 		return nil
 
 	case strings.Contains(data, "play_add_to_list"):
-		playlists, err := db.Instance.GetUserPlaylists(cb.SenderUserId)
-		if err != nil {
-			_ = cb.Answer(c, 0, false, "Unable to fetch playlists.", "")
-			return nil
-		}
-
-		var playlistID string
-		if len(playlists) == 0 {
-			playlistID, err = db.Instance.CreatePlaylist("My Playlist (TgMusic)", cb.SenderUserId)
-			if err != nil {
-				_ = cb.Answer(c, 0, false, "Unable to create playlist.", "")
-				return nil
-			}
-		} else {
-			playlistID = playlists[0].ID
-		}
-
-		song := db.Song{
-			URL:      currentTrack.URL,
-			Name:     currentTrack.Name,
-			TrackID:  currentTrack.TrackID,
-			Duration: currentTrack.Duration,
-			Platform: currentTrack.Platform,
-		}
-
-		err = db.Instance.AddSongToPlaylist(playlistID, song)
-		if err != nil {
-			_ = cb.Answer(c, 0, false, "Unable to add track to playlist.", "")
-			return nil
-		}
+		return addToPlaylist(c, cb, user, songFromCached(currentTrack))
+	}
 
-		playlist, err := db.Instance.GetPlaylist(playlistID)
-		if err != nil {
-			_ = cb.Answer(c, 0, false, "Playlist not found.", "")
-			return nil
-		}
+	text := buildTrackMessage("np.status_playing", "▶")
+	_ = editPanel(c, cb, text, core.ControlButtons("resume"))
+	return nil
+}
 
-		_ = cb.Answer(c, 0, false, fmt.Sprintf("Track \"%s\" added to playlist \"%s\".", song.Name, playlist.Name), "")
+// editPanel updates the playback panel, which is either a text message or a now-playing card with a caption.
+func editPanel(c *td.Client, cb *td.UpdateNewCallbackQuery, text string, markup *td.ReplyMarkupInlineKeyboard) error {
+	_, err := cb.EditMessageText(c, text, &td.EditTextMessageOpts{ReplyMarkup: markup, ParseMode: "HTML", DisableWebPagePreview: true})
+	if err == nil {
 		return nil
 	}
 
-	text := buildTrackMessage("Now Playing", "▶")
-	_, _ = cb.EditMessageText(c, text, &td.EditTextMessageOpts{ReplyMarkup: core.ControlButtons("resume"), ParseMode: "HTML", DisableWebPagePreview: true})
-	return nil
+	_, err = cb.EditMessageCaption(c, text, &td.EditCaptionOpts{ReplyMarkup: markup, ParseMode: "HTML"})
+	return err
 }
 
 func vcPlayHandler(c *td.Client, ctx *td.Context) error {
@@ -185,7 +160,7 @@ func vcPlayHandler(c *td.Client, ctx *td.Context) error {
 	data := cb.DataString()
 
 	if strings.Contains(data, "vcplay_close") {
-		_ = cb.Answer(c, 0, false, "Closing panel.", "")
+		_ = cb.Answer(c, 0, false, lang.T(cb.ChatId, "callback.closing"), "")
 		_ = c.DeleteMessages(cb.ChatId, []int64{cb.MessageId}, &td.DeleteMessagesOpts{Revoke: true})
 		return nil
 	}
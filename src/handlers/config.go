@@ -0,0 +1,134 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package handlers
+
+import (
+	"ashokshau/tgmusic/config"
+	"fmt"
+	"html"
+	"strings"
+
+	"ashokshau/tgmusic/src/core/db"
+
+	td "github.com/AshokShau/gotdbot"
+)
+
+const configUsage = "<b>Usage:</b>\n" +
+	"• <code>/config list</code> — Show every runtime setting\n" +
+	"• <code>/config get KEY</code> — Show one setting\n" +
+	"• <code>/config set KEY [VALUE]</code> — Change a setting immediately\n" +
+	"• <code>/config reset KEY</code> — Restore the environment value"
+
+// configHandler handles the /config command, which reads and changes runtime settings without a restart.
+// Overrides are stored in the database and applied again on startup.
+func configHandler(c *td.Client, ctx *td.Context) error {
+	if !isDev(ctx) {
+		return td.EndGroups
+	}
+
+	m := ctx.EffectiveMessage
+	args := strings.Fields(Args(m))
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "list":
+		return configList(c, m)
+	case "get":
+		if len(args) != 2 {
+			break
+		}
+		return configGet(c, m, args[1])
+	case "set":
+		if len(args) < 2 {
+			break
+		}
+		return configSet(c, m, args[1], strings.Join(args[2:], " "))
+	case "reset", "unset":
+		if len(args) != 2 {
+			break
+		}
+		return configReset(c, m, args[1])
+	}
+
+	_, err := m.ReplyText(c, configUsage, replyOpts)
+	return err
+}
+
+func configList(c *td.Client, m *td.Message) error {
+	var sb strings.Builder
+	sb.WriteString("<b>Runtime Settings</b>\n\n")
+	for _, s := range config.Settings() {
+		value, _ := config.Conf.Display(s.Key)
+		if value == "" {
+			value = "—"
+		}
+		sb.WriteString(fmt.Sprintf("• <code>%s</code> = <code>%s</code>\n  └ %s\n", s.Key, html.EscapeString(value), s.Help))
+	}
+
+	_, err := m.ReplyText(c, sb.String(), replyOpts)
+	return err
+}
+
+func configGet(c *td.Client, m *td.Message, key string) error {
+	value, err := config.Conf.Display(key)
+	if err != nil {
+		_, _ = m.ReplyText(c, err.Error(), nil)
+		return td.EndGroups
+	}
+
+	_, err = m.ReplyText(c, fmt.Sprintf("<code>%s</code> = <code>%s</code>", strings.ToUpper(key), html.EscapeString(value)), replyOpts)
+	return err
+}
+
+func configSet(c *td.Client, m *td.Message, key, value string) error {
+	s, ok := config.LookupSetting(key)
+	if !ok {
+		_, _ = m.ReplyText(c, fmt.Sprintf("Unknown setting: %s", key), nil)
+		return td.EndGroups
+	}
+
+	if err := config.Conf.Set(s.Key, value); err != nil {
+		_, _ = m.ReplyText(c, fmt.Sprintf("Invalid value for %s: %s", s.Key, err.Error()), nil)
+		return td.EndGroups
+	}
+
+	if err := db.Instance.SetSetting(s.Key, value); err != nil {
+		_, _ = m.ReplyText(c, fmt.Sprintf("Applied, but failed to save %s: %s", s.Key, err.Error()), nil)
+		return td.EndGroups
+	}
+
+	display, _ := config.Conf.Display(s.Key)
+	c.Logger.Info("Runtime setting changed", "key", s.Key, "by", m.SenderID())
+	_, err := m.ReplyText(c, fmt.Sprintf("<code>%s</code> is now <code>%s</code>.", s.Key, html.EscapeString(display)), replyOpts)
+	return err
+}
+
+func configReset(c *td.Client, m *td.Message, key string) error {
+	s, ok := config.LookupSetting(key)
+	if !ok {
+		_, _ = m.ReplyText(c, fmt.Sprintf("Unknown setting: %s", key), nil)
+		return td.EndGroups
+	}
+
+	if err := db.Instance.DeleteSetting(s.Key); err != nil {
+		_, _ = m.ReplyText(c, fmt.Sprintf("Failed to reset %s: %s", s.Key, err.Error()), nil)
+		return td.EndGroups
+	}
+
+	if err := config.Conf.Reset(s.Key); err != nil {
+		_, _ = m.ReplyText(c, fmt.Sprintf("Failed to reset %s: %s", s.Key, err.Error()), nil)
+		return td.EndGroups
+	}
+
+	display, _ := config.Conf.Display(s.Key)
+	_, err := m.ReplyText(c, fmt.Sprintf("<code>%s</code> has been reset to <code>%s</code>.", s.Key, html.EscapeString(display)), replyOpts)
+	return err
+}
@@ -93,6 +93,24 @@ func clearAssistantsHandler(c *td.Client, ctx *td.Context) error {
 	return err
 }
 
+// Handles the /rebalance command to spread idle chats evenly over the assistants
+func rebalanceHandler(c *td.Client, ctx *td.Context) error {
+	if !isDev(ctx) {
+		return td.EndGroups
+	}
+
+	m := ctx.EffectiveMessage
+
+	moved, err := vc.Calls.Rebalance()
+	if err != nil {
+		_, _ = m.ReplyText(c, fmt.Sprintf("failed to rebalance assistants: %s", err.Error()), nil)
+		return td.EndGroups
+	}
+
+	_, err = m.ReplyText(c, fmt.Sprintf("Reassigned %d idle chats (strategy: %s)", moved, config.Conf.AssignStrategy()), nil)
+	return err
+}
+
 // Handles the /leaveall command to leave all chats
 func leaveAllHandler(c *td.Client, ctx *td.Context) error {
 	if !isDev(ctx) {
@@ -56,18 +56,20 @@ var replyOpts = &gotdbot.SendTextMessageOpts{
 	DisableWebPagePreview: true,
 }
 
+var editOpts = &gotdbot.EditTextMessageOpts{
+	ParseMode:             "HTML",
+	DisableWebPagePreview: true,
+}
+
 // isDev checks if the user is a developer.
 // It returns true if the user is a developer, otherwise false.
 func isDev(ctx *gotdbot.Context) bool {
-	m := ctx.EffectiveMessage
-
-	for _, dev := range config.Conf.DEVS {
-		if dev == m.SenderID() {
-			return true
-		}
-	}
+	return isDevID(ctx.EffectiveMessage.SenderID())
+}
 
-	return false
+// isDevID reports whether userID belongs to a developer.
+func isDevID(userID int64) bool {
+	return config.Conf.IsDev(userID)
 }
 
 func SenderID(sender gotdbot.MessageSender) int64 {
@@ -9,6 +9,8 @@
 package handlers
 
 import (
+	"ashokshau/tgmusic/config"
+	"ashokshau/tgmusic/src/core/lang"
 	"ashokshau/tgmusic/src/utils"
 	"slices"
 	"strings"
@@ -23,10 +25,10 @@ func checkBotAdmin(c *td.Client, chatID int64, replyErr func(msg string)) bool {
 	botStatus, err := cache.GetUserAdmin(c, chatID, c.Me.Id, false)
 	if err != nil {
 		if strings.Contains(err.Error(), "is not an admin in chat") {
-			replyErr("Bot is not an administrator in this chat. Please promote the bot with invite users permission.")
+			replyErr(lang.T(chatID, "filters.bot_not_admin_invite"))
 		} else {
 			c.Logger.Warn("GetUserAdmin error", "error", err)
-			replyErr("Unable to verify bot administrator status.")
+			replyErr(lang.T(chatID, "filters.bot_admin_unknown"))
 		}
 		return false
 	}
@@ -36,12 +38,12 @@ func checkBotAdmin(c *td.Client, chatID int64, replyErr func(msg string)) bool {
 		return true
 	case *td.ChatMemberStatusAdministrator:
 		if s.Rights == nil || !s.Rights.CanInviteUsers {
-			replyErr("The bot does not have permission to invite users.")
+			replyErr(lang.T(chatID, "filters.bot_no_invite"))
 			return false
 		}
 		return true
 	default:
-		replyErr("Bot is not an administrator in this chat. Use /reload to refresh admin cache.")
+		replyErr(lang.T(chatID, "filters.bot_not_admin_reload"))
 		return false
 	}
 }
@@ -49,7 +51,7 @@ func checkBotAdmin(c *td.Client, chatID int64, replyErr func(msg string)) bool {
 func adminMode(c *td.Client, ctx *td.Context) bool {
 	m := ctx.EffectiveMessage
 	if m.IsPrivate() {
-		return false
+		return config.Conf.IsP2PAllowed(m.SenderID())
 	}
 
 	chatID := m.ChatId
@@ -66,16 +68,19 @@ func adminMode(c *td.Client, ctx *td.Context) bool {
 		if db.Instance.IsAdmin(chatID, userID) || db.Instance.IsAuthUser(chatID, userID) {
 			return true
 		}
-		_, _ = m.ReplyText(c, "You must be an administrator to use this command.", nil)
+		_, _ = m.ReplyText(c, lang.T(chatID, "filters.admin_required"), nil)
 		return false
 	default:
-		_, _ = m.ReplyText(c, "You are not authorized to use this command.", nil)
+		_, _ = m.ReplyText(c, lang.T(chatID, "filters.not_authorized"), nil)
 		return false
 	}
 }
 
 func adminModeCB(c *td.Client, cb *td.UpdateNewCallbackQuery) bool {
 	chatID := cb.ChatId
+	if chatID > 0 {
+		return config.Conf.IsP2PAllowed(cb.SenderUserId)
+	}
 
 	if !checkBotAdmin(c, chatID, func(msg string) { _ = cb.Answer(c, 0, true, msg, "") }) {
 		return false
@@ -89,10 +94,10 @@ func adminModeCB(c *td.Client, cb *td.UpdateNewCallbackQuery) bool {
 		if db.Instance.IsAdmin(chatID, userID) || db.Instance.IsAuthUser(chatID, userID) {
 			return true
 		}
-		_ = cb.Answer(c, 0, true, "You must be an administrator to use this action.", "")
+		_ = cb.Answer(c, 0, true, lang.T(chatID, "filters.admin_required_action"), "")
 		return false
 	default:
-		_ = cb.Answer(c, 0, true, "You are not authorized to use this action.", "")
+		_ = cb.Answer(c, 0, true, lang.T(chatID, "filters.not_authorized_action"), "")
 		return false
 	}
 }
@@ -100,7 +105,7 @@ func adminModeCB(c *td.Client, cb *td.UpdateNewCallbackQuery) bool {
 func playMode(c *td.Client, ctx *td.Context) bool {
 	m := ctx.EffectiveMessage
 	if m.IsPrivate() {
-		return false
+		return config.Conf.IsP2PAllowed(m.SenderID())
 	}
 
 	chatID := m.ChatID()
@@ -122,7 +127,7 @@ func playMode(c *td.Client, ctx *td.Context) bool {
 		})
 
 		if !isAdmin && !db.Instance.IsAuthUser(chatID, senderID) {
-			_, _ = m.ReplyText(c, "Play mode is enabled. Only administrators and authorized users can start playback.", nil)
+			_, _ = m.ReplyText(c, lang.T(chatID, "filters.play_mode_admins"), nil)
 			return false
 		}
 	}
@@ -0,0 +1,84 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package handlers
+
+import (
+	"ashokshau/tgmusic/src/core"
+	"ashokshau/tgmusic/src/core/db"
+	"ashokshau/tgmusic/src/core/lang"
+	"context"
+	"strings"
+	"time"
+
+	td "github.com/AshokShau/gotdbot"
+)
+
+// langHandler handles the /lang command. Without arguments it shows the language picker.
+func langHandler(c *td.Client, ctx *td.Context) error {
+	m := ctx.EffectiveMessage
+	chatID := ctx.EffectiveChatId
+
+	if !m.IsPrivate() && !db.Instance.IsAdmin(chatID, m.SenderID()) {
+		_, err := m.ReplyText(c, lang.T(chatID, "filters.admin_required"), nil)
+		return err
+	}
+
+	code := strings.ToLower(Args(m))
+	if code == "" {
+		_, err := m.ReplyText(c, lang.T(chatID, "lang.choose"), &td.SendTextMessageOpts{ReplyMarkup: core.LanguageKeyboard("lang_", lang.ChatLanguage(chatID), "")})
+		return err
+	}
+
+	if !lang.IsSupported(code) {
+		var codes []string
+		for _, l := range lang.Languages() {
+			codes = append(codes, "<code>"+l.Code+"</code>")
+		}
+		_, err := m.ReplyText(c, lang.T(chatID, "lang.unsupported", strings.Join(codes, ", ")), replyOpts)
+		return err
+	}
+
+	if err := setChatLanguage(chatID, code); err != nil {
+		_, err = m.ReplyText(c, lang.T(chatID, "lang.save_failed"), nil)
+		return err
+	}
+
+	_, err := m.ReplyText(c, lang.T(chatID, "lang.changed", lang.Name(code)), nil)
+	return err
+}
+
+// langCallbackHandler applies a language picked from the /lang keyboard.
+func langCallbackHandler(c *td.Client, ctx *td.Context) error {
+	cb := ctx.Update.UpdateNewCallbackQuery
+	chatID := cb.ChatId
+
+	if chatID < 0 && !db.Instance.IsAdmin(chatID, cb.SenderUserId) {
+		return cb.Answer(c, 0, true, lang.T(chatID, "filters.admin_required_action"), "")
+	}
+
+	code := strings.TrimPrefix(cb.DataString(), "lang_")
+	if !lang.IsSupported(code) {
+		return cb.Answer(c, 0, true, lang.T(chatID, "lang.unsupported_short"), "")
+	}
+
+	if err := setChatLanguage(chatID, code); err != nil {
+		return cb.Answer(c, 0, true, lang.T(chatID, "lang.save_failed"), "")
+	}
+
+	_ = cb.Answer(c, 0, false, "", "")
+	_, err := cb.EditMessageText(c, lang.T(chatID, "lang.changed", lang.Name(code)), nil)
+	return err
+}
+
+// setChatLanguage stores the language for a chat.
+func setChatLanguage(chatID int64, code string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return db.Instance.SetLanguage(ctx, chatID, code)
+}
@@ -0,0 +1,287 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package handlers
+
+import (
+	"ashokshau/tgmusic/src/core"
+	"ashokshau/tgmusic/src/core/lang"
+	"ashokshau/tgmusic/src/utils"
+	"errors"
+	"html"
+	"slices"
+	"strconv"
+	"strings"
+
+	"ashokshau/tgmusic/src/core/cache"
+	"ashokshau/tgmusic/src/core/db"
+
+	td "github.com/AshokShau/gotdbot"
+)
+
+const (
+	likesPerPage = 8
+	topLikesSize = 10
+)
+
+// likeEmojis are the reactions on a now-playing panel that like its song.
+var likeEmojis = []string{"❤", "❤️", "👍", "🔥"}
+
+// likeCallbackHandler handles ❤️ on the now-playing panel. Liking is personal, so unlike the other panel buttons
+// it is not limited by admin mode.
+func likeCallbackHandler(c *td.Client, ctx *td.Context) error {
+	cb := ctx.Update.UpdateNewCallbackQuery
+	chatID := cb.ChatId
+
+	track, ok := core.PanelTrack(chatID, cb.MessageId)
+	if !ok {
+		// Panels sent before a restart are not remembered; they can only show the current track.
+		track = cache.ChatCache.GetPlayingTrack(chatID)
+	}
+	if track == nil {
+		_ = cb.Answer(c, 0, false, lang.T(chatID, "callback.no_playback"), "")
+		return nil
+	}
+
+	song := songFromCached(track)
+	liked, err := db.Instance.ToggleLike(cb.SenderUserId, song)
+	if errors.Is(err, db.ErrNoTrackID) {
+		_ = cb.Answer(c, 0, false, lang.T(chatID, "likes.unsupported"), "")
+		return nil
+	} else if err != nil {
+		c.Logger.Warn("failed to toggle like", "error", err)
+		_ = cb.Answer(c, 0, false, lang.T(chatID, "likes.failed"), "")
+		return nil
+	}
+
+	if !liked {
+		_ = cb.Answer(c, 0, false, lang.T(chatID, "likes.removed", song.Name), "")
+		return nil
+	}
+	count, _ := db.Instance.GetLikeCount(song.TrackID)
+	if count <= 1 {
+		_ = cb.Answer(c, 0, false, lang.T(chatID, "likes.added_first", song.Name), "")
+		return nil
+	}
+	_ = cb.Answer(c, 0, false, lang.T(chatID, "likes.added", song.Name, count), "")
+	return nil
+}
+
+// likeReactionHandler likes the song of a now-playing panel when a user reacts to it with one of likeEmojis,
+// and unlikes it when the reaction is taken back. Telegram only sends reaction updates to bots that are admins.
+func likeReactionHandler(c *td.Client, ctx *td.Context) error {
+	update := ctx.Update.UpdateMessageReaction
+	if update == nil {
+		return td.EndGroups
+	}
+
+	track, ok := core.PanelTrack(update.ChatId, update.MessageId)
+	if !ok {
+		return nil
+	}
+	// Anonymous admins and channels react as a chat; likes belong to users.
+	sender, ok := update.ActorId.(*td.MessageSenderUser)
+	if !ok {
+		return nil
+	}
+
+	had, has := hasLikeReaction(update.OldReactionTypes), hasLikeReaction(update.NewReactionTypes)
+	var err error
+	switch {
+	case has && !had:
+		_, err = db.Instance.LikeSong(sender.UserId, songFromCached(track))
+	case had && !has:
+		_, err = db.Instance.UnlikeSong(sender.UserId, track.TrackID)
+	}
+	if err != nil && !errors.Is(err, db.ErrNoTrackID) {
+		c.Logger.Warn("failed to update like from reaction", "error", err)
+	}
+	return nil
+}
+
+func hasLikeReaction(reactions []td.ReactionType) bool {
+	for _, reaction := range reactions {
+		if emoji, ok := reaction.(*td.ReactionTypeEmoji); ok && slices.Contains(likeEmojis, emoji.Emoji) {
+			return true
+		}
+	}
+	return false
+}
+
+// likesHandler handles /likes and shows the sender's liked songs, newest first.
+func likesHandler(c *td.Client, ctx *td.Context) error {
+	m := ctx.EffectiveMessage
+
+	text, markup, err := buildLikesPage(m.ChatId, m.SenderID(), 0)
+	if err != nil {
+		_, err = m.ReplyText(c, lang.T(m.ChatId, "likes.fetch_failed", err.Error()), nil)
+		return err
+	}
+
+	_, err = m.ReplyText(c, text, &td.SendTextMessageOpts{ParseMode: "HTML", DisableWebPagePreview: true, ReplyMarkup: markup})
+	return err
+}
+
+// likesCallbackHandler pages through liked songs and removes them. Anyone can page, like the playlist browser;
+// only the owner can remove.
+func likesCallbackHandler(c *td.Client, ctx *td.Context) error {
+	cb := ctx.Update.UpdateNewCallbackQuery
+	chatID := cb.ChatId
+
+	parts := strings.Split(strings.TrimPrefix(cb.DataString(), "likes_"), "_")
+	if len(parts) != 3 {
+		_ = cb.Answer(c, 0, false, lang.T(chatID, "common.invalid_page"), "")
+		return nil
+	}
+	userID, err := strconv.ParseInt(parts[1], 10, 64)
+	// Remove buttons carry the song's tag after its position.
+	index, tag, _ := strings.Cut(parts[2], ":")
+	n, nErr := strconv.Atoi(index)
+	if err != nil || nErr != nil || n < 0 {
+		_ = cb.Answer(c, 0, false, lang.T(chatID, "common.invalid_page"), "")
+		return nil
+	}
+
+	page := n
+	switch parts[0] {
+	case "view":
+	case "rm":
+		if userID != cb.SenderUserId {
+			_ = cb.Answer(c, 0, true, lang.T(chatID, "likes.not_yours"), "")
+			return nil
+		}
+		likes, err := db.Instance.GetLikes(userID)
+		if err != nil {
+			_ = cb.Answer(c, 0, true, lang.T(chatID, "likes.failed"), "")
+			return nil
+		}
+		page = n / likesPerPage
+		// The list changed since the buttons were drawn; show it as it is now instead of removing another song.
+		if n >= len(likes) || db.SongTag(likes[n].Song) != tag {
+			text, markup, err := buildLikesPage(chatID, userID, page)
+			_ = cb.Answer(c, 0, true, lang.T(chatID, "likes.changed"), "")
+			if err == nil {
+				_, _ = cb.EditMessageText(c, text, &td.EditTextMessageOpts{ParseMode: "HTML", ReplyMarkup: markup, DisableWebPagePreview: true})
+			}
+			return nil
+		}
+		if _, err = db.Instance.UnlikeSong(userID, likes[n].Song.TrackID); err != nil {
+			_ = cb.Answer(c, 0, true, lang.T(chatID, "likes.failed"), "")
+			return nil
+		}
+	default:
+		_ = cb.Answer(c, 0, false, lang.T(chatID, "common.invalid_page"), "")
+		return nil
+	}
+
+	text, markup, err := buildLikesPage(chatID, userID, page)
+	if err != nil {
+		_ = cb.Answer(c, 0, true, lang.T(chatID, "likes.fetch_failed", err.Error()), "")
+		return nil
+	}
+
+	_ = cb.Answer(c, 0, false, "", "")
+	_, _ = cb.EditMessageText(c, text, &td.EditTextMessageOpts{ParseMode: "HTML", ReplyMarkup: markup, DisableWebPagePreview: true})
+	return nil
+}
+
+// buildLikesPage renders one page of a user's liked songs with a button to remove each.
+func buildLikesPage(chatID, userID int64, page int) (string, *td.ReplyMarkupInlineKeyboard, error) {
+	likes, err := db.Instance.GetLikes(userID)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(likes) == 0 {
+		return lang.T(chatID, "likes.none"), core.LikesKeyboard(userID, 0, nil, 0, 1), nil
+	}
+
+	totalPages := (len(likes) + likesPerPage - 1) / likesPerPage
+	page = min(page, totalPages-1)
+	start := page * likesPerPage
+	end := min(start+likesPerPage, len(likes))
+
+	var b strings.Builder
+	tags := make([]string, 0, end-start)
+	b.WriteString(lang.T(chatID, "likes.header", len(likes)))
+	for i, like := range likes[start:end] {
+		tags = append(tags, db.SongTag(like.Song))
+		b.WriteString(lang.T(chatID, "likes.item",
+			start+i+1,
+			html.EscapeString(like.Song.URL),
+			html.EscapeString(like.Song.Name),
+			utils.TrackDuration(like.Song.Duration, false),
+		))
+	}
+	return b.String(), core.LikesKeyboard(userID, start, tags, page, totalPages), nil
+}
+
+// playLikesHandler handles /playlikes and queues the sender's liked songs, newest first.
+func playLikesHandler(c *td.Client, ctx *td.Context) error {
+	if !playMode(c, ctx) {
+		return td.EndGroups
+	}
+
+	m := ctx.EffectiveMessage
+	chatID := m.ChatId
+	if queueLen := cache.ChatCache.GetQueueLength(chatID); queueLen > 10 {
+		_, _ = m.ReplyText(c, lang.T(chatID, "play.queue_full"), nil)
+		return td.EndGroups
+	}
+
+	likes, err := db.Instance.GetLikes(m.SenderID())
+	if err != nil {
+		_, err = m.ReplyText(c, lang.T(chatID, "likes.fetch_failed", err.Error()), nil)
+		return err
+	}
+	tracks := db.ConvertSongsToTracks(db.LikedSongs(likes))
+	if len(tracks) == 0 {
+		_, err = m.ReplyText(c, lang.T(chatID, "likes.none"), nil)
+		return err
+	}
+
+	updater, err := m.ReplyText(c, lang.T(chatID, "play.searching_playlist"), nil)
+	if err != nil {
+		c.Logger.Warn("failed to send message", "error", err)
+		return td.EndGroups
+	}
+	return handleMultipleTracks(c, m, updater, tracks, chatID, false, utils.VideoQuality{})
+}
+
+// topLikesHandler handles /toplikes and lists the songs with the most likes across all users.
+func topLikesHandler(c *td.Client, ctx *td.Context) error {
+	m := ctx.EffectiveMessage
+	chatID := m.ChatId
+
+	top, err := db.Instance.GetTopLiked(topLikesSize)
+	if err != nil {
+		_, err = m.ReplyText(c, lang.T(chatID, "likes.fetch_failed", err.Error()), nil)
+		return err
+	}
+	if len(top) == 0 {
+		_, err = m.ReplyText(c, lang.T(chatID, "likes.top_none"), nil)
+		return err
+	}
+
+	var b strings.Builder
+	b.WriteString(lang.T(chatID, "likes.top_header"))
+	for i, t := range top {
+		b.WriteString(lang.T(chatID, "likes.top_item", i+1, html.EscapeString(t.Song.URL), html.EscapeString(t.Song.Name), t.Count))
+	}
+	_, err = m.ReplyText(c, b.String(), replyOpts)
+	return err
+}
+
+func songFromCached(track *utils.CachedTrack) db.Song {
+	return db.Song{
+		URL:      track.URL,
+		Name:     track.Name,
+		TrackID:  track.TrackID,
+		Duration: track.Duration,
+		Platform: track.Platform,
+	}
+}
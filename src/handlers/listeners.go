@@ -0,0 +1,140 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package handlers
+
+import (
+	"ashokshau/tgmusic/src/core"
+	"ashokshau/tgmusic/src/core/lang"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	"ashokshau/tgmusic/src/core/cache"
+	"ashokshau/tgmusic/src/vc"
+
+	td "github.com/AshokShau/gotdbot"
+)
+
+const listenersPerPage = 10
+
+// listenersHandler shows the participants of the current voice chat. Anyone in the chat can see who is in
+// its voice chat, so it is open to every user regardless of admin mode.
+func listenersHandler(c *td.Client, ctx *td.Context) error {
+	m := ctx.EffectiveMessage
+	chatID := ctx.EffectiveChatId
+
+	if !cache.ChatCache.IsActive(chatID) {
+		_, err := m.ReplyText(c, lang.T(chatID, "playback.not_streaming"), nil)
+		return err
+	}
+
+	text, markup, err := buildListenersPage(c, chatID, 0)
+	if err != nil {
+		_, err = m.ReplyText(c, lang.T(chatID, "listeners.failed", html.EscapeString(err.Error())), replyOpts)
+		return err
+	}
+
+	_, err = m.ReplyText(c, text, &td.SendTextMessageOpts{ParseMode: "HTML", DisableWebPagePreview: true, ReplyMarkup: markup})
+	return err
+}
+
+// listenersCallbackHandler handles page navigation for the /listeners panel.
+func listenersCallbackHandler(c *td.Client, ctx *td.Context) error {
+	cb := ctx.Update.UpdateNewCallbackQuery
+	page, err := strconv.Atoi(strings.TrimPrefix(cb.DataString(), "listeners_"))
+	if err != nil || page < 0 {
+		_ = cb.Answer(c, 0, false, lang.T(cb.ChatId, "common.invalid_page"), "")
+		return nil
+	}
+
+	text, markup, err := buildListenersPage(c, cb.ChatId, page)
+	if err != nil {
+		_ = cb.Answer(c, 0, true, lang.T(cb.ChatId, "listeners.failed_short"), "")
+		return nil
+	}
+
+	_ = cb.Answer(c, 0, false, "", "")
+	_, _ = cb.EditMessageText(c, text, &td.EditTextMessageOpts{ParseMode: "HTML", ReplyMarkup: markup, DisableWebPagePreview: true})
+	return nil
+}
+
+// buildListenersPage renders one page of the listeners list along with its navigation keyboard.
+func buildListenersPage(c *td.Client, chatID int64, page int) (string, *td.ReplyMarkupInlineKeyboard, error) {
+	listeners, err := vc.Calls.GetListeners(chatID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	totalPages := (len(listeners) + listenersPerPage - 1) / listenersPerPage
+	if page >= totalPages {
+		page = max(totalPages-1, 0)
+	}
+
+	var b strings.Builder
+	b.WriteString(lang.T(chatID, "listeners.header", len(listeners)))
+	if len(listeners) == 0 {
+		b.WriteString(lang.T(chatID, "listeners.empty"))
+		return b.String(), core.PaginationKeyboard("listeners_", 0, 1), nil
+	}
+
+	start := page * listenersPerPage
+	end := min(start+listenersPerPage, len(listeners))
+	for i, l := range listeners[start:end] {
+		status := "🎙"
+		if l.Muted {
+			status = "🔇"
+		}
+		if l.Speaking {
+			status += " 🗣"
+		}
+		if l.Camera {
+			status += " 📹"
+		}
+		if l.Screen {
+			status += " 🖥"
+		}
+		if l.Assistant {
+			status += " 🤖"
+		}
+
+		b.WriteString(lang.T(chatID, "listeners.item",
+			start+i+1,
+			html.EscapeString(truncate(peerName(c, l.ID), 30)),
+			status,
+			getFormattedDuration(time.Since(l.JoinedAt)),
+		))
+	}
+
+	return b.String(), core.PaginationKeyboard("listeners_", page, totalPages), nil
+}
+
+// peerName resolves a user or chat ID to a display name through the bot client.
+func peerName(c *td.Client, id int64) string {
+	if id > 0 {
+		if user, err := c.GetUser(id); err == nil {
+			return user.FirstName
+		}
+		return "Unknown"
+	}
+
+	if chat, err := c.GetChat(id); err == nil {
+		return chat.Title
+	}
+	return "Unknown"
+}
+
+// listenersLine returns the now-playing listener count line, or an empty string when it is unknown.
+func listenersLine(chatID int64) string {
+	count := vc.Calls.ListenerCount(chatID)
+	if count == 0 {
+		return ""
+	}
+	return lang.T(chatID, "listeners.line", count)
+}
@@ -22,6 +22,10 @@ var startTime = time.Now()
 // LoadModules loads all the handlers.
 // It takes a telegram gotdbot.Dispatcher as input.
 func LoadModules(d *gotdbot.Dispatcher) {
+	// Blacklist enforcement runs in an earlier group so it sees every update before the command handlers.
+	d.AddHandlerToGroup(handlers.NewUpdateNewMessage(nil, blacklistWatcher), -1)
+	d.AddHandlerToGroup(handlers.NewUpdateNewCallbackQuery(nil, blacklistCallbackWatcher), -1)
+
 	d.AddHandler(handlers.NewCommand("reload", reloadAdminCacheHandler))
 	d.AddHandler(handlers.NewCommand("authList", authListHandler))
 	d.AddHandler(handlers.NewCommand("auths", authListHandler))
@@ -72,15 +76,52 @@ func LoadModules(d *gotdbot.Dispatcher) {
 	d.AddHandler(handlers.NewCommand("playlistinfo", playlistInfoHandler))
 	d.AddHandler(handlers.NewCommand("myplaylists", myPlaylistsHandler))
 	d.AddHandler(handlers.NewCommand("myplist", myPlaylistsHandler))
+	d.AddHandler(handlers.NewCommand("renameplaylist", renamePlaylistHandler))
+	d.AddHandler(handlers.NewCommand("rnplist", renamePlaylistHandler))
+	d.AddHandler(handlers.NewCommand("moveplaylist", movePlaylistSongHandler))
+	d.AddHandler(handlers.NewCommand("mvplist", movePlaylistSongHandler))
+	d.AddHandler(handlers.NewCommand("dedupeplaylist", dedupePlaylistHandler))
+	d.AddHandler(handlers.NewCommand("importplaylist", importPlaylistHandler))
+	d.AddHandler(handlers.NewCommand("exportplaylist", exportPlaylistHandler))
+	d.AddHandler(handlers.NewCommand("creategroupplaylist", createGroupPlaylistHandler))
+	d.AddHandler(handlers.NewCommand("playlistvisibility", playlistVisibilityHandler))
+	d.AddHandler(handlers.NewCommand("collab", collaboratorHandler))
+	d.AddHandler(handlers.NewCommand("playlists", playlistsHandler))
+	d.AddHandler(handlers.NewCommand("likes", likesHandler))
+	d.AddHandler(handlers.NewCommand("playlikes", playLikesHandler))
+	d.AddHandler(handlers.NewCommand("toplikes", topLikesHandler))
 	d.AddHandler(handlers.NewCommand("stats", statsHandler))
+	d.AddHandler(handlers.NewCommand("listeners", listenersHandler))
+	d.AddHandler(handlers.NewCommand("lang", langHandler))
+	d.AddHandler(handlers.NewCommand("language", langHandler))
+	d.AddHandler(handlers.NewCommand("blacklist", blacklistHandler))
+	d.AddHandler(handlers.NewCommand("unblacklist", unblacklistHandler))
+	d.AddHandler(handlers.NewCommand("blacklisted", blacklistedHandler))
+	d.AddHandler(handlers.NewCommand("addsudo", addSudoHandler))
+	d.AddHandler(handlers.NewCommand("rmsudo", removeSudoHandler))
+	d.AddHandler(handlers.NewCommand("delsudo", removeSudoHandler))
+	d.AddHandler(handlers.NewCommand("sudolist", sudoListHandler))
+	d.AddHandler(handlers.NewCommand("sudoers", sudoListHandler))
+	d.AddHandler(handlers.NewCommand("config", configHandler))
+	d.AddHandler(handlers.NewCommand("assistants", assistantsHandler))
+	d.AddHandler(handlers.NewCommand("rebalance", rebalanceHandler))
+	d.AddHandler(handlers.NewCommand("backup", backupHandler))
+	d.AddHandler(handlers.NewCommand("restore", restoreHandler))
 
 	d.AddHandler(handlers.NewUpdateNewCallbackQuery(callbackquery.Prefix("help_"), helpCallbackHandler))
 	d.AddHandler(handlers.NewUpdateNewCallbackQuery(callbackquery.Prefix("play_"), playCallbackHandler))
 	d.AddHandler(handlers.NewUpdateNewCallbackQuery(callbackquery.Prefix("vcplay_"), vcPlayHandler))
 	d.AddHandler(handlers.NewUpdateNewCallbackQuery(callbackquery.Prefix("settings_"), settingsCallbackHandler))
+	d.AddHandler(handlers.NewUpdateNewCallbackQuery(callbackquery.Prefix("listeners_"), listenersCallbackHandler))
+	d.AddHandler(handlers.NewUpdateNewCallbackQuery(callbackquery.Prefix("lang_"), langCallbackHandler))
+	d.AddHandler(handlers.NewUpdateNewCallbackQuery(callbackquery.Prefix("plist_"), playlistCallbackHandler))
+	d.AddHandler(handlers.NewUpdateNewCallbackQuery(callbackquery.Prefix("restore_"), restoreCallbackHandler))
+	d.AddHandler(handlers.NewUpdateNewCallbackQuery(callbackquery.Prefix("np_like"), likeCallbackHandler))
+	d.AddHandler(handlers.NewUpdateNewCallbackQuery(callbackquery.Prefix("likes_"), likesCallbackHandler))
 
 	d.AddHandler(handlers.NewUpdateChatMember(nil, handleParticipant))
 	d.AddHandler(handlers.NewUpdateNewMessage(nil, handleVoiceChatMessage))
+	d.AddHandler(handlers.NewUpdateMessageReaction(nil, likeReactionHandler))
 
 	slog.Debug("Handlers loaded successfully")
 }
@@ -9,7 +9,7 @@
 package handlers
 
 import (
-	"fmt"
+	"ashokshau/tgmusic/src/core/lang"
 	"strconv"
 
 	"ashokshau/tgmusic/src/core/cache"
@@ -26,36 +26,34 @@ func loopHandler(c *td.Client, ctx *td.Context) error {
 	chatID := m.ChatId
 
 	if !cache.ChatCache.IsActive(chatID) {
-		_, err := m.ReplyText(c, "There is no active playback in the video chat.", nil)
+		_, err := m.ReplyText(c, lang.T(chatID, "playback.not_active"), nil)
 		return err
 	}
 
 	args := Args(m)
 	if args == "" {
-		_, err := m.ReplyText(c, "<b>Loop Control</b>\n\n<b>Usage:</b> <code>/loop [count]</code>\n0 to disable looping\n1-10 to set the number of repeats", &td.SendTextMessageOpts{ParseMode: "HTML"})
+		_, err := m.ReplyText(c, lang.T(chatID, "loop.usage"), &td.SendTextMessageOpts{ParseMode: "HTML"})
 		return err
 	}
 
 	argsInt, err := strconv.Atoi(args)
 	if err != nil {
-		_, _ = m.ReplyText(c, "Invalid loop value. Please provide a number between 0 and 10.", nil)
+		_, _ = m.ReplyText(c, lang.T(chatID, "loop.invalid"), nil)
 		return nil
 	}
 
 	if argsInt < 0 || argsInt > 10 {
-		_, err = m.ReplyText(c, "Loop count must be between 0 and 10.", nil)
+		_, err = m.ReplyText(c, lang.T(chatID, "loop.range"), nil)
 		return err
 	}
 
 	cache.ChatCache.SetLoopCount(chatID, argsInt)
 
-	var action string
+	text := lang.T(chatID, "loop.set", argsInt, firstName(c, m))
 	if argsInt == 0 {
-		action = "Looping has been disabled"
-	} else {
-		action = fmt.Sprintf("Looping has been set to %d time(s)", argsInt)
+		text = lang.T(chatID, "loop.disabled", firstName(c, m))
 	}
 
-	_, err = m.ReplyText(c, fmt.Sprintf("%s.\nChanged by: %s", action, firstName(c, m)), nil)
+	_, err = m.ReplyText(c, text, nil)
 	return err
 }
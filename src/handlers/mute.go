@@ -9,7 +9,7 @@
 package handlers
 
 import (
-	"fmt"
+	"ashokshau/tgmusic/src/core/lang"
 
 	"ashokshau/tgmusic/src/core"
 	"ashokshau/tgmusic/src/core/cache"
@@ -30,16 +30,16 @@ func muteHandler(c *td.Client, ctx *td.Context) error {
 
 	chatID := m.ChatId
 	if !cache.ChatCache.IsActive(chatID) {
-		_, err := m.ReplyText(c, "There is no active playback in the video chat.", nil)
+		_, err := m.ReplyText(c, lang.T(chatID, "playback.not_active"), nil)
 		return err
 	}
 
 	if _, err := vc.Calls.Mute(chatID); err != nil {
-		_, err = m.ReplyText(c, fmt.Sprintf("Failed to mute the playback: %s", err.Error()), nil)
+		_, err = m.ReplyText(c, lang.T(chatID, "mute.failed", err.Error()), nil)
 		return err
 	}
 
-	_, err := m.ReplyText(c, fmt.Sprintf("Playback has been muted by %s.", firstName(c, m)), &td.SendTextMessageOpts{ReplyMarkup: core.ControlButtons("mute")})
+	_, err := m.ReplyText(c, lang.T(chatID, "mute.done", firstName(c, m)), &td.SendTextMessageOpts{ReplyMarkup: core.ControlButtons("mute")})
 	return err
 }
 
@@ -55,15 +55,15 @@ func unmuteHandler(c *td.Client, ctx *td.Context) error {
 
 	chatID := m.ChatId
 	if !cache.ChatCache.IsActive(chatID) {
-		_, err := m.ReplyText(c, "There is no active playback in the video chat.", nil)
+		_, err := m.ReplyText(c, lang.T(chatID, "playback.not_active"), nil)
 		return err
 	}
 
 	if _, err := vc.Calls.Unmute(chatID); err != nil {
-		_, err = m.ReplyText(c, fmt.Sprintf("Failed to unmute the playback: %s", err.Error()), nil)
+		_, err = m.ReplyText(c, lang.T(chatID, "unmute.failed", err.Error()), nil)
 		return err
 	}
 
-	_, err := m.ReplyText(c, fmt.Sprintf("Playback has been unmuted by %s.", firstName(c, m)), &td.SendTextMessageOpts{ReplyMarkup: core.ControlButtons("unmute")})
+	_, err := m.ReplyText(c, lang.T(chatID, "unmute.done", firstName(c, m)), &td.SendTextMessageOpts{ReplyMarkup: core.ControlButtons("unmute")})
 	return err
 }
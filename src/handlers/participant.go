@@ -11,6 +11,7 @@ import (
 	"ashokshau/tgmusic/config"
 	"ashokshau/tgmusic/src/core/cache"
 	"ashokshau/tgmusic/src/core/db"
+	"ashokshau/tgmusic/src/core/lang"
 	"ashokshau/tgmusic/src/vc"
 	"fmt"
 	"log/slog"
@@ -188,6 +189,11 @@ func handleJoin(
 
 	client.Logger.Info("User  joined chat", "user_id", userID, "chat_id", chatID)
 
+	if db.Instance.IsBlacklistedChat(chatID) {
+		leaveBlacklistedChat(client, chatID)
+		return nil
+	}
+
 	if userID == client.Me.Id {
 		client.Logger.Info("Bot joined chat", "chat_id", chatID)
 		sendJoinLog(client, chatID, chat)
@@ -244,11 +250,7 @@ func handleBan(client *gotdbot.Client, chatID, userID, ubID int64) error {
 
 		cache.ChatCache.ClearChat(chatID)
 
-		message := fmt.Sprintf(
-			"🚫 My assistant has been banned from this chat.\n\n"+
-				"If this was a mistake please unban <code>%d</code>.",
-			ubID,
-		)
+		message := lang.T(chatID, "assistant.banned", ubID)
 
 		_, err := client.SendTextMessage(
 			chatID,
@@ -9,10 +9,9 @@
 package handlers
 
 import (
-	"fmt"
-
 	"ashokshau/tgmusic/src/core"
 	"ashokshau/tgmusic/src/core/cache"
+	"ashokshau/tgmusic/src/core/lang"
 	"ashokshau/tgmusic/src/vc"
 
 	td "github.com/AshokShau/gotdbot"
@@ -27,16 +26,16 @@ func pauseHandler(c *td.Client, ctx *td.Context) error {
 	chatID := m.ChatId
 
 	if !cache.ChatCache.IsActive(chatID) {
-		_, _ = m.ReplyText(c, "There is no active playback in the video chat.", nil)
+		_, _ = m.ReplyText(c, lang.T(chatID, "playback.not_active"), nil)
 		return nil
 	}
 
 	if _, err := vc.Calls.Pause(chatID); err != nil {
-		_, _ = m.ReplyText(c, fmt.Sprintf("Failed to pause the playback: %s", err.Error()), nil)
+		_, _ = m.ReplyText(c, lang.T(chatID, "pause.failed", err.Error()), nil)
 		return nil
 	}
 
-	_, err := m.ReplyText(c, fmt.Sprintf("Playback has been paused by %s.", firstName(c, m)), &td.SendTextMessageOpts{ReplyMarkup: core.ControlButtons("pause")})
+	_, err := m.ReplyText(c, lang.T(chatID, "pause.done", firstName(c, m)), &td.SendTextMessageOpts{ReplyMarkup: core.ControlButtons("pause")})
 	return err
 }
 
@@ -48,20 +47,20 @@ func resumeHandler(c *td.Client, ctx *td.Context) error {
 	chatID := m.ChatId
 
 	if chatID > 0 {
-		_, _ = m.ReplyText(c, "This command can only be used in a supergroup.", nil)
+		_, _ = m.ReplyText(c, lang.T(chatID, "common.supergroup_only"), nil)
 		return nil
 	}
 
 	if !cache.ChatCache.IsActive(chatID) {
-		_, _ = m.ReplyText(c, "There is no active playback in the video chat.", nil)
+		_, _ = m.ReplyText(c, lang.T(chatID, "playback.not_active"), nil)
 		return nil
 	}
 
 	if _, err := vc.Calls.Resume(chatID); err != nil {
-		_, _ = m.ReplyText(c, fmt.Sprintf("Failed to resume the playback: %s", err.Error()), nil)
+		_, _ = m.ReplyText(c, lang.T(chatID, "resume.failed", err.Error()), nil)
 		return nil
 	}
 
-	_, err := m.ReplyText(c, fmt.Sprintf("Playback has been resumed by %s.", firstName(c, m)), &td.SendTextMessageOpts{ReplyMarkup: core.ControlButtons("resume")})
+	_, err := m.ReplyText(c, lang.T(chatID, "resume.done", firstName(c, m)), &td.SendTextMessageOpts{ReplyMarkup: core.ControlButtons("resume")})
 	return err
 }
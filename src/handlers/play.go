@@ -14,9 +14,10 @@ import (
 	"ashokshau/tgmusic/src/core/cache"
 	"ashokshau/tgmusic/src/core/db"
 	"ashokshau/tgmusic/src/core/dl"
+	"ashokshau/tgmusic/src/core/lang"
 	"ashokshau/tgmusic/src/vc"
-	"fmt"
 	"html"
+	"strconv"
 	"strings"
 
 	"ashokshau/tgmusic/src/utils"
@@ -46,12 +47,22 @@ func handlePlay(c *td.Client, ctx *td.Context, isVideo bool) error {
 	m := ctx.EffectiveMessage
 
 	if queueLen := cache.ChatCache.GetQueueLength(chatID); queueLen > 10 {
-		_, _ = m.ReplyText(c, "Queue is full (max 10 tracks). Use /end to clear.", nil)
+		_, _ = m.ReplyText(c, lang.T(chatID, "play.queue_full"), nil)
 		return td.EndGroups
 	}
 
 	isReply := m.ReplyToMessageID() != 0
 	args := Args(m)
+
+	var quality utils.VideoQuality
+	if isVideo {
+		var ok bool
+		args, quality, ok = parseQualityFlag(args, chatID)
+		if !ok {
+			_, err := m.ReplyText(c, lang.T(chatID, "play.invalid_quality", joinInts(utils.VideoHeights, "|")), nil)
+			return err
+		}
+	}
 	url := getUrl(c, m, isReply)
 
 	rMsg := m
@@ -66,38 +77,37 @@ func handlePlay(c *td.Client, ctx *td.Context, isVideo bool) error {
 	input := coalesce(url, args)
 
 	if strings.HasPrefix(input, "tgpl_") {
-		playlist, err := db.Instance.GetPlaylist(input)
-		if err != nil {
-			_, err = m.ReplyText(c, "❌ Playlist not found.", nil)
-			return err
+		playlist, ok := loadPlaylist(c, m, input)
+		if !ok {
+			return td.EndGroups
 		}
 
 		tracks := db.ConvertSongsToTracks(playlist.Songs)
 		if len(tracks) == 0 {
-			_, err = m.ReplyText(c, "❌ Playlist is empty.", nil)
+			_, err = m.ReplyText(c, lang.T(chatID, "playlist.empty"), nil)
 			return err
 		}
 
-		updater, err := m.ReplyText(c, "🔍 Searching playlist...", nil)
+		updater, err := m.ReplyText(c, lang.T(chatID, "play.searching_playlist"), nil)
 		if err != nil {
 			c.Logger.Warn("failed to send message", "error", err)
 			return td.EndGroups
 		}
 
-		return handleMultipleTracks(c, m, updater, tracks, chatID, isVideo)
+		return handleMultipleTracks(c, m, updater, tracks, chatID, isVideo, quality)
 	}
 
 	if match := utils.TelegramMessageRegex.FindStringSubmatch(input); match != nil {
 		rMsg, err = utils.GetMessage(c, input)
 		if err != nil {
 			c.Logger.Warn("failed to parse message", "error", err.Error())
-			_, err = m.ReplyText(c, "Invalid Telegram link.", nil)
+			_, err = m.ReplyText(c, lang.T(chatID, "play.invalid_link"), nil)
 			return err
 		}
 	} else if isReply {
 		rMsg, err = m.GetRepliedMessage(c)
 		if err != nil {
-			_, err = m.ReplyText(c, "Invalid reply message.", nil)
+			_, err = m.ReplyText(c, lang.T(chatID, "play.invalid_reply"), nil)
 			return err
 		}
 	}
@@ -107,54 +117,69 @@ func handlePlay(c *td.Client, ctx *td.Context, isVideo bool) error {
 	}
 
 	if url == "" && args == "" && (!isReply || !isValidMedia(rMsg)) {
-		_, _ = m.ReplyText(c, "<b>Usage:</b>\n/play [song or URL]\n\n<b>Supported Platforms:</b>\n- YouTube\n- Spotify\n- JioSaavn\n- Apple Music", &td.SendTextMessageOpts{ReplyMarkup: core.SupportKeyboard(), ParseMode: "HTML"})
+		_, _ = m.ReplyText(c, lang.T(chatID, "play.usage"), &td.SendTextMessageOpts{ReplyMarkup: core.SupportKeyboard(), ParseMode: "HTML"})
 		return td.EndGroups
 	}
 
-	updater, err := m.ReplyText(c, "🔍 Searching and downloading...", nil)
+	updater, err := m.ReplyText(c, lang.T(chatID, "play.searching"), nil)
 	if err != nil {
 		c.Logger.Warn("failed to send message", "error", err)
 		return td.EndGroups
 	}
 
 	if isReply && isValidMedia(rMsg) {
-		return handleMedia(c, m, updater, rMsg, chatID, isVideo)
+		return handleMedia(c, m, updater, rMsg, chatID, isVideo, quality)
 	}
 
 	wrapper := dl.NewDownloaderWrapper(input)
 	if url != "" {
 		if !wrapper.IsValid() {
-			_, _ = updater.EditText(c, "Invalid URL or unsupported platform.\n\n<b>Supported Platforms:</b>\n- YouTube\n- Spotify\n- JioSaavn\n- Apple Music", &td.EditTextMessageOpts{ReplyMarkup: core.SupportKeyboard(), ParseMode: "HTML"})
+			_, _ = updater.EditText(c, lang.T(chatID, "play.invalid_url"), &td.EditTextMessageOpts{ReplyMarkup: core.SupportKeyboard(), ParseMode: "HTML"})
 			return td.EndGroups
 		}
 
 		trackInfo, err := wrapper.GetInfo()
 		if err != nil {
-			_, _ = updater.EditText(c, fmt.Sprintf("❌ Error fetching track info: %s", err.Error()), nil)
+			_, _ = updater.EditText(c, lang.T(chatID, "play.info_failed", err.Error()), nil)
 			return td.EndGroups
 		}
 
 		if trackInfo.Results == nil || len(trackInfo.Results) == 0 {
-			_, _ = updater.EditText(c, "No tracks found.", nil)
+			_, _ = updater.EditText(c, lang.T(chatID, "play.no_tracks"), nil)
 			return td.EndGroups
 		}
 
-		return handleUrl(c, m, updater, trackInfo, chatID, isVideo)
+		return handleUrl(c, m, updater, trackInfo, chatID, isVideo, quality)
 	}
 
-	return handleTextSearch(c, m, updater, wrapper, chatID, isVideo)
+	return handleTextSearch(c, m, updater, wrapper, chatID, isVideo, quality)
+}
+
+// parseQualityFlag strips a leading "-q <height>" from args and returns the video quality to use.
+// Without the flag the chat's profile applies; ok is false when the height is not supported.
+func parseQualityFlag(args string, chatID int64) (string, utils.VideoQuality, bool) {
+	return utils.ParseQualityFlag(args, db.Instance.GetVideoQuality(chatID))
+}
+
+// joinInts formats a list of integers separated by sep.
+func joinInts(values []int, sep string) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.Itoa(v)
+	}
+	return strings.Join(parts, sep)
 }
 
 // handleMedia handles playing media from a message.
-func handleMedia(c *td.Client, m *td.Message, updater *td.Message, dlMsg *td.Message, chatId int64, isVideo bool) error {
+func handleMedia(c *td.Client, m *td.Message, updater *td.Message, dlMsg *td.Message, chatId int64, isVideo bool, quality utils.VideoQuality) error {
 	file, fileName := getFile(dlMsg)
 	if file == nil {
-		_, err := updater.EditText(c, "No valid media found in the message.", nil)
+		_, err := updater.EditText(c, lang.T(chatId, "play.no_media"), nil)
 		return err
 	}
 
-	if file.Size > config.Conf.MaxFileSize {
-		_, err := updater.EditText(c, fmt.Sprintf("File too large. Max size: %d MB.", config.Conf.MaxFileSize/(1024*1024)), nil)
+	if maxSize := config.Conf.MaxFileSize(); file.Size > maxSize {
+		_, err := updater.EditText(c, lang.T(chatId, "play.file_too_large", maxSize/(1024*1024)), nil)
 		if err != nil {
 			c.Logger.Warn("Edit message failed", "error", err)
 		}
@@ -163,7 +188,7 @@ func handleMedia(c *td.Client, m *td.Message, updater *td.Message, dlMsg *td.Mes
 
 	fileId := dlMsg.RemoteFileID()
 	if _track := cache.ChatCache.GetTrackIfExists(chatId, fileId); _track != nil {
-		_, err := updater.EditText(c, "Track already in queue or playing.", nil)
+		_, err := updater.EditText(c, lang.T(chatId, "play.already_queued"), nil)
 		return err
 	}
 
@@ -176,7 +201,7 @@ func handleMedia(c *td.Client, m *td.Message, updater *td.Message, dlMsg *td.Mes
 
 	saveCache := utils.CachedTrack{
 		URL: link.Link, Name: fileName, User: firstName(c, m), TrackID: fileId,
-		Duration: dur, IsVideo: isVideo, Platform: utils.Telegram,
+		Duration: dur, IsVideo: isVideo, Quality: quality, Platform: utils.Telegram,
 	}
 
 	qLen := cache.ChatCache.AddSong(chatId, &saveCache)
@@ -184,18 +209,20 @@ func handleMedia(c *td.Client, m *td.Message, updater *td.Message, dlMsg *td.Mes
 		escURL := html.EscapeString(saveCache.URL)
 		escName := html.EscapeString(saveCache.Name)
 		escUser := html.EscapeString(saveCache.User)
-		queueInfo := fmt.Sprintf(
-			"<u><b>Added to queue: %d</b></u>\n\n<b>Title:</b> <a href='%s'>%s</a>\n\n<b>Duration:</b> %s min\n<b>Requested by:</b> %s",
-			qLen, escURL, escName, utils.SecToMin(saveCache.Duration), escUser,
+		queueInfo := lang.T(chatId, "np.queued",
+			qLen, escURL, escName, utils.TrackDuration(saveCache.Duration, saveCache.IsLive), escUser,
 		)
 		_, err := updater.EditText(c, queueInfo, &td.EditTextMessageOpts{ReplyMarkup: core.ControlButtons("play"), ParseMode: "HTML", DisableWebPagePreview: true})
+		if err == nil {
+			core.SetPanelTrack(updater.ChatId, updater.Id, &saveCache)
+		}
 		return err
 	}
 
 	file, err = dlMsg.Download(c, 1, 0, 0, true)
 	if err != nil {
 		cache.ChatCache.RemoveCurrentSong(chatId)
-		_, err = updater.EditText(c, fmt.Sprintf("Download failed: %s", err.Error()), nil)
+		_, err = updater.EditText(c, lang.T(chatId, "play.download_failed", err.Error()), nil)
 		return err
 	}
 
@@ -217,67 +244,60 @@ func handleMedia(c *td.Client, m *td.Message, updater *td.Message, dlMsg *td.Mes
 	escName := html.EscapeString(saveCache.Name)
 	escUser := html.EscapeString(saveCache.User)
 
-	nowPlaying := fmt.Sprintf(
-		"<u><b>| Started streaming</b></u>\n\n<b>Title:</b> <a href='%s'>%s</a>\n\n<b>Duration:</b> %s min\n<b>Requested by:</b> %s",
-		escURL, escName, utils.SecToMin(saveCache.Duration), escUser,
-	)
-
-	_, err = updater.EditText(c, nowPlaying, &td.EditTextMessageOpts{
-		ParseMode:             "HTML",
-		ReplyMarkup:           core.ControlButtons("play"),
-		DisableWebPagePreview: true,
-	})
+	nowPlaying := lang.T(chatId, "np.started",
+		escURL, escName, utils.TrackDuration(saveCache.Duration, saveCache.IsLive), escUser,
+	) + listenersLine(chatId)
 
-	return err
+	return core.SendNowPlaying(c, updater, &saveCache, nowPlaying)
 }
 
 // handleTextSearch handles a text search for a song.
-func handleTextSearch(c *td.Client, m *td.Message, updater *td.Message, wrapper *dl.DownloaderWrapper, chatId int64, isVideo bool) error {
+func handleTextSearch(c *td.Client, m *td.Message, updater *td.Message, wrapper *dl.DownloaderWrapper, chatId int64, isVideo bool, quality utils.VideoQuality) error {
 	searchResult, err := wrapper.Search()
 	if err != nil {
-		_, err = updater.EditText(c, fmt.Sprintf("❌ Search failed: %s", err.Error()), nil)
+		_, err = updater.EditText(c, lang.T(chatId, "play.search_failed", err.Error()), nil)
 		return err
 	}
 
 	if searchResult.Results == nil || len(searchResult.Results) == 0 {
-		_, err = updater.EditText(c, "😕 No results found. Try a different query.", nil)
+		_, err = updater.EditText(c, lang.T(chatId, "play.no_results"), nil)
 		return err
 	}
 
 	song := searchResult.Results[0]
 	if _track := cache.ChatCache.GetTrackIfExists(chatId, song.Id); _track != nil {
-		_, err := updater.EditText(c, "Track already in queue or playing.", nil)
+		_, err := updater.EditText(c, lang.T(chatId, "play.already_queued"), nil)
 		return err
 	}
 
-	return handleSingleTrack(c, m, updater, song, "", chatId, isVideo)
+	return handleSingleTrack(c, m, updater, song, "", chatId, isVideo, quality)
 }
 
 // handleUrl handles a URL search for a song.
-func handleUrl(c *td.Client, m *td.Message, updater *td.Message, trackInfo utils.PlatformTracks, chatId int64, isVideo bool) error {
+func handleUrl(c *td.Client, m *td.Message, updater *td.Message, trackInfo utils.PlatformTracks, chatId int64, isVideo bool, quality utils.VideoQuality) error {
 	if len(trackInfo.Results) == 1 {
 		track := trackInfo.Results[0]
 		if _track := cache.ChatCache.GetTrackIfExists(chatId, track.Id); _track != nil {
-			_, err := updater.EditText(c, "Track already in queue or playing.", nil)
+			_, err := updater.EditText(c, lang.T(chatId, "play.already_queued"), nil)
 			return err
 		}
-		return handleSingleTrack(c, m, updater, track, "", chatId, isVideo)
+		return handleSingleTrack(c, m, updater, track, "", chatId, isVideo, quality)
 	}
 
-	return handleMultipleTracks(c, m, updater, trackInfo.Results, chatId, isVideo)
+	return handleMultipleTracks(c, m, updater, trackInfo.Results, chatId, isVideo, quality)
 }
 
 // handleSingleTrack handles a single track.
-func handleSingleTrack(c *td.Client, m *td.Message, updater *td.Message, song utils.MusicTrack, filePath string, chatId int64, isVideo bool) error {
-	if song.Duration > int(config.Conf.SongDurationLimit) {
-		_, err := updater.EditText(c, fmt.Sprintf("Sorry, song exceeds max duration of %d minutes.", config.Conf.SongDurationLimit/60), nil)
+func handleSingleTrack(c *td.Client, m *td.Message, updater *td.Message, song utils.MusicTrack, filePath string, chatId int64, isVideo bool, quality utils.VideoQuality) error {
+	if limit := config.Conf.SongDurationLimit(); !song.IsLive && song.Duration > int(limit) {
+		_, err := updater.EditText(c, lang.T(chatId, "play.too_long", limit/60), nil)
 		return err
 	}
 
 	saveCache := utils.CachedTrack{
 		URL: song.Url, Name: song.Title, User: firstName(c, m), FilePath: filePath,
 		Thumbnail: song.Thumbnail, TrackID: song.Id, Duration: song.Duration, Channel: song.Channel, Views: song.Views,
-		IsVideo: isVideo, Platform: song.Platform,
+		IsVideo: isVideo, IsLive: song.IsLive, Quality: quality, Platform: song.Platform,
 	}
 
 	qLen := cache.ChatCache.AddSong(chatId, &saveCache)
@@ -285,12 +305,14 @@ func handleSingleTrack(c *td.Client, m *td.Message, updater *td.Message, song ut
 		escURL := html.EscapeString(saveCache.URL)
 		escName := html.EscapeString(saveCache.Name)
 		escUser := html.EscapeString(saveCache.User)
-		queueInfo := fmt.Sprintf(
-			"<u><b>Added to queue: %d</b></u>\n\n<b>Title:</b> <a href='%s'>%s</a>\n\n<b>Duration:</b> %s min\n<b>Requested by:</b> %s",
-			qLen, escURL, escName, utils.SecToMin(saveCache.Duration), escUser,
+		queueInfo := lang.T(chatId, "np.queued",
+			qLen, escURL, escName, utils.TrackDuration(saveCache.Duration, saveCache.IsLive), escUser,
 		)
 
 		_, err := updater.EditText(c, queueInfo, &td.EditTextMessageOpts{ReplyMarkup: core.ControlButtons("play"), ParseMode: "HTML", DisableWebPagePreview: true})
+		if err == nil {
+			core.SetPanelTrack(updater.ChatId, updater.Id, &saveCache)
+		}
 		return err
 	}
 
@@ -298,7 +320,7 @@ func handleSingleTrack(c *td.Client, m *td.Message, updater *td.Message, song ut
 		dlResult, err := dl.DownloadCachedTrack(&saveCache, c)
 		if err != nil {
 			cache.ChatCache.RemoveCurrentSong(chatId)
-			_, err = updater.EditText(c, fmt.Sprintf("Download failed: %s", err.Error()), nil)
+			_, err = updater.EditText(c, lang.T(chatId, "play.download_failed", err.Error()), nil)
 			return err
 		}
 
@@ -315,18 +337,11 @@ func handleSingleTrack(c *td.Client, m *td.Message, updater *td.Message, song ut
 	escNamenp := html.EscapeString(saveCache.Name)
 	escUsernp := html.EscapeString(saveCache.User)
 
-	nowPlaying := fmt.Sprintf(
-		"<u><b>| Started streaming</b></u>\n\n<b>Title:</b> <a href='%s'>%s</a>\n\n<b>Duration:</b> %s min\n<b>Requested by:</b> %s",
-		escURLnp, escNamenp, utils.SecToMin(song.Duration), escUsernp,
-	)
-
-	_, err := updater.EditText(c, nowPlaying, &td.EditTextMessageOpts{
-		ReplyMarkup:           core.ControlButtons("play"),
-		ParseMode:             "HTML",
-		DisableWebPagePreview: true,
-	})
+	nowPlaying := lang.T(chatId, "np.started",
+		escURLnp, escNamenp, utils.TrackDuration(song.Duration, song.IsLive), escUsernp,
+	) + listenersLine(chatId)
 
-	if err != nil {
+	if err := core.SendNowPlaying(c, updater, &saveCache, nowPlaying); err != nil {
 		c.Logger.Warn("Edit message failed", "error", err)
 		return err
 	}
@@ -335,21 +350,22 @@ func handleSingleTrack(c *td.Client, m *td.Message, updater *td.Message, song ut
 }
 
 // handleMultipleTracks handles multiple tracks.
-func handleMultipleTracks(c *td.Client, m *td.Message, updater *td.Message, tracks []utils.MusicTrack, chatId int64, isVideo bool) error {
+func handleMultipleTracks(c *td.Client, m *td.Message, updater *td.Message, tracks []utils.MusicTrack, chatId int64, isVideo bool, quality utils.VideoQuality) error {
 	if len(tracks) == 0 {
-		_, err := updater.EditText(c, "No tracks found.", nil)
+		_, err := updater.EditText(c, lang.T(chatId, "play.no_tracks"), nil)
 		return err
 	}
 
-	queueHeader := "<u><b>Added to Queue:</b></u>\n<blockquote expandable>\n"
+	queueHeader := lang.T(chatId, "play.batch_header") + "\n<blockquote expandable>\n"
 	var tracksToAdd []*utils.CachedTrack
 	var skippedTracks []string
 
 	shouldPlayFirst := false
 	var firstTrack *utils.CachedTrack
 
+	limit := config.Conf.SongDurationLimit()
 	for _, track := range tracks {
-		if track.Duration > int(config.Conf.SongDurationLimit) {
+		if !track.IsLive && track.Duration > int(limit) {
 			skippedTracks = append(skippedTracks, track.Title)
 			continue
 		}
@@ -357,17 +373,17 @@ func handleMultipleTracks(c *td.Client, m *td.Message, updater *td.Message, trac
 		saveCache := &utils.CachedTrack{
 			Name: track.Title, TrackID: track.Id, Duration: track.Duration,
 			Thumbnail: track.Thumbnail, User: firstName(c, m), Platform: track.Platform,
-			IsVideo: isVideo, URL: track.Url, Channel: track.Channel, Views: track.Views,
+			IsVideo: isVideo, IsLive: track.IsLive, Quality: quality, URL: track.Url, Channel: track.Channel, Views: track.Views,
 		}
 		tracksToAdd = append(tracksToAdd, saveCache)
 	}
 
 	if len(tracksToAdd) == 0 {
 		if len(skippedTracks) > 0 {
-			_, err := updater.EditText(c, fmt.Sprintf("All tracks were skipped (max duration %d min).", config.Conf.SongDurationLimit/60), nil)
+			_, err := updater.EditText(c, lang.T(chatId, "play.all_skipped", limit/60), nil)
 			return err
 		}
-		_, err := updater.EditText(c, "No valid tracks found.", nil)
+		_, err := updater.EditText(c, lang.T(chatId, "play.no_valid_tracks"), nil)
 		return err
 	}
 
@@ -387,21 +403,17 @@ func handleMultipleTracks(c *td.Client, m *td.Message, updater *td.Message, trac
 	for i, track := range tracksToAdd {
 		currentQLen := startLen + i + 1
 		escTrackName := html.EscapeString(track.Name)
-		fmt.Fprintf(&sb, "<b>%d.</b> %s\n└ Duration: %s\n",
-			currentQLen, escTrackName, utils.SecToMin(track.Duration))
+		sb.WriteString(lang.T(chatId, "play.batch_item", currentQLen, escTrackName, utils.TrackDuration(track.Duration, track.IsLive)))
 		totalDuration += track.Duration
 	}
 
 	sb.WriteString("</blockquote>")
 	escRequester := html.EscapeString(firstName(c, m))
-	queueSummary := fmt.Sprintf(
-		"\n<b>Queue Total:</b> %d\n<b>Duration:</b> %s min\n<b>Requested by:</b> %s",
-		qLenAfter, utils.SecToMin(totalDuration), escRequester,
-	)
+	queueSummary := lang.T(chatId, "play.batch_summary", qLenAfter, utils.SecToMin(totalDuration), escRequester)
 
 	sb.WriteString(queueSummary)
 	if len(skippedTracks) > 0 {
-		fmt.Fprintf(&sb, "\n\n<b>Skipped %d tracks</b> (exceeded duration limit).", len(skippedTracks))
+		sb.WriteString(lang.T(chatId, "play.batch_skipped", len(skippedTracks)))
 	}
 
 	fullMessage := sb.String()
@@ -416,7 +428,7 @@ func handleMultipleTracks(c *td.Client, m *td.Message, updater *td.Message, trac
 
 	_, err := updater.EditText(c, fullMessage, &td.EditTextMessageOpts{
 		ParseMode:             "HTML",
-		ReplyMarkup:           core.ControlButtons("play"),
+		ReplyMarkup:           core.ControlButtons("queue"),
 		DisableWebPagePreview: true,
 	})
 
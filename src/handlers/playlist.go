@@ -9,7 +9,9 @@
 package handlers
 
 import (
-	"fmt"
+	"ashokshau/tgmusic/src/core/lang"
+	"errors"
+	"html"
 	"strconv"
 	"strings"
 
@@ -25,18 +27,18 @@ func createPlaylistHandler(c *td.Client, ctx *td.Context) error {
 
 	args := Args(m)
 	if args == "" {
-		_, err := m.ReplyText(c, "<b>Usage:</b> /createplaylist [playlist name]", replyOpts)
+		_, err := m.ReplyText(c, lang.T(m.ChatId, "playlist.create_usage"), replyOpts)
 		return err
 	}
 
 	userPlaylists, err := db.Instance.GetUserPlaylists(userID)
 	if err != nil {
-		_, err = m.ReplyText(c, "Unable to fetch your playlists. Please try again later.", nil)
+		_, err = m.ReplyText(c, lang.T(m.ChatId, "playlist.fetch_failed"), nil)
 		return err
 	}
 
 	if len(userPlaylists) >= 10 {
-		_, _ = m.ReplyText(c, "You have reached the maximum limit of 10 playlists.", nil)
+		_, _ = m.ReplyText(c, lang.T(m.ChatId, "playlist.limit"), nil)
 		return td.EndGroups
 	}
 
@@ -46,17 +48,13 @@ func createPlaylistHandler(c *td.Client, ctx *td.Context) error {
 
 	playlistID, err := db.Instance.CreatePlaylist(args, userID)
 	if err != nil {
-		_, err = m.ReplyText(c, fmt.Sprintf("Failed to create playlist: %s", err.Error()), nil)
+		_, err = m.ReplyText(c, lang.T(m.ChatId, "playlist.create_failed", err.Error()), nil)
 		return err
 	}
 
 	_, err = m.ReplyText(
 		c,
-		fmt.Sprintf(
-			"Playlist <b>%s</b> has been created successfully.\nID: <code>%s</code>",
-			args,
-			playlistID,
-		),
+		lang.T(m.ChatId, "playlist.created", args, playlistID),
 		replyOpts,
 	)
 
@@ -65,42 +63,27 @@ func createPlaylistHandler(c *td.Client, ctx *td.Context) error {
 
 func deletePlaylistHandler(c *td.Client, ctx *td.Context) error {
 	m := ctx.EffectiveMessage
-	userID := m.SenderID()
 
 	args := Args(m)
 	if args == "" {
 		_, err := m.ReplyText(
 			c,
-			"<b>Usage:</b> /deleteplaylist [playlist id]",
+			lang.T(m.ChatId, "playlist.delete_usage"),
 			&td.SendTextMessageOpts{ParseMode: "HTML"},
 		)
 		return err
 	}
 
-	playlist, err := db.Instance.GetPlaylist(args)
-	if err != nil {
-		_, err := m.ReplyText(
-			c,
-			"The specified playlist could not be found. Please check the playlist ID.",
-			nil,
-		)
-		return err
-	}
-
-	if playlist.UserID != userID {
-		_, err := m.ReplyText(
-			c,
-			"You can only delete playlists that you created.",
-			nil,
-		)
-		return err
+	playlist, ok := loadPlaylist(c, m, args)
+	if !ok {
+		return td.EndGroups
 	}
 
-	err = db.Instance.DeletePlaylist(args, userID)
+	err := db.Instance.DeletePlaylist(playlistActor(m), args)
 	if err != nil {
 		_, err := m.ReplyText(
 			c,
-			fmt.Sprintf("Failed to delete the playlist: %s", err.Error()),
+			playlistErrorText(m.ChatId, err, "playlist.delete_failed"),
 			nil,
 		)
 		return err
@@ -108,7 +91,7 @@ func deletePlaylistHandler(c *td.Client, ctx *td.Context) error {
 
 	_, err = m.ReplyText(
 		c,
-		fmt.Sprintf("Playlist <b>%s</b> has been deleted successfully.", playlist.Name),
+		lang.T(m.ChatId, "playlist.deleted", playlist.Name),
 		&td.SendTextMessageOpts{ParseMode: "HTML"},
 	)
 
@@ -116,13 +99,12 @@ func deletePlaylistHandler(c *td.Client, ctx *td.Context) error {
 }
 func addToPlaylistHandler(c *td.Client, ctx *td.Context) error {
 	m := ctx.EffectiveMessage
-	userID := m.SenderID()
 
 	args := strings.SplitN(Args(m), " ", 2)
 	if len(args) != 2 {
 		_, err := m.ReplyText(
 			c,
-			"<b>Usage:</b> /addtoplaylist [playlist id] [song url]",
+			lang.T(m.ChatId, "playlist.add_usage"),
 			&td.SendTextMessageOpts{ParseMode: "HTML"},
 		)
 		return err
@@ -131,22 +113,12 @@ func addToPlaylistHandler(c *td.Client, ctx *td.Context) error {
 	playlistID := args[0]
 	songURL := args[1]
 
-	playlist, err := db.Instance.GetPlaylist(playlistID)
-	if err != nil {
-		_, err := m.ReplyText(
-			c,
-			"The specified playlist could not be found. Please verify the playlist ID.",
-			nil,
-		)
-		return err
+	playlist, ok := loadPlaylist(c, m, playlistID)
+	if !ok {
+		return td.EndGroups
 	}
-
-	if playlist.UserID != userID {
-		_, err := m.ReplyText(
-			c,
-			"You can only modify playlists that you created.",
-			nil,
-		)
+	if !db.Instance.CanEditPlaylist(playlist, playlistActor(m)) {
+		_, err := m.ReplyText(c, lang.T(m.ChatId, "playlist.forbidden"), nil)
 		return err
 	}
 
@@ -154,7 +126,7 @@ func addToPlaylistHandler(c *td.Client, ctx *td.Context) error {
 	if !wrapper.IsValid() {
 		_, err := m.ReplyText(
 			c,
-			"The provided URL is invalid or the platform is not supported.",
+			lang.T(m.ChatId, "play.invalid_url"),
 			nil,
 		)
 		return err
@@ -164,7 +136,7 @@ func addToPlaylistHandler(c *td.Client, ctx *td.Context) error {
 	if err != nil {
 		_, err := m.ReplyText(
 			c,
-			fmt.Sprintf("Unable to retrieve track information: %s", err.Error()),
+			lang.T(m.ChatId, "playlist.info_failed", err.Error()),
 			nil,
 		)
 		return err
@@ -173,7 +145,7 @@ func addToPlaylistHandler(c *td.Client, ctx *td.Context) error {
 	if trackInfo.Results == nil || len(trackInfo.Results) == 0 {
 		_, err := m.ReplyText(
 			c,
-			"No playable tracks were found for the provided link.",
+			lang.T(m.ChatId, "playlist.no_tracks"),
 			nil,
 		)
 		return err
@@ -187,11 +159,11 @@ func addToPlaylistHandler(c *td.Client, ctx *td.Context) error {
 		Platform: trackInfo.Results[0].Platform,
 	}
 
-	err = db.Instance.AddSongToPlaylist(playlistID, song)
+	err = db.Instance.AddSongToPlaylist(playlistActor(m), playlistID, song)
 	if err != nil {
 		_, err := m.ReplyText(
 			c,
-			fmt.Sprintf("Failed to add the track to the playlist: %s", err.Error()),
+			playlistErrorText(m.ChatId, err, "playlist.add_failed"),
 			nil,
 		)
 		return err
@@ -199,11 +171,7 @@ func addToPlaylistHandler(c *td.Client, ctx *td.Context) error {
 
 	_, err = m.ReplyText(
 		c,
-		fmt.Sprintf(
-			"Track <b>%s</b> has been added to playlist <b>%s</b>.",
-			song.Name,
-			playlist.Name,
-		),
+		lang.T(m.ChatId, "playlist.added", song.Name, playlist.Name),
 		replyOpts,
 	)
 
@@ -212,13 +180,12 @@ func addToPlaylistHandler(c *td.Client, ctx *td.Context) error {
 
 func removeFromPlaylistHandler(c *td.Client, ctx *td.Context) error {
 	m := ctx.EffectiveMessage
-	userID := m.SenderID()
 
 	args := strings.SplitN(Args(m), " ", 2)
 	if len(args) != 2 {
 		_, err := m.ReplyText(
 			c,
-			"<b>Usage:</b> /removefromplaylist [playlist id] [song number or url]",
+			lang.T(m.ChatId, "playlist.remove_usage"),
 			&td.SendTextMessageOpts{ParseMode: "HTML"},
 		)
 		return err
@@ -227,14 +194,12 @@ func removeFromPlaylistHandler(c *td.Client, ctx *td.Context) error {
 	playlistID := args[0]
 	songIdentifier := args[1]
 
-	playlist, err := db.Instance.GetPlaylist(playlistID)
-	if err != nil {
-		_, err = m.ReplyText(c, "Playlist not found.", nil)
-		return err
+	playlist, ok := loadPlaylist(c, m, playlistID)
+	if !ok {
+		return td.EndGroups
 	}
-
-	if playlist.UserID != userID {
-		_, err = m.ReplyText(c, "You do not own this playlist.", nil)
+	if !db.Instance.CanEditPlaylist(playlist, playlistActor(m)) {
+		_, err := m.ReplyText(c, lang.T(m.ChatId, "playlist.forbidden"), nil)
 		return err
 	}
 
@@ -243,7 +208,7 @@ func removeFromPlaylistHandler(c *td.Client, ctx *td.Context) error {
 
 	if err == nil {
 		if songIndex < 1 || songIndex > len(playlist.Songs) {
-			_, err := m.ReplyText(c, "Invalid song number.", nil)
+			_, err := m.ReplyText(c, lang.T(m.ChatId, "playlist.invalid_number"), nil)
 			return err
 		}
 		trackID = playlist.Songs[songIndex-1].TrackID
@@ -257,17 +222,17 @@ func removeFromPlaylistHandler(c *td.Client, ctx *td.Context) error {
 	}
 
 	if trackID == "" {
-		_, err = m.ReplyText(c, "Song not found in playlist.", nil)
+		_, err = m.ReplyText(c, lang.T(m.ChatId, "playlist.song_not_found"), nil)
 		return err
 	}
 
-	err = db.Instance.RemoveSongFromPlaylist(playlistID, trackID)
+	err = db.Instance.RemoveSongFromPlaylist(playlistActor(m), playlistID, trackID)
 	if err != nil {
-		_, err = m.ReplyText(c, fmt.Sprintf("Error removing song: %s", err.Error()), nil)
+		_, err = m.ReplyText(c, playlistErrorText(m.ChatId, err, "playlist.remove_failed"), nil)
 		return err
 	}
 
-	_, err = m.ReplyText(c, fmt.Sprintf("Song removed from playlist '%s'.", playlist.Name), nil)
+	_, err = m.ReplyText(c, lang.T(m.ChatId, "playlist.removed", playlist.Name), nil)
 	return err
 }
 
@@ -278,21 +243,20 @@ func playlistInfoHandler(c *td.Client, ctx *td.Context) error {
 	if args == "" {
 		_, err := m.ReplyText(
 			c,
-			"<b>Usage:</b> /playlistinfo [playlist id]",
+			lang.T(m.ChatId, "playlist.info_usage"),
 			&td.SendTextMessageOpts{ParseMode: "HTML"},
 		)
 		return err
 	}
 
-	playlist, err := db.Instance.GetPlaylist(args)
-	if err != nil {
-		_, err = m.ReplyText(c, "Playlist not found.", nil)
-		return err
+	playlist, ok := loadPlaylist(c, m, args)
+	if !ok {
+		return td.EndGroups
 	}
 
 	var songs []string
 	for i, song := range playlist.Songs {
-		songs = append(songs, fmt.Sprintf("%d. %s (%s)", i+1, song.Name, song.URL))
+		songs = append(songs, lang.T(m.ChatId, "playlist.song_item", i+1, song.Name, song.URL))
 	}
 
 	owner, err := c.GetUser(playlist.UserID)
@@ -302,10 +266,10 @@ func playlistInfoHandler(c *td.Client, ctx *td.Context) error {
 
 	_, err = m.ReplyText(
 		c,
-		fmt.Sprintf(
-			"<b>Playlist Info</b>\n\n<b>Name:</b> %s\n<b>Owner:</b> %s\n<b>Songs:</b> %d\n\n%s",
+		lang.T(m.ChatId, "playlist.info",
 			playlist.Name,
 			owner.FirstName,
+			visibilityLabel(m.ChatId, playlist.Visibility),
 			len(playlist.Songs),
 			strings.Join(songs, "\n"),
 		),
@@ -314,34 +278,135 @@ func playlistInfoHandler(c *td.Client, ctx *td.Context) error {
 	return td.EndGroups
 }
 
-func myPlaylistsHandler(c *td.Client, ctx *td.Context) error {
+// renamePlaylistHandler handles /renameplaylist [id] [new name].
+func renamePlaylistHandler(c *td.Client, ctx *td.Context) error {
 	m := ctx.EffectiveMessage
-	userID := m.SenderID()
 
-	playlists, err := db.Instance.GetUserPlaylists(userID)
-	if err != nil {
-		_, err := m.ReplyText(c, fmt.Sprintf("Error fetching playlists: %s", err.Error()), nil)
+	args := strings.SplitN(Args(m), " ", 2)
+	if len(args) != 2 || strings.TrimSpace(args[1]) == "" {
+		_, err := m.ReplyText(c, lang.T(m.ChatId, "playlist.rename_usage"), replyOpts)
 		return err
 	}
 
-	if len(playlists) == 0 {
-		_, err := m.ReplyText(c, "You do not have any playlists.", nil)
+	playlist, ok := loadPlaylist(c, m, args[0])
+	if !ok {
+		return td.EndGroups
+	}
+
+	name := strings.TrimSpace(args[1])
+	if len([]rune(name)) > 40 {
+		name = string([]rune(name)[:40])
+	}
+
+	if err := db.Instance.RenamePlaylist(playlistActor(m), playlist.ID, name); err != nil {
+		_, err = m.ReplyText(c, playlistErrorText(m.ChatId, err, "playlist.rename_failed"), nil)
 		return err
 	}
 
-	var playlistInfo []string
-	for _, playlist := range playlists {
-		playlistInfo = append(
-			playlistInfo,
-			fmt.Sprintf("- %s (<code>%s</code>)", playlist.Name, playlist.ID),
-		)
+	_, err := m.ReplyText(c, lang.T(m.ChatId, "playlist.renamed", html.EscapeString(playlist.Name), html.EscapeString(name)), replyOpts)
+	return err
+}
+
+// movePlaylistSongHandler handles /moveplaylist [id] [from] [to], with positions numbered as in /playlistinfo.
+func movePlaylistSongHandler(c *td.Client, ctx *td.Context) error {
+	m := ctx.EffectiveMessage
+
+	args := strings.Fields(Args(m))
+	if len(args) != 3 {
+		_, err := m.ReplyText(c, lang.T(m.ChatId, "playlist.move_usage"), replyOpts)
+		return err
 	}
 
-	_, err = m.ReplyText(
-		c,
-		fmt.Sprintf("<b>My Playlists</b>\n\n%s", strings.Join(playlistInfo, "\n")),
-		&td.SendTextMessageOpts{ParseMode: "HTML"},
-	)
+	from, errFrom := strconv.Atoi(args[1])
+	to, errTo := strconv.Atoi(args[2])
+	if errFrom != nil || errTo != nil {
+		_, err := m.ReplyText(c, lang.T(m.ChatId, "playlist.move_usage"), replyOpts)
+		return err
+	}
+
+	playlist, ok := loadPlaylist(c, m, args[0])
+	if !ok {
+		return td.EndGroups
+	}
 
+	err := db.Instance.MovePlaylistSong(playlistActor(m), playlist.ID, from-1, to-1, "")
+	if errors.Is(err, db.ErrSongPosition) {
+		_, err = m.ReplyText(c, lang.T(m.ChatId, "playlist.invalid_number"), nil)
+		return err
+	} else if err != nil {
+		_, err = m.ReplyText(c, playlistErrorText(m.ChatId, err, "playlist.move_failed"), nil)
+		return err
+	}
+
+	var name string
+	if from >= 1 && from <= len(playlist.Songs) {
+		name = playlist.Songs[from-1].Name
+	}
+	_, err = m.ReplyText(c, lang.T(m.ChatId, "playlist.moved", html.EscapeString(name), to, html.EscapeString(playlist.Name)), replyOpts)
 	return err
 }
+
+// dedupePlaylistHandler handles /dedupeplaylist [id].
+func dedupePlaylistHandler(c *td.Client, ctx *td.Context) error {
+	m := ctx.EffectiveMessage
+
+	args := Args(m)
+	if args == "" {
+		_, err := m.ReplyText(c, lang.T(m.ChatId, "playlist.dedupe_usage"), replyOpts)
+		return err
+	}
+
+	playlist, ok := loadPlaylist(c, m, args)
+	if !ok {
+		return td.EndGroups
+	}
+
+	removed, err := db.Instance.DedupePlaylist(playlistActor(m), playlist.ID)
+	if err != nil {
+		_, err = m.ReplyText(c, playlistErrorText(m.ChatId, err, "playlist.dedupe_failed"), nil)
+		return err
+	}
+
+	if removed == 0 {
+		_, err = m.ReplyText(c, lang.T(m.ChatId, "playlist.no_duplicates", html.EscapeString(playlist.Name)), replyOpts)
+		return err
+	}
+
+	_, err = m.ReplyText(c, lang.T(m.ChatId, "playlist.deduped", removed, html.EscapeString(playlist.Name)), replyOpts)
+	return err
+}
+
+// loadPlaylist loads a playlist the sender can see, replying with the reason when it is missing or private.
+func loadPlaylist(c *td.Client, m *td.Message, id string) (*db.Playlist, bool) {
+	playlist, err := db.Instance.GetPlaylist(playlistActor(m), id)
+	if err != nil {
+		_, _ = m.ReplyText(c, playlistErrorText(m.ChatId, err, "playlist.list_failed"), nil)
+		return nil, false
+	}
+	loadPlaylistAdmins(c, playlist)
+	return playlist, true
+}
+
+// playlistActor returns the sender of m as the actor for playlist permission checks.
+func playlistActor(m *td.Message) db.Actor {
+	return db.Actor{UserID: m.SenderID(), ChatID: m.ChatId}
+}
+
+// playlistErrorText describes a playlist error, using fallbackKey with the error for anything but a missing
+// playlist or a permission error.
+func playlistErrorText(chatID int64, err error, fallbackKey string) string {
+	switch {
+	case errors.Is(err, db.ErrPlaylistForbidden):
+		return lang.T(chatID, "playlist.forbidden")
+	case errors.Is(err, db.ErrNotFound):
+		return lang.T(chatID, "playlist.not_found_id")
+	case errors.Is(err, db.ErrPlaylistChanged):
+		return lang.T(chatID, "playlist.changed")
+	}
+	return lang.T(chatID, fallbackKey, err.Error())
+}
+
+// visibilityLabel returns the localized name of a playlist visibility.
+func visibilityLabel(chatID int64, visibility db.PlaylistVisibility) string {
+	return lang.T(chatID, "playlist.visibility_"+string(visibility))
+}
@@ -0,0 +1,324 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package handlers
+
+import (
+	"ashokshau/tgmusic/src/core"
+	"ashokshau/tgmusic/src/core/lang"
+	"ashokshau/tgmusic/src/utils"
+	"errors"
+	"html"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"ashokshau/tgmusic/src/core/cache"
+	"ashokshau/tgmusic/src/core/db"
+
+	td "github.com/AshokShau/gotdbot"
+)
+
+const (
+	playlistsPerPage = 5
+	songsPerPage     = 8
+)
+
+// pendingPick is a song waiting for its user to pick a playlist from the chooser.
+type pendingPick struct {
+	song   db.Song
+	userID int64
+}
+
+var (
+	pendingPicks   = cache.NewCache[*pendingPick](10 * time.Minute)
+	pendingPicksMu sync.Mutex
+)
+
+// myPlaylistsHandler shows the sender's playlists as a browsable panel.
+func myPlaylistsHandler(c *td.Client, ctx *td.Context) error {
+	m := ctx.EffectiveMessage
+
+	text, markup, err := buildPlaylistsPage(playlistActor(m), m.SenderID(), 0)
+	if err != nil {
+		_, err = m.ReplyText(c, lang.T(m.ChatId, "playlist.list_failed", err.Error()), nil)
+		return err
+	}
+
+	_, err = m.ReplyText(c, text, &td.SendTextMessageOpts{ParseMode: "HTML", DisableWebPagePreview: true, ReplyMarkup: markup})
+	return err
+}
+
+// playlistCallbackHandler handles the playlist browser and the ➕ chooser. Browsing is open to anyone who can see
+// the playlist, like /playlistinfo; edits are limited to those who can edit it.
+func playlistCallbackHandler(c *td.Client, ctx *td.Context) error {
+	cb := ctx.Update.UpdateNewCallbackQuery
+	chatID := cb.ChatId
+
+	action, args, _ := strings.Cut(strings.TrimPrefix(cb.DataString(), "plist_"), "_")
+	// Playlist IDs contain underscores, so the number or token after them is split off at the last one.
+	head, tail, ok := cutLast(args)
+	if !ok {
+		_ = cb.Answer(c, 0, false, lang.T(chatID, "common.invalid_page"), "")
+		return nil
+	}
+
+	if action == "pick" {
+		return pickPlaylist(c, cb, head, tail)
+	}
+
+	// Song buttons carry the song's tag after its position.
+	tail, tag, _ := strings.Cut(tail, ":")
+	n, err := strconv.Atoi(tail)
+	if err != nil || n < 0 {
+		_ = cb.Answer(c, 0, false, lang.T(chatID, "common.invalid_page"), "")
+		return nil
+	}
+
+	actor := db.Actor{UserID: cb.SenderUserId, ChatID: chatID}
+	var text string
+	var markup *td.ReplyMarkupInlineKeyboard
+	switch action {
+	case "list":
+		userID, err := strconv.ParseInt(head, 10, 64)
+		if err != nil {
+			_ = cb.Answer(c, 0, false, lang.T(chatID, "common.invalid_page"), "")
+			return nil
+		}
+		text, markup, err = buildPlaylistsPage(actor, userID, n)
+		if err != nil {
+			_ = cb.Answer(c, 0, true, lang.T(chatID, "callback.playlists_failed"), "")
+			return nil
+		}
+
+	case "view":
+		playlist, err := db.Instance.GetPlaylist(actor, head)
+		if err != nil {
+			_ = cb.Answer(c, 0, true, playlistErrorText(chatID, err, "playlist.list_failed"), "")
+			return nil
+		}
+		text, markup = buildPlaylistSongsPage(chatID, playlist, n)
+
+	case "up", "down", "rm":
+		playlist, err := db.Instance.GetPlaylist(actor, head)
+		if err == nil {
+			loadPlaylistAdmins(c, playlist)
+			playlist, err = editPlaylistSong(actor, playlist, action, n, tag)
+		}
+		if err != nil {
+			_ = cb.Answer(c, 0, true, playlistErrorText(chatID, err, "playlist.edit_failed"), "")
+			// The buttons were stale; show the playlist as it is now.
+			if errors.Is(err, db.ErrPlaylistChanged) {
+				if playlist, err = db.Instance.GetPlaylist(actor, head); err == nil {
+					text, markup = buildPlaylistSongsPage(chatID, playlist, n/songsPerPage)
+					_, _ = cb.EditMessageText(c, text, &td.EditTextMessageOpts{ParseMode: "HTML", ReplyMarkup: markup, DisableWebPagePreview: true})
+				}
+			}
+			return nil
+		}
+		text, markup = buildPlaylistSongsPage(chatID, playlist, n/songsPerPage)
+
+	default:
+		_ = cb.Answer(c, 0, false, lang.T(chatID, "common.invalid_page"), "")
+		return nil
+	}
+
+	_ = cb.Answer(c, 0, false, "", "")
+	_, _ = cb.EditMessageText(c, text, &td.EditTextMessageOpts{ParseMode: "HTML", ReplyMarkup: markup, DisableWebPagePreview: true})
+	return nil
+}
+
+// editPlaylistSong applies a browser button to the song at index and returns the updated playlist.
+// Moving the first song up or the last one down leaves the playlist unchanged. The edit is refused with
+// db.ErrPlaylistChanged if the song at index no longer has the button's tag.
+func editPlaylistSong(actor db.Actor, playlist *db.Playlist, action string, index int, tag string) (*db.Playlist, error) {
+	if index >= len(playlist.Songs) {
+		return nil, db.ErrSongPosition
+	}
+	if !db.Instance.CanEditPlaylist(playlist, actor) {
+		return nil, db.ErrPlaylistForbidden
+	}
+
+	var err error
+	switch action {
+	case "up":
+		if index > 0 {
+			err = db.Instance.MovePlaylistSong(actor, playlist.ID, index, index-1, tag)
+		}
+	case "down":
+		if index+1 < len(playlist.Songs) {
+			err = db.Instance.MovePlaylistSong(actor, playlist.ID, index, index+1, tag)
+		}
+	case "rm":
+		err = db.Instance.RemovePlaylistSongAt(actor, playlist.ID, index, tag)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return db.Instance.GetPlaylist(actor, playlist.ID)
+}
+
+// buildPlaylistsPage renders one page of a user's playlists with a button to open each, leaving out those the
+// actor may not browse.
+func buildPlaylistsPage(actor db.Actor, userID int64, page int) (string, *td.ReplyMarkupInlineKeyboard, error) {
+	chatID := actor.ChatID
+	playlists, err := db.Instance.ListPlaylistsFor(actor, userID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(playlists) == 0 {
+		return lang.T(chatID, "playlist.none"), core.PlaylistsKeyboard(userID, nil, 0, 1), nil
+	}
+
+	totalPages := (len(playlists) + playlistsPerPage - 1) / playlistsPerPage
+	page = min(page, totalPages-1)
+	start := page * playlistsPerPage
+	end := min(start+playlistsPerPage, len(playlists))
+
+	var items []string
+	var buttons []core.PlaylistButton
+	for _, p := range playlists[start:end] {
+		items = append(items, lang.T(chatID, "playlist.list_item", html.EscapeString(p.Name), p.ID, len(p.Songs)))
+		buttons = append(buttons, core.PlaylistButton{ID: p.ID, Name: p.Name})
+	}
+
+	text := lang.T(chatID, "playlist.list", strings.Join(items, "\n"))
+	return text, core.PlaylistsKeyboard(userID, buttons, page, totalPages), nil
+}
+
+// buildPlaylistSongsPage renders one page of a playlist's songs with move and remove buttons.
+func buildPlaylistSongsPage(chatID int64, playlist *db.Playlist, page int) (string, *td.ReplyMarkupInlineKeyboard) {
+	totalPages := (len(playlist.Songs) + songsPerPage - 1) / songsPerPage
+	page = max(min(page, totalPages-1), 0)
+	start := page * songsPerPage
+	end := min(start+songsPerPage, len(playlist.Songs))
+
+	var b strings.Builder
+	b.WriteString(lang.T(chatID, "playlist.songs_header", html.EscapeString(playlist.Name), playlist.ID, len(playlist.Songs)))
+	if len(playlist.Songs) == 0 {
+		b.WriteString(lang.T(chatID, "playlist.empty"))
+	}
+	for i, song := range playlist.Songs[start:end] {
+		b.WriteString(lang.T(chatID, "playlist.browse_item",
+			start+i+1,
+			html.EscapeString(song.URL),
+			html.EscapeString(song.Name),
+			utils.TrackDuration(song.Duration, false),
+		))
+	}
+
+	tags := make([]string, 0, end-start)
+	for _, song := range playlist.Songs[start:end] {
+		tags = append(tags, db.SongTag(song))
+	}
+	markup := core.PlaylistSongsKeyboard(lang.ChatLanguage(chatID), playlist.ID, playlist.UserID, start, tags, page, totalPages)
+	return b.String(), markup
+}
+
+// addToPlaylist handles ➕ on the now-playing panel. The song goes straight into the user's only playlist,
+// or a new one if they have none; with several, a chooser is sent to the chat.
+func addToPlaylist(c *td.Client, cb *td.UpdateNewCallbackQuery, user *td.User, song db.Song) error {
+	chatID := cb.ChatId
+
+	playlists, err := db.Instance.GetUserPlaylists(user.Id)
+	if err != nil {
+		_ = cb.Answer(c, 0, false, lang.T(chatID, "callback.playlists_failed"), "")
+		return nil
+	}
+
+	if len(playlists) > 1 {
+		token := strconv.FormatInt(time.Now().UnixNano(), 36)
+		pendingPicks.Set(token, &pendingPick{song: song, userID: user.Id})
+
+		buttons := make([]core.PlaylistButton, 0, len(playlists))
+		for _, p := range playlists {
+			buttons = append(buttons, core.PlaylistButton{ID: p.ID, Name: p.Name})
+		}
+
+		text := lang.T(chatID, "playlist.choose", html.EscapeString(user.FirstName), html.EscapeString(song.Name))
+		_, err = c.SendTextMessage(chatID, text, &td.SendTextMessageOpts{ParseMode: "HTML", ReplyMarkup: core.PlaylistChooserKeyboard(token, buttons)})
+		if err != nil {
+			_ = cb.Answer(c, 0, false, lang.T(chatID, "callback.playlist_add_failed"), "")
+			return nil
+		}
+		_ = cb.Answer(c, 0, false, lang.T(chatID, "playlist.choose_sent"), "")
+		return nil
+	}
+
+	var playlistID, name string
+	if len(playlists) == 0 {
+		name = "My Playlist (TgMusic)"
+		playlistID, err = db.Instance.CreatePlaylist(name, user.Id)
+		if err != nil {
+			_ = cb.Answer(c, 0, false, lang.T(chatID, "callback.playlist_create_failed"), "")
+			return nil
+		}
+	} else {
+		playlistID, name = playlists[0].ID, playlists[0].Name
+	}
+
+	if err = db.Instance.AddSongToPlaylist(db.Actor{UserID: user.Id, ChatID: chatID}, playlistID, song); err != nil {
+		_ = cb.Answer(c, 0, false, lang.T(chatID, "callback.playlist_add_failed"), "")
+		return nil
+	}
+
+	_ = cb.Answer(c, 0, false, lang.T(chatID, "callback.playlist_added", song.Name, name), "")
+	return nil
+}
+
+// pickPlaylist adds the song saved under token to the playlist chosen from the chooser.
+func pickPlaylist(c *td.Client, cb *td.UpdateNewCallbackQuery, playlistID, token string) error {
+	chatID := cb.ChatId
+
+	pick, ok := pendingPicks.Get(token)
+	if !ok {
+		_ = cb.Answer(c, 0, true, lang.T(chatID, "playlist.choose_expired"), "")
+		_ = c.DeleteMessages(chatID, []int64{cb.MessageId}, &td.DeleteMessagesOpts{Revoke: true})
+		return nil
+	}
+	if pick.userID != cb.SenderUserId {
+		_ = cb.Answer(c, 0, true, lang.T(chatID, "playlist.choose_not_yours"), "")
+		return nil
+	}
+
+	actor := db.Actor{UserID: cb.SenderUserId, ChatID: chatID}
+	playlist, err := db.Instance.GetPlaylist(actor, playlistID)
+	if err != nil {
+		_ = cb.Answer(c, 0, true, playlistErrorText(chatID, err, "playlist.list_failed"), "")
+		return nil
+	}
+
+	// Only the first tap adds the song.
+	pendingPicksMu.Lock()
+	_, ok = pendingPicks.Get(token)
+	pendingPicks.Delete(token)
+	pendingPicksMu.Unlock()
+	if !ok {
+		return cb.Answer(c, 0, false, "", "")
+	}
+
+	if err = db.Instance.AddSongToPlaylist(actor, playlist.ID, pick.song); err != nil {
+		_ = cb.Answer(c, 0, true, playlistErrorText(chatID, err, "playlist.add_failed"), "")
+		return nil
+	}
+
+	_ = cb.Answer(c, 0, false, "", "")
+	_, err = cb.EditMessageText(c, lang.T(chatID, "playlist.added", html.EscapeString(pick.song.Name), html.EscapeString(playlist.Name)), &td.EditTextMessageOpts{ParseMode: "HTML"})
+	return err
+}
+
+// cutLast splits s around its last underscore.
+func cutLast(s string) (before, after string, found bool) {
+	i := strings.LastIndex(s, "_")
+	if i < 0 {
+		return s, "", false
+	}
+	return s[:i], s[i+1:], true
+}
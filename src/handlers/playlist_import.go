@@ -0,0 +1,324 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package handlers
+
+import (
+	"ashokshau/tgmusic/src/core/lang"
+	"ashokshau/tgmusic/src/utils"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"ashokshau/tgmusic/src/core/db"
+	"ashokshau/tgmusic/src/core/dl"
+
+	td "github.com/AshokShau/gotdbot"
+)
+
+const (
+	// maxPlaylistFileSize is the largest playlist file /importplaylist accepts.
+	maxPlaylistFileSize = 1 << 20
+	// maxImportSongs caps how many songs one import saves.
+	maxImportSongs = 500
+	// importProgressInterval is how often the status message is updated while links are resolved.
+	importProgressInterval = 3 * time.Second
+)
+
+// importPlaylistHandler handles /importplaylist [url] [name].
+// It saves a platform playlist as a new playlist, or, as a reply to an .m3u, .json or .csv file, imports the file.
+func importPlaylistHandler(c *td.Client, ctx *td.Context) error {
+	m := ctx.EffectiveMessage
+	chatID := m.ChatId
+	userID := m.SenderID()
+	args := Args(m)
+
+	if m.ReplyToMessageID() != 0 {
+		if r, err := m.GetRepliedMessage(c); err == nil {
+			if doc, ok := r.Content.(*td.MessageDocument); ok && doc.Document != nil && doc.Document.Document != nil {
+				return importPlaylistFile(c, m, r, doc.Document, args)
+			}
+		}
+	}
+
+	if args == "" {
+		_, err := m.ReplyText(c, lang.T(chatID, "playlist.import_usage"), replyOpts)
+		return err
+	}
+	if !canCreatePlaylist(c, m) {
+		return td.EndGroups
+	}
+
+	url, name, _ := strings.Cut(args, " ")
+	wrapper := dl.NewDownloaderWrapper(url)
+	if !wrapper.IsValid() {
+		_, err := m.ReplyText(c, lang.T(chatID, "play.invalid_url"), nil)
+		return err
+	}
+
+	status, err := m.ReplyText(c, lang.T(chatID, "playlist.import_fetching"), nil)
+	if err != nil {
+		return err
+	}
+
+	info, err := wrapper.GetInfo()
+	if err != nil {
+		_, _ = status.EditText(c, lang.T(chatID, "playlist.info_failed", err.Error()), nil)
+		return td.EndGroups
+	}
+
+	songs := make([]db.Song, 0, len(info.Results))
+	for _, track := range info.Results {
+		songs = append(songs, songFromTrack(track))
+	}
+
+	if name == "" {
+		name = lang.T(chatID, "playlist.import_default_name")
+	}
+	return saveImportedPlaylist(c, status, userID, name, songs, 0)
+}
+
+// importPlaylistFile imports a playlist file from the replied message. Entries without a track ID are resolved
+// from their URL one by one in the background, with the status message showing progress.
+func importPlaylistFile(c *td.Client, m *td.Message, r *td.Message, doc *td.Document, name string) error {
+	chatID := m.ChatId
+
+	format, ok := db.ParsePlaylistFormat(filepath.Ext(doc.FileName))
+	if !ok {
+		_, err := m.ReplyText(c, lang.T(chatID, "playlist.import_bad_file"), replyOpts)
+		return err
+	}
+	if doc.Document.Size > maxPlaylistFileSize {
+		_, err := m.ReplyText(c, lang.T(chatID, "playlist.import_too_large", maxPlaylistFileSize>>10), nil)
+		return err
+	}
+	if !canCreatePlaylist(c, m) {
+		return td.EndGroups
+	}
+
+	status, err := m.ReplyText(c, lang.T(chatID, "playlist.import_fetching"), nil)
+	if err != nil {
+		return err
+	}
+
+	file, err := r.Download(c, 1, 0, 0, true)
+	if err != nil {
+		_, _ = status.EditText(c, lang.T(chatID, "playlist.import_failed", err.Error()), nil)
+		return td.EndGroups
+	}
+
+	playlist, err := readPlaylistFile(file.Local.Path, format)
+	_ = os.Remove(file.Local.Path)
+	if err != nil {
+		_, _ = status.EditText(c, lang.T(chatID, "playlist.import_failed", err.Error()), nil)
+		return td.EndGroups
+	}
+
+	// Cap before resolving so a huge file does not trigger thousands of lookups.
+	over := max(len(playlist.Songs)-maxImportSongs, 0)
+	playlist.Songs = playlist.Songs[:len(playlist.Songs)-over]
+
+	if name == "" {
+		name = playlist.Name
+	}
+	if name == "" {
+		name = strings.TrimSuffix(doc.FileName, filepath.Ext(doc.FileName))
+	}
+
+	userID := m.SenderID()
+	go func() {
+		songs, skipped := resolveSongs(c, status, chatID, playlist.Songs)
+		_ = saveImportedPlaylist(c, status, userID, name, songs, skipped+over)
+	}()
+	return td.EndGroups
+}
+
+func readPlaylistFile(path string, format db.PlaylistFormat) (*db.PlaylistFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return db.ReadPlaylist(f, format)
+}
+
+// resolveSongs looks up the entries that only have a URL and returns the playable songs and how many were skipped.
+// Only web links are looked up; anything else in a file, such as a local path, is skipped.
+func resolveSongs(c *td.Client, status *td.Message, chatID int64, songs []db.Song) ([]db.Song, int) {
+	resolved := make([]db.Song, 0, len(songs))
+	skipped := 0
+	lastUpdate := time.Now()
+
+	for i, song := range songs {
+		if time.Since(lastUpdate) >= importProgressInterval {
+			_, _ = status.EditText(c, lang.T(chatID, "playlist.import_progress", i, len(songs)), nil)
+			lastUpdate = time.Now()
+		}
+
+		if song.TrackID != "" {
+			resolved = append(resolved, song)
+			continue
+		}
+
+		if !strings.HasPrefix(song.URL, "https://") && !strings.HasPrefix(song.URL, "http://") {
+			skipped++
+			continue
+		}
+		wrapper := dl.NewDownloaderWrapper(song.URL)
+		if !wrapper.IsValid() {
+			skipped++
+			continue
+		}
+		info, err := wrapper.GetInfo()
+		if err != nil || len(info.Results) == 0 {
+			skipped++
+			continue
+		}
+		resolved = append(resolved, songFromTrack(info.Results[0]))
+	}
+	return resolved, skipped
+}
+
+// saveImportedPlaylist creates the playlist and reports the result on the status message.
+func saveImportedPlaylist(c *td.Client, status *td.Message, userID int64, name string, songs []db.Song, skipped int) error {
+	chatID := status.ChatId
+
+	if len(songs) == 0 {
+		_, _ = status.EditText(c, lang.T(chatID, "playlist.no_tracks"), nil)
+		return td.EndGroups
+	}
+	if len(songs) > maxImportSongs {
+		skipped += len(songs) - maxImportSongs
+		songs = songs[:maxImportSongs]
+	}
+
+	if len([]rune(name)) > 40 {
+		name = string([]rune(name)[:40])
+	}
+
+	playlistID, err := db.Instance.CreatePlaylist(name, userID)
+	if err != nil {
+		_, _ = status.EditText(c, lang.T(chatID, "playlist.create_failed", err.Error()), nil)
+		return td.EndGroups
+	}
+
+	added, err := db.Instance.AddSongsToPlaylist(db.Actor{UserID: userID, ChatID: chatID}, playlistID, songs)
+	if err != nil {
+		_, _ = status.EditText(c, lang.T(chatID, "playlist.import_failed", err.Error()), nil)
+		return td.EndGroups
+	}
+
+	text := lang.T(chatID, "playlist.imported", html.EscapeString(name), playlistID, added)
+	if skipped > 0 {
+		text += lang.T(chatID, "playlist.import_skipped", skipped)
+	}
+	_, err = status.EditText(c, text, editOpts)
+	return err
+}
+
+// canCreatePlaylist reports whether the sender is below the playlist limit, replying with the reason if not.
+func canCreatePlaylist(c *td.Client, m *td.Message) bool {
+	playlists, err := db.Instance.GetUserPlaylists(m.SenderID())
+	if err != nil {
+		_, _ = m.ReplyText(c, lang.T(m.ChatId, "playlist.fetch_failed"), nil)
+		return false
+	}
+	if len(playlists) >= 10 {
+		_, _ = m.ReplyText(c, lang.T(m.ChatId, "playlist.limit"), nil)
+		return false
+	}
+	return true
+}
+
+// exportPlaylistHandler handles /exportplaylist [id] [format] and sends the playlist as a file. JSON is the default
+// format since it keeps every field and imports without looking the songs up again.
+func exportPlaylistHandler(c *td.Client, ctx *td.Context) error {
+	m := ctx.EffectiveMessage
+	chatID := m.ChatId
+
+	args := strings.Fields(Args(m))
+	if len(args) == 0 || len(args) > 2 {
+		_, err := m.ReplyText(c, lang.T(chatID, "playlist.export_usage"), replyOpts)
+		return err
+	}
+
+	format := db.FormatJSON
+	if len(args) == 2 {
+		var ok bool
+		if format, ok = db.ParsePlaylistFormat(args[1]); !ok {
+			_, err := m.ReplyText(c, lang.T(chatID, "playlist.export_usage"), replyOpts)
+			return err
+		}
+	}
+
+	playlist, ok := loadPlaylist(c, m, args[0])
+	if !ok {
+		return td.EndGroups
+	}
+	if len(playlist.Songs) == 0 {
+		_, err := m.ReplyText(c, lang.T(chatID, "playlist.empty"), nil)
+		return err
+	}
+
+	path := filepath.Join(os.TempDir(), fmt.Sprintf("%s-%s.%s", fileSafeName(playlist.Name), playlist.ID, format))
+	if err := writePlaylistFile(path, playlist, format); err != nil {
+		_, err = m.ReplyText(c, lang.T(chatID, "playlist.export_failed", err.Error()), nil)
+		return err
+	}
+	defer os.Remove(path)
+
+	_, err := c.SendDocument(
+		chatID,
+		td.InputFileLocal{Path: path},
+		&td.SendDocumentOpts{
+			Caption:   lang.T(chatID, "playlist.exported", html.EscapeString(playlist.Name), len(playlist.Songs)),
+			ParseMode: "HTML",
+		},
+	)
+	if err != nil {
+		_, err = m.ReplyText(c, lang.T(chatID, "playlist.export_failed", err.Error()), nil)
+		return err
+	}
+	return td.EndGroups
+}
+
+func writePlaylistFile(path string, playlist *db.Playlist, format db.PlaylistFormat) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := db.WritePlaylist(f, playlist, format); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// fileSafeName keeps letters, digits and hyphens and replaces everything else with underscores.
+func fileSafeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+func songFromTrack(track utils.MusicTrack) db.Song {
+	return db.Song{
+		URL:      track.Url,
+		Name:     track.Title,
+		TrackID:  track.Id,
+		Duration: track.Duration,
+		Platform: track.Platform,
+	}
+}
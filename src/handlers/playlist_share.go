@@ -0,0 +1,237 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package handlers
+
+import (
+	"ashokshau/tgmusic/src/core/lang"
+	"errors"
+	"html"
+	"strings"
+
+	"ashokshau/tgmusic/src/core/cache"
+	"ashokshau/tgmusic/src/core/db"
+
+	td "github.com/AshokShau/gotdbot"
+)
+
+// loadPlaylistAdmins makes sure the admins of a group playlist's chat are cached, since the db layer checks
+// group admin rights against the cache.
+func loadPlaylistAdmins(c *td.Client, playlist *db.Playlist) {
+	if playlist.ChatID != 0 {
+		_, _ = cache.GetAdmins(c, playlist.ChatID, false)
+	}
+}
+
+// createGroupPlaylistHandler handles /creategroupplaylist [name], which creates a playlist owned by the group.
+// Any admin of the group can manage it.
+func createGroupPlaylistHandler(c *td.Client, ctx *td.Context) error {
+	m := ctx.EffectiveMessage
+	chatID := m.ChatId
+	if m.IsPrivate() {
+		_, err := m.ReplyText(c, lang.T(chatID, "playlist.group_only"), nil)
+		return err
+	}
+
+	name := Args(m)
+	if name == "" {
+		_, err := m.ReplyText(c, lang.T(chatID, "playlist.group_create_usage"), replyOpts)
+		return err
+	}
+	if len([]rune(name)) > 40 {
+		name = string([]rune(name)[:40])
+	}
+
+	playlists, err := db.Instance.GetChatPlaylists(chatID)
+	if err != nil {
+		_, err = m.ReplyText(c, lang.T(chatID, "playlist.fetch_failed"), nil)
+		return err
+	}
+	if len(playlists) >= 10 {
+		_, err = m.ReplyText(c, lang.T(chatID, "playlist.group_limit"), nil)
+		return err
+	}
+
+	if _, err = cache.GetAdmins(c, chatID, false); err != nil {
+		_, err = m.ReplyText(c, lang.T(chatID, "filters.admin_unverified"), nil)
+		return err
+	}
+
+	playlistID, err := db.Instance.CreateChatPlaylist(name, chatID, m.SenderID())
+	if errors.Is(err, db.ErrPlaylistForbidden) {
+		_, err = m.ReplyText(c, lang.T(chatID, "filters.admin_required"), nil)
+		return err
+	} else if err != nil {
+		_, err = m.ReplyText(c, lang.T(chatID, "playlist.create_failed", err.Error()), nil)
+		return err
+	}
+
+	_, err = m.ReplyText(c, lang.T(chatID, "playlist.created", html.EscapeString(name), playlistID), replyOpts)
+	return err
+}
+
+// playlistVisibilityHandler handles /playlistvisibility [id] [private|unlisted|public].
+func playlistVisibilityHandler(c *td.Client, ctx *td.Context) error {
+	m := ctx.EffectiveMessage
+	chatID := m.ChatId
+
+	args := strings.Fields(Args(m))
+	if len(args) == 1 {
+		playlist, ok := loadPlaylist(c, m, args[0])
+		if !ok {
+			return td.EndGroups
+		}
+		_, err := m.ReplyText(c, lang.T(chatID, "playlist.visibility_current", html.EscapeString(playlist.Name), visibilityLabel(chatID, playlist.Visibility)), replyOpts)
+		return err
+	}
+
+	var visibility db.PlaylistVisibility
+	ok := len(args) == 2
+	if ok {
+		visibility, ok = db.ParsePlaylistVisibility(args[1])
+	}
+	if !ok {
+		_, err := m.ReplyText(c, lang.T(chatID, "playlist.visibility_usage"), replyOpts)
+		return err
+	}
+
+	playlist, ok := loadPlaylist(c, m, args[0])
+	if !ok {
+		return td.EndGroups
+	}
+
+	if err := db.Instance.SetPlaylistVisibility(playlistActor(m), playlist.ID, visibility); err != nil {
+		_, err = m.ReplyText(c, playlistErrorText(chatID, err, "playlist.visibility_failed"), nil)
+		return err
+	}
+
+	_, err := m.ReplyText(c, lang.T(chatID, "playlist.visibility_set", html.EscapeString(playlist.Name), visibilityLabel(chatID, visibility)), replyOpts)
+	return err
+}
+
+// collaboratorHandler handles /collab [id] [add|remove] [user], where the user can also be given by replying to
+// their message. Collaborators can add, remove and reorder songs but not rename, share or delete the playlist.
+func collaboratorHandler(c *td.Client, ctx *td.Context) error {
+	m := ctx.EffectiveMessage
+	chatID := m.ChatId
+
+	args := strings.Fields(Args(m))
+	if len(args) == 1 {
+		return listCollaborators(c, m, args[0])
+	}
+	if len(args) < 2 || (args[1] != "add" && args[1] != "remove") {
+		_, err := m.ReplyText(c, lang.T(chatID, "playlist.collab_usage"), replyOpts)
+		return err
+	}
+
+	var userID int64
+	var err error
+	switch {
+	case len(args) > 2:
+		userID, err = resolveFromArg(c, args[2])
+	case m.ReplyToMessageID() != 0:
+		userID, err = resolveFromReply(c, m)
+	default:
+		_, err = m.ReplyText(c, lang.T(chatID, "playlist.collab_usage"), replyOpts)
+		return err
+	}
+	if err != nil {
+		_, err = m.ReplyText(c, err.Error(), nil)
+		return err
+	}
+
+	playlist, ok := loadPlaylist(c, m, args[0])
+	if !ok {
+		return td.EndGroups
+	}
+
+	if args[1] == "add" {
+		if userID == playlist.UserID {
+			_, err = m.ReplyText(c, lang.T(chatID, "playlist.collab_owner"), nil)
+			return err
+		}
+		err = db.Instance.AddPlaylistCollaborator(playlistActor(m), playlist.ID, userID)
+		if errors.Is(err, db.ErrTooManyCollaborators) {
+			_, err = m.ReplyText(c, lang.T(chatID, "playlist.collab_limit", db.MaxCollaborators), nil)
+			return err
+		}
+	} else {
+		err = db.Instance.RemovePlaylistCollaborator(playlistActor(m), playlist.ID, userID)
+	}
+	if err != nil {
+		_, err = m.ReplyText(c, playlistErrorText(chatID, err, "playlist.collab_failed"), nil)
+		return err
+	}
+
+	key := "playlist.collab_added"
+	if args[1] == "remove" {
+		key = "playlist.collab_removed"
+	}
+	_, err = m.ReplyText(c, lang.T(chatID, key, userID, html.EscapeString(playlist.Name)), replyOpts)
+	return err
+}
+
+func listCollaborators(c *td.Client, m *td.Message, id string) error {
+	chatID := m.ChatId
+
+	playlist, ok := loadPlaylist(c, m, id)
+	if !ok {
+		return td.EndGroups
+	}
+	if len(playlist.Collaborators) == 0 {
+		_, err := m.ReplyText(c, lang.T(chatID, "playlist.collab_none", html.EscapeString(playlist.Name)), replyOpts)
+		return err
+	}
+
+	var lines []string
+	for _, userID := range playlist.Collaborators {
+		lines = append(lines, lang.T(chatID, "playlist.collab_item", userID, userID))
+	}
+	_, err := m.ReplyText(c, lang.T(chatID, "playlist.collab_list", html.EscapeString(playlist.Name), strings.Join(lines, "\n")), replyOpts)
+	return err
+}
+
+// playlistsHandler handles /playlists. "/playlists search [name]" finds public playlists; without arguments in a
+// group it lists the group's playlists.
+func playlistsHandler(c *td.Client, ctx *td.Context) error {
+	m := ctx.EffectiveMessage
+	chatID := m.ChatId
+
+	sub, query, _ := strings.Cut(Args(m), " ")
+	query = strings.TrimSpace(query)
+
+	var playlists []db.Playlist
+	var err error
+	var header string
+	switch {
+	case sub == "search" && query != "":
+		playlists, err = db.Instance.SearchPublicPlaylists(query)
+		header = lang.T(chatID, "playlist.search_results", html.EscapeString(query))
+	case sub == "" && !m.IsPrivate():
+		playlists, err = db.Instance.GetChatPlaylists(chatID)
+		header = lang.T(chatID, "playlist.group_list")
+	default:
+		_, err = m.ReplyText(c, lang.T(chatID, "playlist.playlists_usage"), replyOpts)
+		return err
+	}
+	if err != nil {
+		_, err = m.ReplyText(c, lang.T(chatID, "playlist.list_failed", err.Error()), nil)
+		return err
+	}
+	if len(playlists) == 0 {
+		_, err = m.ReplyText(c, lang.T(chatID, "playlist.search_none"), nil)
+		return err
+	}
+
+	items := make([]string, 0, len(playlists))
+	for _, p := range playlists {
+		items = append(items, lang.T(chatID, "playlist.list_item", html.EscapeString(p.Name), p.ID, len(p.Songs)))
+	}
+	_, err = m.ReplyText(c, header+"\n\n"+strings.Join(items, "\n"), replyOpts)
+	return err
+}
@@ -9,8 +9,8 @@
 package handlers
 
 import (
+	"ashokshau/tgmusic/src/core/lang"
 	"ashokshau/tgmusic/src/utils"
-	"fmt"
 	"math"
 	"strconv"
 	"strings"
@@ -32,18 +32,18 @@ func queueHandler(c *td.Client, ctx *td.Context) error {
 
 	chat, err := c.GetChat(chatID)
 	if err != nil {
-		_, _ = m.ReplyText(c, "Error fetching chat information.", nil)
+		_, _ = m.ReplyText(c, lang.T(chatID, "queue.chat_failed"), nil)
 		return nil
 	}
 
 	queue := cache.ChatCache.GetQueue(chatID)
 	if len(queue) == 0 {
-		_, _ = m.ReplyText(c, "The queue is empty.", nil)
+		_, _ = m.ReplyText(c, lang.T(chatID, "queue.empty"), nil)
 		return nil
 	}
 
 	if !cache.ChatCache.IsActive(chatID) {
-		_, _ = m.ReplyText(c, "The bot is not streaming in the video chat.", nil)
+		_, _ = m.ReplyText(c, lang.T(chatID, "playback.not_streaming"), nil)
 		return nil
 	}
 
@@ -51,28 +51,27 @@ func queueHandler(c *td.Client, ctx *td.Context) error {
 	playedTime, _ := vc.Calls.PlayedTime(chatID)
 
 	var b strings.Builder
-	b.WriteString(fmt.Sprintf("<b>Queue for %s</b>\n\n", chat.Title))
+	b.WriteString(lang.T(chatID, "queue.header", chat.Title))
 
-	b.WriteString("<b>Now Playing:</b>\n")
-	b.WriteString(fmt.Sprintf("• <b>Title:</b> <code>%s</code>\n", truncate(current.Name, 45)))
-	b.WriteString(fmt.Sprintf("• <b>By:</b> %s\n", current.User))
-	b.WriteString(fmt.Sprintf("• <b>Duration:</b> %s min\n", utils.SecToMin(current.Duration)))
-	b.WriteString("• <b>Loop:</b> ")
+	loop := lang.T(chatID, "common.off")
 	if current.Loop > 0 {
-		b.WriteString("On\n")
-	} else {
-		b.WriteString("Off\n")
+		loop = lang.T(chatID, "common.on")
 	}
-	b.WriteString("• <b>Progress:</b> ")
+	progress := "0:00"
 	if playedTime > 0 && playedTime < math.MaxInt {
-		b.WriteString(utils.SecToMin(int(playedTime)))
-	} else {
-		b.WriteString("0:00")
+		progress = utils.SecToMin(int(playedTime))
 	}
-	b.WriteString(" min\n")
+
+	b.WriteString(lang.T(chatID, "queue.now_playing",
+		truncate(current.Name, 45),
+		current.User,
+		utils.TrackDuration(current.Duration, current.IsLive),
+		loop,
+		progress,
+	))
 
 	if len(queue) > 1 {
-		b.WriteString(fmt.Sprintf("\n<b>Next Up (%d):</b>\n", len(queue)-1))
+		b.WriteString(lang.T(chatID, "queue.next_up", len(queue)-1))
 
 		for i, song := range queue[1:] {
 			if i >= 14 {
@@ -82,33 +81,26 @@ func queueHandler(c *td.Client, ctx *td.Context) error {
 			b.WriteString(". <code>")
 			b.WriteString(truncate(song.Name, 45))
 			b.WriteString("</code> | ")
-			b.WriteString(utils.SecToMin(song.Duration))
-			b.WriteString(" min\n")
+			b.WriteString(utils.TrackDuration(song.Duration, song.IsLive))
+			b.WriteString("\n")
 		}
 
 		if len(queue) > 15 {
-			b.WriteString(fmt.Sprintf("...and %d more tracks\n", len(queue)-15))
+			b.WriteString(lang.T(chatID, "queue.more", len(queue)-15))
 		}
 	}
 
-	b.WriteString(fmt.Sprintf("\n<b>Total:</b> %d tracks", len(queue)))
+	b.WriteString(lang.T(chatID, "queue.total", len(queue)))
 
 	text := b.String()
 	if len(text) > 4096 {
-		var sb strings.Builder
-		progress := "0:00"
-		if playedTime > 0 && playedTime < math.MaxInt {
-			progress = utils.SecToMin(int(playedTime))
-		}
-		sb.WriteString(fmt.Sprintf(
-			"<b>Queue for %s</b>\n\n<b>Now Playing:</b>\n• <code>%s</code>\n• %s/%s min\n\n<b>Total:</b> %d tracks",
+		text = lang.T(chatID, "queue.compact",
 			chat.Title,
 			truncate(current.Name, 45),
 			progress,
-			utils.SecToMin(current.Duration),
+			utils.TrackDuration(current.Duration, current.IsLive),
 			len(queue),
-		))
-		text = sb.String()
+		)
 	}
 
 	_, err = m.ReplyText(c, text, replyOpts)
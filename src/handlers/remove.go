@@ -9,7 +9,7 @@
 package handlers
 
 import (
-	"fmt"
+	"ashokshau/tgmusic/src/core/lang"
 	"strconv"
 
 	"ashokshau/tgmusic/src/core/cache"
@@ -27,34 +27,34 @@ func removeHandler(c *td.Client, ctx *td.Context) error {
 	m := ctx.EffectiveMessage
 
 	if !cache.ChatCache.IsActive(chatID) {
-		_, _ = m.ReplyText(c, "The bot is not streaming in the video chat.", nil)
+		_, _ = m.ReplyText(c, lang.T(chatID, "playback.not_streaming"), nil)
 		return nil
 	}
 
 	queue := cache.ChatCache.GetQueue(chatID)
 	if len(queue) == 0 {
-		_, _ = m.ReplyText(c, "The queue is currently empty.", nil)
+		_, _ = m.ReplyText(c, lang.T(chatID, "queue.empty"), nil)
 		return nil
 	}
 
 	args := Args(m)
 	if args == "" {
-		_, _ = m.ReplyText(c, "<b>Usage:</b> <code>/remove [track number]</code>\n\nUse <code>1</code> to remove the first track, <code>2</code> for the second, and so on.", replyOpts)
+		_, _ = m.ReplyText(c, lang.T(chatID, "remove.usage"), replyOpts)
 		return nil
 	}
 
 	trackNum, err := strconv.Atoi(args)
 	if err != nil {
-		_, _ = m.ReplyText(c, "Please provide a valid track number.", nil)
+		_, _ = m.ReplyText(c, lang.T(chatID, "remove.invalid"), nil)
 		return nil
 	}
 
 	if trackNum <= 0 || trackNum > len(queue) {
-		_, _ = m.ReplyText(c, fmt.Sprintf("Invalid track number. Please choose a number between 1 and %d.", len(queue)), nil)
+		_, _ = m.ReplyText(c, lang.T(chatID, "remove.range", len(queue)), nil)
 		return nil
 	}
 
 	cache.ChatCache.RemoveTrack(chatID, trackNum)
-	_, err = m.ReplyText(c, fmt.Sprintf("Track #%d has been removed by %s.", trackNum, firstName(c, m)), replyOpts)
+	_, err = m.ReplyText(c, lang.T(chatID, "remove.done", trackNum, firstName(c, m)), replyOpts)
 	return err
 }
@@ -9,8 +9,8 @@
 package handlers
 
 import (
+	"ashokshau/tgmusic/src/core/lang"
 	"ashokshau/tgmusic/src/utils"
-	"fmt"
 	"strconv"
 
 	"ashokshau/tgmusic/src/core/cache"
@@ -28,42 +28,47 @@ func seekHandler(c *td.Client, ctx *td.Context) error {
 	m := ctx.EffectiveMessage
 
 	if !cache.ChatCache.IsActive(chatID) {
-		_, err := m.ReplyText(c, "The bot is not streaming in the video chat.", nil)
+		_, err := m.ReplyText(c, lang.T(chatID, "playback.not_streaming"), nil)
 		return err
 	}
 
 	playingSong := cache.ChatCache.GetPlayingTrack(chatID)
 	if playingSong == nil {
-		_, err := m.ReplyText(c, "The bot is not streaming in the video chat.", nil)
+		_, err := m.ReplyText(c, lang.T(chatID, "playback.not_streaming"), nil)
 		return err
 	}
 
+	if playingSong.IsLive {
+		_, _ = m.ReplyText(c, lang.T(chatID, "seek.live"), nil)
+		return nil
+	}
+
 	args := Args(m)
 	if args == "" {
-		_, _ = m.ReplyText(c, "<b>Usage:</b> /seek duration\n<b>Example:</b> <code>/seek 15</code>", replyOpts)
+		_, _ = m.ReplyText(c, lang.T(chatID, "seek.usage"), replyOpts)
 		return nil
 	}
 
 	seekTime, err := strconv.Atoi(args)
 	if err != nil {
-		_, _ = m.ReplyText(c, "Invalid seek time provided. Please use a valid number of seconds.", nil)
+		_, _ = m.ReplyText(c, lang.T(chatID, "seek.invalid"), nil)
 		return nil
 	}
 
 	if seekTime < 10 {
-		_, _ = m.ReplyText(c, "Minimum seek time is 10 seconds.", nil)
+		_, _ = m.ReplyText(c, lang.T(chatID, "seek.minimum"), nil)
 		return nil
 	}
 
 	currDur, err := vc.Calls.PlayedTime(chatID)
 	if err != nil {
-		_, _ = m.ReplyText(c, "Failed to fetch the duration of the ongoing stream.", nil)
+		_, _ = m.ReplyText(c, lang.T(chatID, "seek.duration_failed"), nil)
 		return nil
 	}
 
 	toSeek := int(currDur) + seekTime
-	if toSeek >= playingSong.Duration {
-		_, _ = m.ReplyText(c, fmt.Sprintf("You cannot seek beyond the track duration. Maximum allowed is %s.", utils.SecToMin(playingSong.Duration)), nil)
+	if playingSong.Duration > 0 && toSeek >= playingSong.Duration {
+		_, _ = m.ReplyText(c, lang.T(chatID, "seek.beyond", utils.SecToMin(playingSong.Duration)), nil)
 		return nil
 	}
 
@@ -74,10 +79,10 @@ func seekHandler(c *td.Client, ctx *td.Context) error {
 		playingSong.Duration,
 		playingSong.IsVideo,
 	); err != nil {
-		_, _ = m.ReplyText(c, fmt.Sprintf("An error occurred while seeking the track: %s", err.Error()), replyOpts)
+		_, _ = m.ReplyText(c, lang.T(chatID, "seek.failed", err.Error()), replyOpts)
 		return nil
 	}
 
-	_, _ = m.ReplyText(c, fmt.Sprintf("<b>Stream skipped %s and started from %s seconds by</b> %s", utils.SecToMin(seekTime), utils.SecToMin(toSeek), firstName(c, m)), replyOpts)
+	_, _ = m.ReplyText(c, lang.T(chatID, "seek.done", utils.SecToMin(seekTime), utils.SecToMin(toSeek), firstName(c, m)), replyOpts)
 	return nil
 }
@@ -9,8 +9,9 @@
 package handlers
 
 import (
+	"ashokshau/tgmusic/src/core/lang"
 	"ashokshau/tgmusic/src/utils"
-	"fmt"
+	"html"
 	"strings"
 
 	"ashokshau/tgmusic/src/core"
@@ -21,12 +22,16 @@ import (
 )
 
 func settingsHandler(c *td.Client, ctx *td.Context) error {
+	m := ctx.EffectiveMessage
+	if m.IsPrivate() {
+		_, err := m.ReplyText(c, lang.T(m.ChatId, "settings.group_only"), nil)
+		return err
+	}
+
 	if !adminMode(c, ctx) {
 		return td.EndGroups
 	}
 
-	m := ctx.EffectiveMessage
-
 	chatID := ctx.EffectiveChatId
 	admins, err := cache.GetAdmins(c, chatID, false)
 	if err != nil {
@@ -46,29 +51,34 @@ func settingsHandler(c *td.Client, ctx *td.Context) error {
 		return nil
 	}
 
-	// Get current settings
-	getPlayMode := db.Instance.GetPlayMode(chatID)
-	playModeStr := utils.Everyone
-	if getPlayMode {
-		playModeStr = utils.Admins
-	}
-	getAdminMode := db.Instance.GetAdminMode(chatID)
-	cmdDelete := db.Instance.GetCmdDelete(chatID)
-	language, _ := db.Instance.GetLanguage(chatID)
-
 	chat, err := m.GetChat(c)
 	if err != nil {
 		c.Logger.Warn("Failed to get chat", "error", err)
 		return nil
 	}
 
-	text := fmt.Sprintf("<u><b>%s settings</b></u>\n\nClick the buttons below to change this chat's current settings.",
-		chat.Title)
-
-	_, err = m.ReplyText(c, text, &td.SendTextMessageOpts{ReplyMarkup: core.SettingsKeyboard(playModeStr, getAdminMode, cmdDelete, language), ParseMode: td.ParseModeHTML})
+	text := lang.T(chatID, "settings.title", html.EscapeString(chat.Title))
+	_, err = m.ReplyText(c, text, &td.SendTextMessageOpts{ReplyMarkup: settingsKeyboard(chatID), ParseMode: td.ParseModeHTML})
 	return err
 }
 
+// settingsKeyboard builds the settings panel from the chat's current settings.
+func settingsKeyboard(chatID int64) *td.ReplyMarkupInlineKeyboard {
+	playModeStr := utils.Everyone
+	if db.Instance.GetPlayMode(chatID) {
+		playModeStr = utils.Admins
+	}
+
+	return core.SettingsKeyboard(
+		playModeStr,
+		db.Instance.GetAdminMode(chatID),
+		db.Instance.GetCmdDelete(chatID),
+		lang.ChatLanguage(chatID),
+		db.Instance.GetVideoQuality(chatID),
+		db.Instance.GetAudioProfile(chatID),
+	)
+}
+
 func settingsCallbackHandler(c *td.Client, ctx *td.Context) error {
 	chatID := ctx.EffectiveChatId
 	cb := ctx.Update.UpdateNewCallbackQuery
@@ -89,14 +99,14 @@ func settingsCallbackHandler(c *td.Client, ctx *td.Context) error {
 	}
 
 	if !hasPerms {
-		err = cb.Answer(c, 0, true, "You don't have permission to change settings.", "")
+		err = cb.Answer(c, 0, true, lang.T(chatID, "settings.no_permission"), "")
 		return err
 	}
 
 	// Process the callback data
 	data := cb.DataString()
 	if data == "settings_main" {
-		return cb.Answer(c, 0, false, "Update your chat settings", "")
+		return cb.Answer(c, 0, false, lang.T(chatID, "settings.hint"), "")
 	}
 
 	parts := strings.Split(data, "_")
@@ -120,20 +130,31 @@ func settingsCallbackHandler(c *td.Client, ctx *td.Context) error {
 			newMode = utils.Admins
 		}
 		_ = db.Instance.SetAdminMode(chatID, newMode)
+	case "vres":
+		quality := db.Instance.GetVideoQuality(chatID)
+		quality.Height = utils.NextOption(utils.VideoHeights, quality.Height)
+		_ = db.Instance.SetVideoQuality(chatID, quality)
+	case "vfps":
+		quality := db.Instance.GetVideoQuality(chatID)
+		quality.Fps = utils.NextOption(utils.VideoFrameRates, quality.Fps)
+		_ = db.Instance.SetVideoQuality(chatID, quality)
+	case "audio":
+		audio := db.Instance.GetAudioProfile(chatID)
+		_ = db.Instance.SetAudioProfile(chatID, utils.NextAudioProfile(audio.Name).Name)
 	case "lang":
-		return cb.Answer(c, 0, true, "Language selection is not yet implemented via this menu.", "")
+		_, err = cb.EditMessageText(c, lang.T(chatID, "lang.choose"), &td.EditTextMessageOpts{ReplyMarkup: core.LanguageKeyboard("settings_setlang_", lang.ChatLanguage(chatID), "settings_refresh")})
+		return err
+	case "setlang":
+		if len(parts) < 3 || !lang.IsSupported(parts[2]) {
+			return cb.Answer(c, 0, true, lang.T(chatID, "lang.unsupported_short"), "")
+		}
+		if err := setChatLanguage(chatID, parts[2]); err != nil {
+			return cb.Answer(c, 0, true, lang.T(chatID, "lang.save_failed"), "")
+		}
+	case "refresh":
 	default:
-		return cb.Answer(c, 0, true, "Unknown setting", "")
-	}
-
-	getPlayMode := db.Instance.GetPlayMode(chatID)
-	playModeStr := utils.Everyone
-	if getPlayMode {
-		playModeStr = utils.Admins
+		return cb.Answer(c, 0, true, lang.T(chatID, "settings.unknown"), "")
 	}
-	getAdminMode := db.Instance.GetAdminMode(chatID)
-	cmdDelete := db.Instance.GetCmdDelete(chatID)
-	language, _ := db.Instance.GetLanguage(chatID)
 
 	chat, err := c.GetChat(chatID)
 	if err != nil {
@@ -141,14 +162,12 @@ func settingsCallbackHandler(c *td.Client, ctx *td.Context) error {
 		return nil
 	}
 
-	text := fmt.Sprintf("<u><b>%s settings</b></u>\n\nClick the buttons below to change this chat's current settings.",
-		chat.Title)
-
-	_, err = cb.EditMessageText(c, text, &td.EditTextMessageOpts{ReplyMarkup: core.SettingsKeyboard(playModeStr, getAdminMode, cmdDelete, language), ParseMode: td.ParseModeHTML})
+	text := lang.T(chatID, "settings.title", html.EscapeString(chat.Title))
+	_, err = cb.EditMessageText(c, text, &td.EditTextMessageOpts{ReplyMarkup: settingsKeyboard(chatID), ParseMode: td.ParseModeHTML})
 	if err != nil {
 		return err
 	}
 
-	_ = cb.Answer(c, 0, false, "Settings updated", "")
+	_ = cb.Answer(c, 0, false, lang.T(chatID, "settings.updated"), "")
 	return nil
 }
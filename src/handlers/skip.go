@@ -10,6 +10,7 @@ package handlers
 
 import (
 	"ashokshau/tgmusic/src/core/cache"
+	"ashokshau/tgmusic/src/core/lang"
 	"ashokshau/tgmusic/src/vc"
 
 	td "github.com/AshokShau/gotdbot"
@@ -25,7 +26,7 @@ func skipHandler(c *td.Client, ctx *td.Context) error {
 	chatID := ctx.EffectiveChatId
 
 	if !cache.ChatCache.IsActive(chatID) {
-		_, _ = m.ReplyText(c, "The bot is not streaming in the video chat.", nil)
+		_, _ = m.ReplyText(c, lang.T(chatID, "playback.not_streaming"), nil)
 		return nil
 	}
 
@@ -9,7 +9,7 @@
 package handlers
 
 import (
-	"fmt"
+	"ashokshau/tgmusic/src/core/lang"
 	"strconv"
 
 	"ashokshau/tgmusic/src/core/cache"
@@ -27,32 +27,38 @@ func speedHandler(c *td.Client, ctx *td.Context) error {
 	m := ctx.EffectiveMessage
 
 	if !cache.ChatCache.IsActive(chatID) {
-		_, err := m.ReplyText(c, "The bot is not streaming in the video chat.", nil)
+		_, err := m.ReplyText(c, lang.T(chatID, "playback.not_streaming"), nil)
 		return err
 	}
 
-	if playingSong := cache.ChatCache.GetPlayingTrack(chatID); playingSong == nil {
-		_, err := m.ReplyText(c, "The bot is not streaming in the video chat.", nil)
+	playingSong := cache.ChatCache.GetPlayingTrack(chatID)
+	if playingSong == nil {
+		_, err := m.ReplyText(c, lang.T(chatID, "playback.not_streaming"), nil)
+		return err
+	}
+
+	if playingSong.IsLive {
+		_, err := m.ReplyText(c, lang.T(chatID, "speed.live"), nil)
 		return err
 	}
 
 	args := Args(m)
 	if args == "" {
-		_, _ = m.ReplyText(c, "<b>Change Playback Speed</b>\n\n<b>Usage:</b> <code>/speed [value]</code>\n\nThe speed can be set between <code>0.5</code> and <code>4.0</code>.", replyOpts)
+		_, _ = m.ReplyText(c, lang.T(chatID, "speed.usage"), replyOpts)
 		return nil
 	}
 
 	speed, err := strconv.ParseFloat(args, 64)
 	if err != nil {
-		_, _ = m.ReplyText(c, "Invalid speed value. Please provide a number between 0.5 and 4.0.", nil)
+		_, _ = m.ReplyText(c, lang.T(chatID, "speed.invalid"), nil)
 		return nil
 	}
 
 	if err = vc.Calls.ChangeSpeed(chatID, speed); err != nil {
-		_, _ = m.ReplyText(c, fmt.Sprintf("An error occurred while changing the speed: %s", err.Error()), replyOpts)
+		_, _ = m.ReplyText(c, lang.T(chatID, "speed.failed", err.Error()), replyOpts)
 		return nil
 	}
 
-	_, _ = m.ReplyText(c, fmt.Sprintf("Playback speed has been set to <code>%.2fx</code>.", speed), replyOpts)
+	_, _ = m.ReplyText(c, lang.T(chatID, "speed.done", speed), replyOpts)
 	return nil
 }
@@ -10,7 +10,7 @@ package handlers
 
 import (
 	"ashokshau/tgmusic/config"
-	"fmt"
+	"ashokshau/tgmusic/src/core/lang"
 	"runtime"
 	"time"
 
@@ -25,7 +25,7 @@ func pingHandler(c *td.Client, ctx *td.Context) error {
 	m := ctx.EffectiveMessage
 	start := time.Now()
 
-	msg, err := m.ReplyText(c, "Pinging… please wait…", nil)
+	msg, err := m.ReplyText(c, lang.T(m.ChatId, "ping.start"), nil)
 	if err != nil {
 		return err
 	}
@@ -33,13 +33,7 @@ func pingHandler(c *td.Client, ctx *td.Context) error {
 	latency := time.Since(start).Milliseconds()
 	uptime := getFormattedDuration(time.Since(startTime))
 
-	response := fmt.Sprintf(
-		"<b>📊 System Performance Metrics</b>\n\n"+
-			"<b>Bot Latency:</b> <code>%d ms</code>\n"+
-			"<b>Uptime:</b> <code>%s</code>\n"+
-			"<b>Go Routines:</b> <code>%d</code>\n",
-		latency, uptime, runtime.NumGoroutine(),
-	)
+	response := lang.T(m.ChatId, "ping.result", latency, uptime, runtime.NumGoroutine())
 
 	_, err = msg.EditText(c, response, &td.EditTextMessageOpts{ParseMode: "HTML"})
 	return err
@@ -54,11 +48,7 @@ func startHandler(c *td.Client, ctx *td.Context) error {
 			_ = db.Instance.AddUser(chatID)
 		}(chatID)
 
-		response := fmt.Sprintf(
-			"Hey %s,\nThis is %s !\n\n<b>Supported Platforms:</b> YouTube, Spotify, Apple Music, SoundCloud, MXPlayer, Deezer, Twitch, Kick....\n\n<b><i>Click on the help button for more info.</i></b>",
-			firstName(c, m),
-			c.Me.FirstName,
-		)
+		response := lang.T(chatID, "start.private", firstName(c, m), c.Me.FirstName)
 
 		_, err := m.ReplyPhoto(c, td.InputFileRemote{Id: config.Conf.StartImg}, &td.SendPhotoOpts{
 			ParseMode:   "HTML",
@@ -74,13 +64,7 @@ func startHandler(c *td.Client, ctx *td.Context) error {
 	}(chatID)
 
 	uptime := getFormattedDuration(time.Since(startTime))
-	response := fmt.Sprintf(
-		"<b>🎵 %s is ready</b>\n"+
-			"<b>Uptime:</b> <code>%s</code>\n\n"+
-			"<i>A music player bot with some awesome and useful features.</i>",
-		c.Me.FirstName,
-		uptime,
-	)
+	response := lang.T(chatID, "start.group", c.Me.FirstName, uptime)
 
 	_, err := m.ReplyText(c, response, &td.SendTextMessageOpts{
 		ParseMode:             "HTML",
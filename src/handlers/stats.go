@@ -9,6 +9,7 @@
 package handlers
 
 import (
+	"ashokshau/tgmusic/src/utils"
 	"ashokshau/tgmusic/src/vc"
 	"fmt"
 	"os"
@@ -190,6 +191,17 @@ func statsHandler(c *td.Client, ctx *td.Context) error {
 	chats, _ := db.Instance.GetAllChats()
 	users, _ := db.Instance.GetAllUsers()
 	ntgCpuUsage, _ := vc.Calls.CpuUsage(chatID)
+	activeCalls := vc.Calls.ActiveCallCount()
+
+	audio := utils.DefaultAudioProfile()
+	if chatID != 0 {
+		audio = db.Instance.GetAudioProfile(chatID)
+	}
+
+	perCallLine := "• CPU per call: N/A (no active calls)\n"
+	if usage, calls := vc.Calls.CallsCpuUsage(); calls > 0 {
+		perCallLine = fmt.Sprintf("• CPU per call: ~%.2f%% (%d active)\n", usage/float64(calls), activeCalls)
+	}
 
 	memLine := fmt.Sprintf("• Ram usage: %s\n", stats.AppMemUsed)
 	if stats.MemLimit != "" {
@@ -210,6 +222,8 @@ func statsHandler(c *td.Client, ctx *td.Context) error {
 			"• CPU usage: %s\n"+
 			"• NTG Calls CPU: %.2f%%\n"+
 			"%s"+
+			"• Audio profile: %s\n"+
+			"%s"+
 			"• Heap: %s\n"+
 			"• GC Runs: %d (pause %s)\n\n"+
 			"<b>Database</b>\n"+
@@ -231,6 +245,8 @@ func statsHandler(c *td.Client, ctx *td.Context) error {
 		stats.GoVersion,
 		stats.AppCPU,
 		ntgCpuUsage,
+		perCallLine,
+		audio.String(),
 
 		memLine,
 
@@ -9,9 +9,8 @@
 package handlers
 
 import (
-	"fmt"
-
 	"ashokshau/tgmusic/src/core/cache"
+	"ashokshau/tgmusic/src/core/lang"
 	"ashokshau/tgmusic/src/vc"
 
 	td "github.com/AshokShau/gotdbot"
@@ -26,11 +25,11 @@ func stopHandler(c *td.Client, ctx *td.Context) error {
 	chatID := ctx.EffectiveChatId
 
 	if !cache.ChatCache.IsActive(chatID) {
-		_, _ = m.ReplyText(c, "The bot isn't streaming in the video chat.", nil)
+		_, _ = m.ReplyText(c, lang.T(chatID, "playback.not_streaming"), nil)
 		return nil
 	}
 
 	_ = vc.Calls.Stop(chatID)
-	_, _ = m.ReplyText(c, fmt.Sprintf("<b>Stream ended by</b> %s", firstName(c, m)), replyOpts)
+	_, _ = m.ReplyText(c, lang.T(chatID, "stop.done", firstName(c, m)), replyOpts)
 	return nil
 }
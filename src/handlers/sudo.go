@@ -0,0 +1,125 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package handlers
+
+import (
+	"ashokshau/tgmusic/config"
+	"fmt"
+	"strings"
+
+	"ashokshau/tgmusic/src/core/db"
+
+	td "github.com/AshokShau/gotdbot"
+)
+
+// isOwner checks if the message was sent by the bot owner.
+func isOwner(ctx *td.Context) bool {
+	return config.Conf.OwnerId != 0 && ctx.EffectiveMessage.SenderID() == config.Conf.OwnerId
+}
+
+// addSudoHandler handles the /addsudo command and grants developer access to a user.
+func addSudoHandler(c *td.Client, ctx *td.Context) error {
+	if !isOwner(ctx) {
+		return td.EndGroups
+	}
+
+	m := ctx.EffectiveMessage
+	userID, err := getTargetUserID(c, m)
+	if err != nil {
+		_, _ = m.ReplyText(c, err.Error(), nil)
+		return td.EndGroups
+	}
+
+	if config.Conf.IsDev(userID) {
+		_, _ = m.ReplyText(c, "This user is already a developer.", nil)
+		return td.EndGroups
+	}
+
+	if err = db.Instance.AddSudo(userID); err != nil {
+		_, _ = m.ReplyText(c, fmt.Sprintf("Failed to add the sudo user: %s", err.Error()), nil)
+		return td.EndGroups
+	}
+
+	config.Conf.AddSudo(userID)
+	_, err = m.ReplyText(c, fmt.Sprintf("User <code>%d</code> is now a sudo user.", userID), replyOpts)
+	return err
+}
+
+// removeSudoHandler handles the /rmsudo command. The owner and DEVS from the environment cannot be removed.
+func removeSudoHandler(c *td.Client, ctx *td.Context) error {
+	if !isOwner(ctx) {
+		return td.EndGroups
+	}
+
+	m := ctx.EffectiveMessage
+	userID, err := getTargetUserID(c, m)
+	if err != nil {
+		_, _ = m.ReplyText(c, err.Error(), nil)
+		return td.EndGroups
+	}
+
+	if userID == config.Conf.OwnerId {
+		_, _ = m.ReplyText(c, "The owner cannot be removed.", nil)
+		return td.EndGroups
+	}
+
+	if config.Conf.IsEnvDev(userID) {
+		_, _ = m.ReplyText(c, "This developer is set through the DEVS variable. Remove them there and restart.", nil)
+		return td.EndGroups
+	}
+
+	if !config.Conf.IsDev(userID) {
+		_, _ = m.ReplyText(c, "This user is not a sudo user.", nil)
+		return td.EndGroups
+	}
+
+	if err = db.Instance.RemoveSudo(userID); err != nil {
+		_, _ = m.ReplyText(c, fmt.Sprintf("Failed to remove the sudo user: %s", err.Error()), nil)
+		return td.EndGroups
+	}
+
+	config.Conf.RemoveSudo(userID)
+	_, err = m.ReplyText(c, fmt.Sprintf("User <code>%d</code> is no longer a sudo user.", userID), replyOpts)
+	return err
+}
+
+// sudoListHandler handles the /sudolist command.
+func sudoListHandler(c *td.Client, ctx *td.Context) error {
+	if !isOwner(ctx) {
+		return td.EndGroups
+	}
+
+	m := ctx.EffectiveMessage
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<b>Owner:</b> <a href=\"tg://user?id=%d\">%d</a>\n", config.Conf.OwnerId, config.Conf.OwnerId))
+
+	var devs []int64
+	for _, id := range config.Conf.DEVS {
+		if id != config.Conf.OwnerId {
+			devs = append(devs, id)
+		}
+	}
+	writeUserSection(&sb, "Developers (DEVS)", devs)
+	writeUserSection(&sb, "Sudo Users", config.Conf.Sudoers())
+
+	_, err := m.ReplyText(c, sb.String(), replyOpts)
+	return err
+}
+
+func writeUserSection(sb *strings.Builder, title string, ids []int64) {
+	sb.WriteString(fmt.Sprintf("\n<b>%s</b> (%d)\n", title, len(ids)))
+	if len(ids) == 0 {
+		sb.WriteString("None\n")
+		return
+	}
+
+	for _, id := range ids {
+		sb.WriteString(fmt.Sprintf("• <a href=\"tg://user?id=%d\">%d</a>\n", id, id))
+	}
+}
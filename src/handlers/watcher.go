@@ -11,7 +11,9 @@ package handlers
 import (
 	"ashokshau/tgmusic/src/core"
 	"ashokshau/tgmusic/src/core/cache"
-	"fmt"
+	"ashokshau/tgmusic/src/core/lang"
+	"ashokshau/tgmusic/src/vc"
+	"ashokshau/tgmusic/src/vc/ubot/types"
 	"time"
 
 	td "github.com/AshokShau/gotdbot"
@@ -23,10 +25,7 @@ func handleVoiceChatMessage(c *td.Client, ctx *td.Context) error {
 	chatID := ctx.EffectiveChatId
 
 	if m.IsGroup() {
-		text := fmt.Sprintf(
-			"This chat (%d) is not a supergroup yet.\n<b>⚠️ Please convert this chat to a supergroup and add me as admin.</b>\n\nIf you don't know how to convert, use this guide:\n🔗 https://te.legra.ph/How-to-Convert-a-Group-to-a-Supergroup-01-02\n\nIf you have any questions, join our support group:",
-			chatID,
-		)
+		text := lang.T(chatID, "watcher.not_supergroup", chatID)
 
 		_, _ = c.SendTextMessage(chatID, text, &td.SendTextMessageOpts{
 			ReplyMarkup:           core.AddMeMarkup(c.Me.Usernames.EditableUsername),
@@ -46,10 +45,10 @@ func handleVoiceChatMessage(c *td.Client, ctx *td.Context) error {
 	switch m.Content.(type) {
 	case *td.MessageVideoChatStarted:
 		cache.ChatCache.ClearChat(chatID)
-		message = "🎙️ Video chat started!\nUse /play <song name> to play music."
+		message = lang.T(chatID, "watcher.vc_started")
 	case *td.MessageVideoChatEnded:
-		cache.ChatCache.ClearChat(chatID)
-		message = "🎧 Video chat ended!\nAll queues cleared."
+		vc.Calls.DispatchCallEvent(types.CallEvent{Type: types.CallDiscarded, ChatId: chatID})
+		return td.EndGroups
 	default:
 		return nil
 	}
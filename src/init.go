@@ -12,6 +12,7 @@ import (
 	"ashokshau/tgmusic/config"
 	"ashokshau/tgmusic/src/core/db"
 	"ashokshau/tgmusic/src/vc"
+	"log/slog"
 
 	"github.com/AshokShau/gotdbot"
 )
@@ -21,6 +22,22 @@ func Init(client *gotdbot.Client) error {
 		return err
 	}
 
+	sudoers, err := db.Instance.GetSudoers()
+	if err != nil {
+		return err
+	}
+	config.Conf.SetSudoers(sudoers)
+
+	settings, err := db.Instance.GetSettings()
+	if err != nil {
+		return err
+	}
+	for key, value := range settings {
+		if err := config.Conf.Set(key, value); err != nil {
+			slog.Warn("Ignoring invalid runtime setting", "key", key, "error", err)
+		}
+	}
+
 	for _, session := range config.Conf.SessionStrings {
 		_, err := vc.Calls.StartClient(config.Conf.ApiId, config.Conf.ApiHash, session)
 		if err != nil {
@@ -28,6 +45,16 @@ func Init(client *gotdbot.Client) error {
 		}
 	}
 
+	stored, err := db.Instance.GetAssistantSessions()
+	if err != nil {
+		return err
+	}
+	for _, s := range stored {
+		if _, err := vc.Calls.StartClient(config.Conf.ApiId, config.Conf.ApiHash, s.Session); err != nil {
+			slog.Warn("Failed to start a stored assistant", "assistant", s.ID, "error", err)
+		}
+	}
+
 	vc.Calls.RegisterHandlers(client)
 	return nil
 }
@@ -126,3 +126,12 @@ func SecToMin(seconds int) string {
 
 	return fmt.Sprintf("%d:%02d", m, s)
 }
+
+// TrackDuration formats a track duration for display, labelling live streams instead of showing 0:00.
+func TrackDuration(seconds int, live bool) string {
+	if live {
+		return "🔴 LIVE"
+	}
+
+	return SecToMin(seconds)
+}
@@ -11,18 +11,20 @@ package utils
 // CachedTrack defines the structure for a track that is stored in the queue.
 // It includes metadata such as the track's URL, name, duration, and the user who requested it.
 type CachedTrack struct {
-	URL       string `json:"url"`
-	Name      string `json:"name"`
-	Loop      int    `json:"loop"`
-	User      string `json:"user"`
-	FilePath  string `json:"file_path"`
-	Thumbnail string `json:"thumbnail"`
-	TrackID   string `json:"track_id"`
-	Duration  int    `json:"duration"`
-	Channel   string `json:"channel"`
-	Views     string `json:"views"`
-	IsVideo   bool   `json:"is_video"`
-	Platform  string `json:"platform"`
+	URL       string       `json:"url"`
+	Name      string       `json:"name"`
+	Loop      int          `json:"loop"`
+	User      string       `json:"user"`
+	FilePath  string       `json:"file_path"`
+	Thumbnail string       `json:"thumbnail"`
+	TrackID   string       `json:"track_id"`
+	Duration  int          `json:"duration"`
+	Channel   string       `json:"channel"`
+	Views     string       `json:"views"`
+	IsVideo   bool         `json:"is_video"`
+	IsLive    bool         `json:"is_live"`
+	Platform  string       `json:"platform"`
+	Quality   VideoQuality `json:"quality"`
 }
 
 // TrackInfo holds detailed information about a specific track, including its CDN URL, cover art, and lyrics.
@@ -44,6 +46,7 @@ type MusicTrack struct {
 	Duration  int    `json:"duration"`
 	Channel   string `json:"channel"`
 	Views     string `json:"views"`
+	IsLive    bool   `json:"is_live"`
 	Platform  string `json:"platform"`
 }
 
@@ -78,9 +81,11 @@ const (
 // FFProbeFormat defines the structure for parsing the format information from ffprobe's JSON output.
 type FFProbeFormat struct {
 	Format struct {
-		Duration string `json:"duration"`
-		Tags     struct {
-			Title string `json:"title"`
+		FormatName string `json:"format_name"`
+		Duration   string `json:"duration"`
+		Tags       struct {
+			Title   string `json:"title"`
+			IcyName string `json:"icy-name"`
 		} `json:"tags,omitempty"`
 	} `json:"format"`
 }
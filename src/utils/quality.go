@@ -0,0 +1,151 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package utils
+
+import (
+	"ashokshau/tgmusic/config"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// VideoQuality is a video streaming profile: the maximum height and the frame rate.
+type VideoQuality struct {
+	Height int `json:"height" bson:"height"`
+	Fps    int `json:"fps" bson:"fps"`
+}
+
+var (
+	// VideoHeights lists the supported video resolutions.
+	VideoHeights = []int{360, 480, 720, 1080}
+	// VideoFrameRates lists the supported video frame rates.
+	VideoFrameRates = []int{15, 24, 30}
+	// DefaultVideoQuality is used when a chat has not picked a profile.
+	DefaultVideoQuality = VideoQuality{Height: 720, Fps: 30}
+)
+
+// IsZero reports whether no profile has been set.
+func (q VideoQuality) IsZero() bool {
+	return q.Height == 0 && q.Fps == 0
+}
+
+// Valid reports whether both the height and the frame rate are supported values.
+func (q VideoQuality) Valid() bool {
+	return slices.Contains(VideoHeights, q.Height) && slices.Contains(VideoFrameRates, q.Fps)
+}
+
+// Or fills unset or unsupported fields from def.
+func (q VideoQuality) Or(def VideoQuality) VideoQuality {
+	if !slices.Contains(VideoHeights, q.Height) {
+		q.Height = def.Height
+	}
+	if !slices.Contains(VideoFrameRates, q.Fps) {
+		q.Fps = def.Fps
+	}
+	return q
+}
+
+// Width returns the even 16:9 width that matches the profile height.
+func (q VideoQuality) Width() int {
+	return (q.Height * 16 / 9) &^ 1
+}
+
+// String formats the profile as, for example, "720p30".
+func (q VideoQuality) String() string {
+	return fmt.Sprintf("%dp%d", q.Height, q.Fps)
+}
+
+// ParseVideoHeight parses a resolution such as "480" or "480p" and reports whether it is supported.
+func ParseVideoHeight(s string) (int, bool) {
+	height, err := strconv.Atoi(strings.TrimSuffix(strings.ToLower(strings.TrimSpace(s)), "p"))
+	if err != nil || !slices.Contains(VideoHeights, height) {
+		return 0, false
+	}
+	return height, true
+}
+
+// ParseQualityFlag strips a leading "-q HEIGHT" flag from command arguments and applies the height to quality.
+// Arguments without the flag are returned unchanged; ok is false when the flag has no height or an unsupported one.
+func ParseQualityFlag(args string, quality VideoQuality) (string, VideoQuality, bool) {
+	fields := strings.Fields(args)
+	if len(fields) == 0 || fields[0] != "-q" {
+		return args, quality, true
+	}
+
+	if len(fields) < 2 {
+		return "", quality, false
+	}
+
+	height, ok := ParseVideoHeight(fields[1])
+	if !ok {
+		return "", quality, false
+	}
+
+	quality.Height = height
+	return strings.Join(fields[2:], " "), quality, true
+}
+
+// NextOption returns the value following current in options, wrapping around to the first one.
+func NextOption(options []int, current int) int {
+	i := slices.Index(options, current)
+	return options[(i+1)%len(options)]
+}
+
+// AudioProfile is an audio streaming profile: the output sample rate, channel count and download bitrate.
+type AudioProfile struct {
+	Name       string
+	SampleRate int
+	Channels   int
+	Bitrate    int // Bitrate is the highest source bitrate, in kbps, picked when downloading.
+}
+
+// AudioProfiles lists the supported audio profiles, from the highest to the lowest quality.
+var AudioProfiles = []AudioProfile{
+	{Name: config.AudioStereo48, SampleRate: 48000, Channels: 2, Bitrate: 160},
+	{Name: config.AudioMono48, SampleRate: 48000, Channels: 1, Bitrate: 128},
+	{Name: config.AudioMono24, SampleRate: 24000, Channels: 1, Bitrate: 64},
+}
+
+// GetAudioProfile looks up an audio profile by name.
+func GetAudioProfile(name string) (AudioProfile, bool) {
+	for _, profile := range AudioProfiles {
+		if profile.Name == name {
+			return profile, true
+		}
+	}
+	return AudioProfile{}, false
+}
+
+// DefaultAudioProfile returns the globally configured audio profile.
+func DefaultAudioProfile() AudioProfile {
+	if profile, ok := GetAudioProfile(config.Conf.AudioProfile()); ok {
+		return profile
+	}
+	return AudioProfiles[0]
+}
+
+// NextAudioProfile returns the profile following name, wrapping around to the first one.
+func NextAudioProfile(name string) AudioProfile {
+	for i, profile := range AudioProfiles {
+		if profile.Name == name {
+			return AudioProfiles[(i+1)%len(AudioProfiles)]
+		}
+	}
+	return AudioProfiles[0]
+}
+
+// String formats the profile as, for example, "48 kHz stereo".
+func (p AudioProfile) String() string {
+	layout := "stereo"
+	if p.Channels == 1 {
+		layout = "mono"
+	}
+	return fmt.Sprintf("%d kHz %s", p.SampleRate/1000, layout)
+}
@@ -0,0 +1,74 @@
+package utils
+
+import "testing"
+
+func TestParseVideoHeight(t *testing.T) {
+	tests := []struct {
+		in     string
+		height int
+		ok     bool
+	}{
+		{"480", 480, true},
+		{"480p", 480, true},
+		{" 720P ", 720, true},
+		{"1080", 1080, true},
+		{"999", 0, false},
+		{"4k", 0, false},
+		{"p", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		height, ok := ParseVideoHeight(tt.in)
+		if height != tt.height || ok != tt.ok {
+			t.Errorf("ParseVideoHeight(%q) = %d, %v; want %d, %v", tt.in, height, ok, tt.height, tt.ok)
+		}
+	}
+}
+
+func TestNextOption(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []int
+		current int
+		want    int
+	}{
+		{"next", VideoHeights, 480, 720},
+		{"wraps", VideoHeights, 1080, 360},
+		{"unknown starts over", VideoHeights, 999, 360},
+		{"single", []int{30}, 30, 30},
+		{"frame rates", VideoFrameRates, 24, 30},
+	}
+
+	for _, tt := range tests {
+		if got := NextOption(tt.options, tt.current); got != tt.want {
+			t.Errorf("%s: NextOption(%v, %d) = %d; want %d", tt.name, tt.options, tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestParseQualityFlag(t *testing.T) {
+	chat := VideoQuality{Height: 720, Fps: 24}
+	tests := []struct {
+		args    string
+		rest    string
+		quality VideoQuality
+		ok      bool
+	}{
+		{"song name", "song name", chat, true},
+		{"", "", chat, true},
+		{"-q 480 song name", "song name", VideoQuality{Height: 480, Fps: 24}, true},
+		{"-q 1080p  https://example.com/v", "https://example.com/v", VideoQuality{Height: 1080, Fps: 24}, true},
+		{"-q 360", "", VideoQuality{Height: 360, Fps: 24}, true},
+		{"-q", "", chat, false},
+		{"-q 999 song", "", chat, false},
+		{"song -q 480", "song -q 480", chat, true},
+	}
+
+	for _, tt := range tests {
+		rest, quality, ok := ParseQualityFlag(tt.args, chat)
+		if rest != tt.rest || quality != tt.quality || ok != tt.ok {
+			t.Errorf("ParseQualityFlag(%q) = %q, %v, %v; want %q, %v, %v", tt.args, rest, quality, ok, tt.rest, tt.quality, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package vc
+
+import (
+	"ashokshau/tgmusic/src/core/cache"
+	"ashokshau/tgmusic/src/core/lang"
+	"ashokshau/tgmusic/src/vc/ubot"
+	"html"
+
+	td "github.com/AshokShau/gotdbot"
+)
+
+// handleAdminMute pauses playback while an admin has force-muted the assistant,
+// so the current position is kept instead of being played into silence.
+func (c *TelegramCalls) handleAdminMute(ub *ubot.Context, chatID int64) {
+	if chatID > 0 || !cache.ChatCache.IsActive(chatID) {
+		return
+	}
+
+	paused, err := c.Pause(chatID)
+	if err != nil {
+		logger.Warn("Failed to pause after an admin mute", "chat_id", chatID, "error", err)
+	}
+
+	c.pauseMu.Lock()
+	c.mutePaused[chatID] = paused
+	c.pauseMu.Unlock()
+
+	me := ub.App.Me()
+	text := lang.T(chatID, "assistant.muted", me.ID, html.EscapeString(me.FirstName))
+	_, _ = c.bot.SendTextMessage(chatID, text, &td.SendTextMessageOpts{ParseMode: "HTML", DisableWebPagePreview: true})
+}
+
+// handleAdminUnmute resumes playback that handleAdminMute paused once the assistant may speak again.
+func (c *TelegramCalls) handleAdminUnmute(chatID int64) {
+	c.pauseMu.Lock()
+	paused, ok := c.mutePaused[chatID]
+	delete(c.mutePaused, chatID)
+	_, idle := c.idleTimers[chatID]
+	if paused && idle {
+		// The chat emptied while muted; let the idle watcher resume once a listener joins.
+		c.autoPaused[chatID] = true
+	}
+	c.pauseMu.Unlock()
+
+	if !ok || !paused || idle || !cache.ChatCache.IsActive(chatID) {
+		return
+	}
+
+	if _, err := c.Resume(chatID); err != nil {
+		logger.Warn("Failed to resume after an admin unmute", "chat_id", chatID, "error", err)
+		return
+	}
+
+	_, _ = c.bot.SendTextMessage(chatID, lang.T(chatID, "assistant.unmuted"), nil)
+}
@@ -0,0 +1,175 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package vc
+
+import (
+	"ashokshau/tgmusic/config"
+	"ashokshau/tgmusic/src/core/cache"
+	"ashokshau/tgmusic/src/core/db"
+	"ashokshau/tgmusic/src/core/lang"
+	"ashokshau/tgmusic/src/vc/ntgcalls"
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// AssistantInfo describes a running assistant account.
+type AssistantInfo struct {
+	ID          int64
+	Name        string
+	Username    string
+	ActiveCalls int
+	Health      AssistantHealth
+}
+
+// inCall reports whether an assistant is currently streaming to a chat.
+func (c *TelegramCalls) inCall(assistantID, chatID int64) bool {
+	c.mu.RLock()
+	call, ok := c.uBContext[assistantID]
+	c.mu.RUnlock()
+	if !ok {
+		return false
+	}
+
+	_, ok = call.Calls()[chatID]
+	return ok
+}
+
+// assistantIDs returns the IDs of all running assistants in ascending order.
+func (c *TelegramCalls) assistantIDs() []int64 {
+	c.mu.RLock()
+	ids := make([]int64, 0, len(c.uBContext))
+	for id := range c.uBContext {
+		ids = append(ids, id)
+	}
+	c.mu.RUnlock()
+
+	slices.Sort(ids)
+	return ids
+}
+
+// Assistants lists the running assistants in ascending ID order.
+func (c *TelegramCalls) Assistants() []AssistantInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	assistants := make([]AssistantInfo, 0, len(c.uBContext))
+	for id, call := range c.uBContext {
+		me := call.App.Me()
+		name := strings.TrimSpace(me.FirstName + " " + me.LastName)
+		assistants = append(assistants, AssistantInfo{
+			ID:          id,
+			Name:        name,
+			Username:    me.Username,
+			ActiveCalls: len(call.Calls()),
+			Health:      c.Health(id),
+		})
+	}
+
+	slices.SortFunc(assistants, func(a, b AssistantInfo) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return assistants
+}
+
+// AddAssistant starts a new assistant from a session string and registers its handlers.
+// It returns the assistant's stable ID, which is its Telegram user ID.
+func (c *TelegramCalls) AddAssistant(session string) (int64, error) {
+	call, err := c.StartClient(config.Conf.ApiId, config.Conf.ApiHash, session)
+	if err != nil {
+		return 0, err
+	}
+	if call == nil {
+		return 0, errors.New("the account is frozen and cannot be used for voice calls")
+	}
+
+	if c.bot != nil {
+		c.registerCallHandlers(call)
+	}
+	return call.App.Me().ID, nil
+}
+
+// RemoveAssistant stops an assistant and moves its active streams to the remaining assistants.
+// Private calls are ended instead, since the user agreed to be called by this assistant only.
+// Chats that were assigned to it get a new assistant on their next play.
+// It returns the number of streams that were moved.
+func (c *TelegramCalls) RemoveAssistant(assistantID int64) (int, error) {
+	c.mu.Lock()
+	call, ok := c.uBContext[assistantID]
+	if !ok {
+		c.mu.Unlock()
+		return 0, fmt.Errorf("no assistant with ID %d is running", assistantID)
+	}
+	if len(c.uBContext) == 1 {
+		c.mu.Unlock()
+		return 0, errors.New("the last assistant cannot be removed")
+	}
+
+	client := c.clients[assistantID]
+	delete(c.uBContext, assistantID)
+	delete(c.clients, assistantID)
+	c.mu.Unlock()
+	c.forgetHealth(assistantID)
+
+	if _, err := db.Instance.UnassignAssistant(assistantID); err != nil {
+		logger.Warn("Failed to clear chats of a removed assistant", "assistant", assistantID, "error", err)
+	}
+
+	moved := 0
+	for chatID, info := range call.Calls() {
+		played, _ := call.Time(chatID, 0)
+		_ = call.Stop(chatID)
+
+		if chatID > 0 {
+			c.endCall(chatID, "call.assistant_removed", false)
+			continue
+		}
+		if err := c.moveStream(chatID, int(played), info.Playback == ntgcalls.PausedStream); err != nil {
+			logger.Warn("Failed to move a stream to another assistant", "chat_id", chatID, "error", err)
+			c.endCall(chatID, "call.assistant_removed", false)
+			continue
+		}
+		_, _ = c.bot.SendTextMessage(chatID, lang.T(chatID, "call.assistant_moved"), nil)
+		moved++
+	}
+
+	call.Close()
+	if client != nil {
+		_ = client.Stop()
+	}
+
+	logger.Info("[TelegramCalls] Assistant removed", "assistant", assistantID, "moved", moved)
+	return moved, nil
+}
+
+// moveStream restarts the chat's current track on its newly assigned assistant, from the given position.
+// A track that was paused is paused again once it has been moved.
+func (c *TelegramCalls) moveStream(chatID int64, played int, paused bool) error {
+	song := cache.ChatCache.GetPlayingTrack(chatID)
+	if song == nil || song.FilePath == "" {
+		return errors.New("nothing is playing")
+	}
+
+	var err error
+	if !song.IsLive && played > 0 && played < song.Duration {
+		err = c.SeekStream(chatID, song.FilePath, played, song.Duration, song.IsVideo)
+	} else {
+		err = c.PlayMedia(chatID, song.FilePath, song.IsVideo, "")
+	}
+	if err != nil || !paused {
+		return err
+	}
+
+	if _, err := c.Pause(chatID); err != nil {
+		logger.Warn("Failed to pause the moved stream", "chat_id", chatID, "error", err)
+	}
+	return nil
+}
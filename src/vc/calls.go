@@ -26,17 +26,18 @@ import (
 	"ashokshau/tgmusic/src/core/cache"
 	"ashokshau/tgmusic/src/core/db"
 	"ashokshau/tgmusic/src/core/dl"
+	"ashokshau/tgmusic/src/core/lang"
 	"ashokshau/tgmusic/src/utils"
 	"ashokshau/tgmusic/src/vc/ntgcalls"
 	"ashokshau/tgmusic/src/vc/ubot"
 	"context"
-	"crypto/rand"
 	"errors"
 	"fmt"
 	"html"
 	"log/slog"
-	"math/big"
+	"maps"
 	"os"
+	"slices"
 	"strings"
 
 	td "github.com/AshokShau/gotdbot"
@@ -44,68 +45,78 @@ import (
 
 const DefaultStreamURL = "https://t.me/FallenSongs/1295"
 
-// getClientIndex selects an assistant client index (0-based) for a given chat.
-func (c *TelegramCalls) getClientIndex(chatID int64) (int, error) {
-	c.mu.RLock()
-	totalClients := len(c.uBContext)
-	c.mu.RUnlock()
-
-	if totalClients == 0 {
-		return -1, fmt.Errorf("no clients are available")
+// getAssistantID returns the stable ID of the chat's assistant.
+// Chats without a running assistant get one through the configured AssignStrategy.
+func (c *TelegramCalls) getAssistantID(chatID int64) (int64, error) {
+	ids := c.assistantIDs()
+	if len(ids) == 0 {
+		return 0, fmt.Errorf("no clients are available")
 	}
 
-	assignedIndex, err := db.Instance.GetAssistant(chatID)
+	assignedID, err := db.Instance.GetAssistant(chatID)
 	if err != nil {
 		slog.Info("[TelegramCalls] DB.GetAssistant error", "error", err)
-		assignedIndex = -1
+		assignedID = 0
 	}
 
-	if assignedIndex >= 0 && assignedIndex < totalClients {
-		return assignedIndex, nil
+	// A draining assistant keeps the chats it is streaming to, but other chats move to a healthy one.
+	if assignedID != 0 && slices.Contains(ids, assignedID) && (!c.isDraining(assignedID) || c.inCall(assignedID, chatID)) {
+		return assignedID, nil
 	}
 
-	n, err := rand.Int(rand.Reader, big.NewInt(int64(totalClients)))
-	if err != nil {
-		slog.Info("[TelegramCalls] Could not generate a random number", "error", err)
-		newClientIndex := 0
-		if assignedIndex == -1 && chatID != 0 {
-			if _, err := db.Instance.AssignAssistant(chatID, newClientIndex); err != nil {
-				logger.Info("[TelegramCalls] DB.AssignAssistant error", "error", err)
-			}
-		}
-		return newClientIndex, nil
+	newID := c.pickAssistant(c.healthyAssistants(ids))
+	if chatID == 0 {
+		return newID, nil
 	}
 
-	newClientIndex := int(n.Int64())
-	if chatID != 0 {
-		if _, err := db.Instance.AssignAssistant(chatID, newClientIndex); err != nil {
-			logger.Info("[TelegramCalls] DB.AssignAssistant error", "error", err)
+	if assignedID != 0 {
+		// The assigned assistant is no longer running, so the stale assignment is replaced.
+		if err := db.Instance.SetAssistant(chatID, newID); err != nil {
+			logger.Info("[TelegramCalls] DB.SetAssistant error", "error", err)
 		}
+		return newID, nil
 	}
 
-	return newClientIndex, nil
+	assigned, err := db.Instance.AssignAssistant(chatID, newID)
+	if err != nil {
+		logger.Info("[TelegramCalls] DB.AssignAssistant error", "error", err)
+		return newID, nil
+	}
+
+	if slices.Contains(ids, assigned) {
+		return assigned, nil
+	}
+	return newID, nil
 }
 
-// GetGroupAssistant retrieves the ubot.Context and its index for a given chat.
-func (c *TelegramCalls) GetGroupAssistant(chatID int64) (*ubot.Context, int, error) {
-	clientIndex, err := c.getClientIndex(chatID)
+// GetGroupAssistant retrieves the ubot.Context and the assistant ID for a given chat.
+func (c *TelegramCalls) GetGroupAssistant(chatID int64) (*ubot.Context, int64, error) {
+	assistantID, err := c.getAssistantID(chatID)
 	if err != nil {
-		return nil, -1, err
+		return nil, 0, err
 	}
 
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	call, ok := c.uBContext[clientIndex]
+	call, ok := c.uBContext[assistantID]
 	if !ok {
-		return nil, -1, fmt.Errorf("no ntgcalls instance was found for client index %d", clientIndex)
+		return nil, 0, fmt.Errorf("no ntgcalls instance was found for assistant %d", assistantID)
 	}
-	return call, clientIndex, nil
+	return call, assistantID, nil
 }
 
-func (c *TelegramCalls) playMedia(chatID int64, filePath string, video bool, ffmpegParameters string, call *ubot.Context, index int) error {
+// videoQuality returns the quality of the playing track, falling back to the chat's profile.
+func (c *TelegramCalls) videoQuality(chatID int64) utils.VideoQuality {
+	if track := cache.ChatCache.GetPlayingTrack(chatID); track != nil && !track.Quality.IsZero() {
+		return track.Quality.Or(utils.DefaultVideoQuality)
+	}
+	return db.Instance.GetVideoQuality(chatID)
+}
+
+func (c *TelegramCalls) playMedia(chatID int64, filePath string, video bool, ffmpegParameters string, call *ubot.Context) error {
 	if chatID < 0 {
-		if err := c.joinAssistant(chatID, call, index); err != nil {
+		if err := c.joinAssistant(chatID, call); err != nil {
 			cache.ChatCache.ClearChat(chatID)
 			return err
 		}
@@ -113,8 +124,8 @@ func (c *TelegramCalls) playMedia(chatID int64, filePath string, video bool, ffm
 		_, _ = call.App.ResolvePeer(chatID)
 	}
 
-	logger.Debug("Playing media in chat", "id", chatID, "path", filePath, "index", index)
-	mediaDesc := getMediaDescription(filePath, video, ffmpegParameters)
+	logger.Debug("Playing media in chat", "id", chatID, "path", filePath, "assistant", call.App.Me().ID)
+	mediaDesc := getMediaDescription(filePath, video, ffmpegParameters, c.videoQuality(chatID), db.Instance.GetAudioProfile(chatID))
 	if err := call.Play(chatID, mediaDesc); err != nil {
 		cache.ChatCache.ClearChat(chatID)
 		return err
@@ -129,7 +140,7 @@ func (c *TelegramCalls) playMedia(chatID int64, filePath string, video bool, ffm
 
 // PlayMedia plays media in a voice chat with automatic assistant rotation on certain errors.
 func (c *TelegramCalls) PlayMedia(chatID int64, filePath string, video bool, ffmpegParameters string) error {
-	tried := make(map[int]bool)
+	tried := make(map[int64]bool)
 	var lastErr error
 
 	for {
@@ -144,32 +155,33 @@ func (c *TelegramCalls) PlayMedia(chatID int64, filePath string, video bool, ffm
 			return fmt.Errorf("no available assistants to play media")
 		}
 
-		call, index, err := c.GetGroupAssistant(chatID)
+		call, assistantID, err := c.GetGroupAssistant(chatID)
 		if err != nil {
 			return err
 		}
 
-		if tried[index] {
-			index = -1
+		if tried[assistantID] {
+			assistantID = 0
 			c.mu.RLock()
-			for i, ctx := range c.uBContext {
-				if !tried[i] {
-					index = i
+			for id, ctx := range c.uBContext {
+				if !tried[id] {
+					assistantID = id
 					call = ctx
 					break
 				}
 			}
 			c.mu.RUnlock()
-			if index == -1 {
+			if assistantID == 0 {
 				break
 			}
 		}
 
-		tried[index] = true
+		tried[assistantID] = true
 
-		err = c.playMedia(chatID, filePath, video, ffmpegParameters, call, index)
+		err = c.playMedia(chatID, filePath, video, ffmpegParameters, call)
 		if err == nil {
-			_ = db.Instance.SetAssistant(chatID, index)
+			c.recordPlay(assistantID, nil)
+			_ = db.Instance.SetAssistant(chatID, assistantID)
 			return nil
 		}
 
@@ -183,10 +195,13 @@ func (c *TelegramCalls) PlayMedia(chatID int64, filePath string, video bool, ffm
 			return fmt.Errorf("<b>GROUPCALL_INVALID:</b> start a video chat and try again.\n\nIf the problem persists, please report it to the developer.")
 		}
 
+		// Errors above come from the chat, anything below counts against the assistant's health.
+		c.recordPlay(assistantID, err)
+
 		if strings.Contains(err.Error(), "CHANNELS_TOO_MUCH") {
-			go func(idx int) {
-				_, _ = c.LeaveAllForClient(idx)
-			}(index)
+			go func(id int64) {
+				_, _ = c.LeaveAllForClient(id)
+			}(assistantID)
 
 			_ = db.Instance.RemoveAssistant(chatID)
 			continue
@@ -197,8 +212,8 @@ func (c *TelegramCalls) PlayMedia(chatID int64, filePath string, video bool, ffm
 			continue
 		}
 
-		logger.Error("Failed to play the media", "error", err, "index", index)
-		return fmt.Errorf("client%d: playback failed: %w", index, err)
+		logger.Error("Failed to play the media", "error", err, "assistant", assistantID)
+		return fmt.Errorf("assistant %d: playback failed: %w", assistantID, err)
 	}
 
 	return fmt.Errorf("failed to play media after trying all assistants: %w", lastErr)
@@ -213,13 +228,13 @@ func (c *TelegramCalls) downloadAndPrepareSong(song *utils.CachedTrack, reply *t
 
 	dlPath, err := dl.DownloadCachedTrack(song, c.bot)
 	if err != nil {
-		_, _ = reply.EditText(c.bot, "⚠️ Download failed. Skipping track...", nil)
+		_, _ = reply.EditText(c.bot, lang.T(reply.ChatId, "play.download_skipped"), nil)
 		return err
 	}
 
 	song.FilePath = dlPath
 	if song.FilePath == "" {
-		_, _ = reply.EditText(c.bot, "⚠️ Download failed. Skipping track...", nil)
+		_, _ = reply.EditText(c.bot, lang.T(reply.ChatId, "play.download_skipped"), nil)
 		return errors.New("download failed due to an empty file path")
 	}
 
@@ -249,14 +264,14 @@ func (c *TelegramCalls) PlayNext(chatID int64) error {
 // and sending a notification to the chat.
 func (c *TelegramCalls) handleNoSong(chatID int64) error {
 	_ = c.Stop(chatID)
-	_, _ = c.bot.SendTextMessage(chatID, "🎵 Queue finished. Add more songs with /play.", nil)
+	_, _ = c.bot.SendTextMessage(chatID, lang.T(chatID, "queue.finished"), nil)
 	return nil
 }
 
 // playSong downloads and plays a single song. It sends a message to the chat to indicate the download status
 // and updates it with the song's information once playback begins.
 func (c *TelegramCalls) playSong(chatID int64, song *utils.CachedTrack) error {
-	reply, err := c.bot.SendTextMessage(chatID, fmt.Sprintf("Downloading %s...", song.Name), nil)
+	reply, err := c.bot.SendTextMessage(chatID, lang.T(chatID, "play.downloading", song.Name), nil)
 	if err != nil {
 		slog.Info("[playSong] Failed to send message", "error", err)
 		return err
@@ -271,7 +286,7 @@ func (c *TelegramCalls) playSong(chatID int64, song *utils.CachedTrack) error {
 		return err
 	}
 
-	if song.Duration == 0 {
+	if song.Duration == 0 && !song.IsLive {
 		song.Duration = utils.GetMediaDuration(song.FilePath)
 	}
 
@@ -279,22 +294,19 @@ func (c *TelegramCalls) playSong(chatID int64, song *utils.CachedTrack) error {
 	escName := html.EscapeString(song.Name)
 	escUser := html.EscapeString(song.User)
 
-	text := fmt.Sprintf(
-		"<u><b>| Started streaming</b></u>\n\n<b>Title:</b> <a href='%s'>%s</a>\n\n<b>Duration:</b> %s min\n<b>Requested by:</b> %s",
+	text := lang.T(chatID, "np.started",
 		escURL,
 		escName,
-		utils.SecToMin(song.Duration),
+		utils.TrackDuration(song.Duration, song.IsLive),
 		escUser,
 	)
 
-	_, err = reply.EditText(c.bot, text, &td.EditTextMessageOpts{
-		ReplyMarkup:           core.ControlButtons("play"),
-		ParseMode:             "HTML",
-		DisableWebPagePreview: true,
-	})
+	if listeners := c.ListenerCount(chatID); listeners > 0 {
+		text += lang.T(chatID, "listeners.line", listeners)
+	}
 
-	if err != nil {
-		slog.Info("[playSong] Failed to edit message", "error", err)
+	if err = core.SendNowPlaying(c.bot, reply, song, text); err != nil {
+		slog.Info("[playSong] Failed to send the now-playing panel", "error", err)
 		return nil
 	}
 
@@ -303,11 +315,15 @@ func (c *TelegramCalls) playSong(chatID int64, song *utils.CachedTrack) error {
 
 // Stop halts media playback in a voice chat and clears the chat's cache.
 func (c *TelegramCalls) Stop(chatId int64) error {
-	call, index, err := c.GetGroupAssistant(chatId)
+	call, assistantID, err := c.GetGroupAssistant(chatId)
 	if err != nil {
 		return err
 	}
 
+	c.stopIdle(chatId, false)
+	c.pauseMu.Lock()
+	delete(c.mutePaused, chatId)
+	c.pauseMu.Unlock()
 	cache.ChatCache.ClearChat(chatId)
 	err = call.Stop(chatId)
 	if err != nil {
@@ -315,8 +331,8 @@ func (c *TelegramCalls) Stop(chatId int64) error {
 			return nil
 		}
 
-		slog.Info("[Stop] Failed to stop the call", "error", err, "index", index)
-		return fmt.Errorf("failed to stop call (client %d): %w", index, err)
+		slog.Info("[Stop] Failed to stop the call", "error", err, "assistant", assistantID)
+		return fmt.Errorf("failed to stop call (assistant %d): %w", assistantID, err)
 	}
 	return nil
 }
@@ -324,29 +340,29 @@ func (c *TelegramCalls) Stop(chatId int64) error {
 // Pause temporarily stops media playback in a voice chat.
 // It returns true if the operation was successful, and an error otherwise.
 func (c *TelegramCalls) Pause(chatId int64) (bool, error) {
-	call, index, err := c.GetGroupAssistant(chatId)
+	call, assistantID, err := c.GetGroupAssistant(chatId)
 	if err != nil {
 		return false, err
 	}
 
 	res, err := call.Pause(chatId)
 	if err != nil {
-		slog.Warn("[Pause] Failed to pause the call", "error", err, "index", index)
-		return res, fmt.Errorf("failed to pause (client %d): %w", index, err)
+		slog.Warn("[Pause] Failed to pause the call", "error", err, "assistant", assistantID)
+		return res, fmt.Errorf("failed to pause (assistant %d): %w", assistantID, err)
 	}
 	return res, err
 }
 
 // Resume continues a paused media playback in a voice chat.
 func (c *TelegramCalls) Resume(chatId int64) (bool, error) {
-	call, index, err := c.GetGroupAssistant(chatId)
+	call, assistantID, err := c.GetGroupAssistant(chatId)
 	if err != nil {
 		return false, err
 	}
 
 	res, err := call.Resume(chatId)
 	if err != nil {
-		logger.Warn("Failed to resume the call", "error", err, "index", index)
+		logger.Warn("Failed to resume the call", "error", err, "assistant", assistantID)
 		return res, fmt.Errorf("failed to resume: %w", err)
 	}
 
@@ -355,14 +371,14 @@ func (c *TelegramCalls) Resume(chatId int64) (bool, error) {
 
 // Mute silences the media playback in a voice chat.
 func (c *TelegramCalls) Mute(chatId int64) (bool, error) {
-	call, index, err := c.GetGroupAssistant(chatId)
+	call, assistantID, err := c.GetGroupAssistant(chatId)
 	if err != nil {
 		return false, err
 	}
 
 	res, err := call.Mute(chatId)
 	if err != nil {
-		logger.Warn("Failed to mute the call", "error", err, "index", index)
+		logger.Warn("Failed to mute the call", "error", err, "assistant", assistantID)
 		return res, fmt.Errorf("failed to mute: %w", err)
 	}
 
@@ -371,14 +387,14 @@ func (c *TelegramCalls) Mute(chatId int64) (bool, error) {
 
 // Unmute restores the audio of a muted media playback in a voice chat.
 func (c *TelegramCalls) Unmute(chatId int64) (bool, error) {
-	call, index, err := c.GetGroupAssistant(chatId)
+	call, assistantID, err := c.GetGroupAssistant(chatId)
 	if err != nil {
 		return false, err
 	}
 
 	res, err := call.Unmute(chatId)
 	if err != nil {
-		logger.Warn("Failed to unmute the call", "error", err, "index", index)
+		logger.Warn("Failed to unmute the call", "error", err, "assistant", assistantID)
 		return res, fmt.Errorf("failed to unmute: %w", err)
 	}
 
@@ -387,14 +403,14 @@ func (c *TelegramCalls) Unmute(chatId int64) (bool, error) {
 
 // PlayedTime retrieves the elapsed time of the current playback in a voice chat.
 func (c *TelegramCalls) PlayedTime(chatId int64) (uint64, error) {
-	call, index, err := c.GetGroupAssistant(chatId)
+	call, assistantID, err := c.GetGroupAssistant(chatId)
 	if err != nil {
 		return 0, err
 	}
 
 	_time, err := call.Time(chatId, 0)
 	if err != nil {
-		logger.Warn("Failed to get played time", "error", err, "index", index)
+		logger.Warn("Failed to get played time", "error", err, "assistant", assistantID)
 		return 0, fmt.Errorf("failed to get played time: %w", err)
 	}
 
@@ -403,24 +419,62 @@ func (c *TelegramCalls) PlayedTime(chatId int64) (uint64, error) {
 
 // CpuUsage Get an estimate of the CPU usage of the current process.
 func (c *TelegramCalls) CpuUsage(chatId int64) (float64, error) {
-	call, index, err := c.GetGroupAssistant(chatId)
+	call, assistantID, err := c.GetGroupAssistant(chatId)
 	if err != nil {
 		return 0, err
 	}
 
 	usage, err := call.CpuUsage()
 	if err != nil {
-		logger.Warn("Failed to get CPU usage", "error", err, "index", index)
+		logger.Warn("Failed to get CPU usage", "error", err, "assistant", assistantID)
 		return 0, fmt.Errorf("failed to get cpu usage: %w", err)
 	}
 
 	return usage, nil
 }
 
+// ActiveCallCount returns the number of calls currently running across all assistants.
+func (c *TelegramCalls) ActiveCallCount() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	count := 0
+	for _, call := range c.uBContext {
+		count += len(call.Calls())
+	}
+	return count
+}
+
+// CallsCpuUsage sums the CPU usage of the assistants that have active calls and returns it with their call count,
+// so usage divided by calls is the cost of one call.
+func (c *TelegramCalls) CallsCpuUsage() (float64, int) {
+	c.mu.RLock()
+	contexts := make(map[int64]*ubot.Context, len(c.uBContext))
+	maps.Copy(contexts, c.uBContext)
+	c.mu.RUnlock()
+
+	var usage float64
+	var calls int
+	for assistantID, call := range contexts {
+		n := len(call.Calls())
+		if n == 0 {
+			continue
+		}
+		u, err := call.CpuUsage()
+		if err != nil {
+			logger.Warn("Failed to get CPU usage", "error", err, "assistant", assistantID)
+			continue
+		}
+		usage += u
+		calls += n
+	}
+	return usage, calls
+}
+
 // SeekStream jumps to a specific time in the current media stream.
 func (c *TelegramCalls) SeekStream(chatID int64, filePath string, toSeek, duration int, isVideo bool) error {
-	if toSeek < 0 || duration <= 0 {
-		return errors.New("invalid seek position or duration. The position must be positive and the duration must be greater than 0")
+	if toSeek < 0 {
+		return errors.New("invalid seek position. The position must be positive")
 	}
 
 	isURL := urlRegex.MatchString(filePath)
@@ -429,9 +483,13 @@ func (c *TelegramCalls) SeekStream(chatID int64, filePath string, toSeek, durati
 
 	var ffmpegParams string
 	if isURL || !isFile {
-		ffmpegParams = fmt.Sprintf("-ss %d -i %s -to %d", toSeek, filePath, duration)
+		ffmpegParams = fmt.Sprintf("-ss %d -i %s", toSeek, filePath)
 	} else {
-		ffmpegParams = fmt.Sprintf("-ss %d -to %d", toSeek, duration)
+		ffmpegParams = fmt.Sprintf("-ss %d", toSeek)
+	}
+	// A duration of 0 means it is unknown, so the stream plays to the end.
+	if duration > 0 {
+		ffmpegParams += fmt.Sprintf(" -to %d", duration)
 	}
 
 	return c.PlayMedia(chatID, filePath, isVideo, ffmpegParams)
@@ -448,6 +506,10 @@ func (c *TelegramCalls) ChangeSpeed(chatID int64, speed float64) error {
 		return errors.New("the bot isn't streaming in the video chat")
 	}
 
+	if playingSong.IsLive {
+		return errors.New("the playback speed of a live stream cannot be changed")
+	}
+
 	videoPTS := 1 / speed
 
 	var audioFilterBuilder strings.Builder
@@ -475,49 +537,40 @@ func (c *TelegramCalls) RegisterHandlers(client *td.Client) {
 	c.bot = client
 
 	c.startAutoLeave(context.Background())
+	c.startHealthMonitor(context.Background())
+	c.startPositionSampler(context.Background())
 
 	for _, call := range c.uBContext {
-		call.OnStreamEnd(func(chatID int64, streamType ntgcalls.StreamType, device ntgcalls.StreamDevice) {
-			if streamType == ntgcalls.VideoStream {
-				return
-			}
+		c.registerCallHandlers(call)
+	}
+}
 
-			if err := c.PlayNext(chatID); err != nil {
-				call.App.Logger.Warnf("[OnStreamEnd] Failed to play the song: %v", err)
-			}
-		})
-
-		call.OnIncomingCall(func(ub *ubot.Context, chatID int64) {
-			_, _ = ub.App.SendMessage(chatID, "Incoming call detected. Playing music...")
-			msg, err := utils.GetMessage(c.bot, DefaultStreamURL)
-			if err != nil {
-				call.App.Logger.Warnf("[OnIncomingCall] Failed to get the message: %v", err)
-				return
-			}
+// registerCallHandlers sets up the event handlers for a single assistant.
+func (c *TelegramCalls) registerCallHandlers(call *ubot.Context) {
+	call.OnStreamEnd(func(chatID int64, streamType ntgcalls.StreamType, device ntgcalls.StreamDevice) {
+		if streamType == ntgcalls.VideoStream {
+			return
+		}
 
-			file, err := msg.Download(c.bot, 1, 0, 0, true)
-			if err != nil {
-				call.App.Logger.Warnf("[OnIncomingCall] Failed to download the message: %v", err)
-				return
-			}
+		if err := c.PlayNext(chatID); err != nil {
+			call.App.Logger.Warnf("[OnStreamEnd] Failed to play the song: %v", err)
+		}
+	})
 
-			err = c.PlayMedia(chatID, file.Local.Path, false, "")
-			if err != nil {
-				call.App.Logger.Warnf("[OnIncomingCall] Failed to play the media: %v", err)
-				return
-			}
+	call.OnParticipantsChange(c.handleParticipantsChange)
+	call.OnCallEvent(c.handleCallEvent)
 
-			return
-		})
+	call.OnIncomingCall(func(ub *ubot.Context, chatID int64) {
+		c.handleIncomingCall(ub, chatID)
+	})
 
-		_, err := call.App.SendMessage(client.Me.Usernames.EditableUsername, "/start")
-		if err != nil {
-			call.App.Logger.Warnf("failed to start bot: %v", err)
-		}
+	_, err := call.App.SendMessage(c.bot.Me.Usernames.EditableUsername, "/start")
+	if err != nil {
+		call.App.Logger.Warnf("failed to start bot: %v", err)
+	}
 
-		_, err = call.App.SendMessage(config.Conf.LoggerId, "Userbot started.")
-		if err != nil {
-			call.App.Logger.Warnf("Failed to send message: %v", err)
-		}
+	_, err = call.App.SendMessage(config.Conf.LoggerId, "Userbot started.")
+	if err != nil {
+		call.App.Logger.Warnf("Failed to send message: %v", err)
 	}
 }
@@ -0,0 +1,83 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package vc
+
+import (
+	"ashokshau/tgmusic/src/core/cache"
+	"ashokshau/tgmusic/src/core/lang"
+	"ashokshau/tgmusic/src/vc/ubot"
+	"ashokshau/tgmusic/src/vc/ubot/types"
+	"errors"
+)
+
+// handleCallEvent receives lifecycle events emitted by the assistants.
+// A lost connection or a removed assistant first tries to move the stream to another assistant,
+// unless an admin removed the assistant from the chat.
+func (c *TelegramCalls) handleCallEvent(ub *ubot.Context, event types.CallEvent) {
+	ub.App.Logger.Debugf("[CallEvent] %s in %d", event.Type, event.ChatId)
+	switch event.Type {
+	case types.MutedByAdmin:
+		c.handleAdminMute(ub, event.ChatId)
+	case types.UnmutedByAdmin:
+		c.handleAdminUnmute(event.ChatId)
+	case types.ConnectionLost:
+		c.recordPlay(ub.App.Me().ID, errors.New("connection lost"))
+		if !c.failover(ub, event.ChatId, errors.New("connection lost")) {
+			c.DispatchCallEvent(event)
+		}
+	case types.AssistantRemoved:
+		// An assistant kicked out of the chat by an admin is not replaced by another one.
+		if c.removedByAdmin(ub, event.ChatId) || !c.failover(ub, event.ChatId, errors.New("assistant removed from the call")) {
+			c.DispatchCallEvent(event)
+		}
+	default:
+		c.DispatchCallEvent(event)
+	}
+}
+
+// DispatchCallEvent applies a call lifecycle event, whether it was detected by an assistant
+// or by the bot itself (for example a video chat ended service message).
+// Ending events clear the queue, cancel pending idle work and notify the chat once.
+func (c *TelegramCalls) DispatchCallEvent(event types.CallEvent) {
+	switch event.Type {
+	case types.CallDiscarded:
+		key := "call.ended"
+		if event.ChatId > 0 {
+			key = "call.private_ended"
+		}
+		c.endCall(event.ChatId, key, false)
+	case types.AssistantRemoved:
+		c.endCall(event.ChatId, "call.assistant_removed", false)
+	case types.ConnectionLost:
+		c.endCall(event.ChatId, "call.connection_lost", true)
+	case types.CallStarted:
+		logger.Debug("Assistant connected to the call", "chat_id", event.ChatId)
+	}
+}
+
+// endCall tears down the chat's playback state after its call has ended.
+// The chat is only notified if it still had an active queue. The queue is checked and cleared in one step, so an
+// ending detected twice, even concurrently, is reported once.
+func (c *TelegramCalls) endCall(chatID int64, key string, leave bool) {
+	c.stopIdle(chatID, false)
+	c.pauseMu.Lock()
+	delete(c.mutePaused, chatID)
+	c.pauseMu.Unlock()
+	if !cache.ChatCache.ClearChat(chatID) {
+		return
+	}
+
+	if leave {
+		if err := c.Stop(chatID); err != nil {
+			logger.Warn("Failed to leave the call", "chat_id", chatID, "error", err)
+		}
+	}
+
+	_, _ = c.bot.SendTextMessage(chatID, lang.T(chatID, key), nil)
+}
@@ -0,0 +1,175 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package vc
+
+import (
+	"ashokshau/tgmusic/src/core/cache"
+	"ashokshau/tgmusic/src/core/db"
+	"ashokshau/tgmusic/src/core/lang"
+	"ashokshau/tgmusic/src/utils"
+	"ashokshau/tgmusic/src/vc/ntgcalls"
+	"ashokshau/tgmusic/src/vc/ubot"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	td "github.com/AshokShau/gotdbot"
+)
+
+const positionSampleInterval = 5 * time.Second
+
+// streamPosition is where a stream was when it was last sampled.
+type streamPosition struct {
+	played int
+	paused bool
+}
+
+// failover moves the chat's stream from an assistant that lost the call to another one.
+// The current track resumes at its last known position and the queue is kept.
+// It reports false if the stream could not be moved, in which case the caller ends the call as before.
+func (c *TelegramCalls) failover(ub *ubot.Context, chatID int64, cause error) bool {
+	if chatID > 0 || !cache.ChatCache.IsActive(chatID) {
+		return false
+	}
+
+	c.streamMu.Lock()
+	if c.failingOver[chatID] {
+		c.streamMu.Unlock()
+		return true
+	}
+	c.failingOver[chatID] = true
+	c.streamMu.Unlock()
+
+	defer func() {
+		c.streamMu.Lock()
+		delete(c.failingOver, chatID)
+		c.streamMu.Unlock()
+	}()
+
+	oldID := ub.App.Me().ID
+	pos := c.lastPosition(ub, chatID)
+	_ = ub.Stop(chatID)
+
+	if err := c.reassign(chatID, oldID); err != nil {
+		logger.Warn("[Failover] No assistant to take over the stream", "chat_id", chatID, "error", err)
+		return false
+	}
+
+	logger.Info("[Failover] Moving the stream to another assistant", "chat_id", chatID, "from", oldID, "position", pos.played, "paused", pos.paused, "cause", cause)
+	if err := c.moveStream(chatID, pos.played, pos.paused); err != nil {
+		logger.Warn("[Failover] Failed to resume the stream", "chat_id", chatID, "error", err)
+		return false
+	}
+
+	_, _ = c.bot.SendTextMessage(chatID, lang.T(chatID, "call.failover", utils.SecToMin(pos.played)), nil)
+	return true
+}
+
+// reassign gives the chat a healthy assistant other than the excluded one and joins it to the chat.
+func (c *TelegramCalls) reassign(chatID, exclude int64) error {
+	ids := slices.DeleteFunc(c.healthyAssistants(c.assistantIDs()), func(id int64) bool {
+		return id == exclude
+	})
+	if len(ids) == 0 {
+		return errors.New("no other assistant is running")
+	}
+
+	newID := c.pickAssistant(ids)
+	c.mu.RLock()
+	call, ok := c.uBContext[newID]
+	c.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("assistant %d is no longer running", newID)
+	}
+
+	if err := c.joinAssistant(chatID, call); err != nil {
+		return err
+	}
+	return db.Instance.SetAssistant(chatID, newID)
+}
+
+// lastPosition returns how far the chat's track has played, in seconds, and whether it was paused.
+// It asks the assistant first and falls back to the last sample, since a removed assistant has already dropped the call.
+func (c *TelegramCalls) lastPosition(ub *ubot.Context, chatID int64) streamPosition {
+	if info, ok := ub.Calls()[chatID]; ok {
+		if played, err := ub.Time(chatID, 0); err == nil && played > 0 {
+			return streamPosition{played: int(played), paused: info.Playback == ntgcalls.PausedStream}
+		}
+	}
+
+	c.streamMu.Lock()
+	defer c.streamMu.Unlock()
+	return c.positions[chatID]
+}
+
+// removedByAdmin reports whether the assistant was removed from the call because it is no longer in the chat,
+// which means an admin kicked or banned it. The membership is looked up again rather than read from the cache.
+func (c *TelegramCalls) removedByAdmin(ub *ubot.Context, chatID int64) bool {
+	assistantID := ub.App.Me().ID
+	c.statusCache.Delete(fmt.Sprintf("%d:%d", chatID, assistantID))
+
+	status, err := c.checkUserStats(chatID, ub)
+	if err != nil {
+		logger.Warn("[Failover] Failed to check the removed assistant", "chat_id", chatID, "assistant", assistantID, "error", err)
+		return false
+	}
+
+	switch status.(type) {
+	case *td.ChatMemberStatusLeft, td.ChatMemberStatusLeft, *td.ChatMemberStatusBanned, td.ChatMemberStatusBanned:
+		return true
+	}
+	return false
+}
+
+// startPositionSampler records the played time of every active stream so a failover knows where to resume.
+func (c *TelegramCalls) startPositionSampler(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(positionSampleInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.samplePositions()
+			}
+		}
+	}()
+}
+
+// samplePositions asks every assistant for its streams' positions. The assistants are copied first so the
+// calls into ntgcalls do not hold mu.
+func (c *TelegramCalls) samplePositions() {
+	c.mu.RLock()
+	calls := slices.Collect(maps.Values(c.uBContext))
+	c.mu.RUnlock()
+
+	positions := make(map[int64]streamPosition)
+	for _, call := range calls {
+		for chatID, info := range call.Calls() {
+			if played, err := call.Time(chatID, 0); err == nil {
+				positions[chatID] = streamPosition{played: int(played), paused: info.Playback == ntgcalls.PausedStream}
+			}
+		}
+	}
+
+	c.streamMu.Lock()
+	defer c.streamMu.Unlock()
+	for chatID := range c.positions {
+		if !cache.ChatCache.IsActive(chatID) {
+			delete(c.positions, chatID)
+		}
+	}
+	for chatID, played := range positions {
+		c.positions[chatID] = played
+	}
+}
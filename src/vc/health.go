@@ -0,0 +1,238 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package vc
+
+import (
+	"ashokshau/tgmusic/config"
+	"ashokshau/tgmusic/src/core/lang"
+	"context"
+	"strings"
+	"time"
+
+	td "github.com/AshokShau/gotdbot"
+	"github.com/amarnathcjd/gogram/telegram"
+)
+
+const (
+	healthCheckInterval = 2 * time.Minute
+	healthWindow        = 20  // healthWindow is how many recent play attempts count towards the failure rate.
+	healthMinSamples    = 5   // healthMinSamples is how many attempts are needed before the failure rate is trusted.
+	healthMaxFailRate   = 0.5 // healthMaxFailRate is the failure rate above which an assistant is drained.
+)
+
+// AssistantHealth is the health state of an assistant.
+// Draining assistants keep their current streams but get no new chats.
+type AssistantHealth struct {
+	Connected  bool
+	Frozen     bool
+	FloodUntil time.Time
+	Attempts   int // Attempts is the number of recent play attempts, up to healthWindow.
+	Failures   int // Failures is how many of the recent play attempts failed.
+	Draining   bool
+	Reason     string // Reason is why the assistant is draining, in English.
+	CheckedAt  time.Time
+
+	outcomes   []bool
+	reasonKey  string // reasonKey is the catalog key of Reason, so alerts can be sent in the logger chat's language.
+	reasonArgs []any
+}
+
+// FailureRate returns the share of recent play attempts that failed.
+func (h AssistantHealth) FailureRate() float64 {
+	if h.Attempts == 0 {
+		return 0
+	}
+	return float64(h.Failures) / float64(h.Attempts)
+}
+
+// evaluate decides whether the assistant should be drained and why.
+func (h *AssistantHealth) evaluate() {
+	h.Attempts, h.Failures = len(h.outcomes), 0
+	for _, ok := range h.outcomes {
+		if !ok {
+			h.Failures++
+		}
+	}
+
+	h.reasonArgs = nil
+	switch {
+	case !h.Connected:
+		h.reasonKey = "health.reason_disconnected"
+	case h.Frozen:
+		h.reasonKey = "health.reason_frozen"
+	case time.Now().Before(h.FloodUntil):
+		h.reasonKey, h.reasonArgs = "health.reason_flood", []any{h.FloodUntil.UTC().Format("15:04:05 UTC")}
+	case h.Attempts >= healthMinSamples && h.FailureRate() >= healthMaxFailRate:
+		h.reasonKey, h.reasonArgs = "health.reason_failures", []any{int(h.FailureRate()*100 + 0.5)}
+	default:
+		h.Draining, h.Reason, h.reasonKey = false, "", ""
+		return
+	}
+	h.Draining, h.Reason = true, lang.Tr(lang.DefaultLanguage, h.reasonKey, h.reasonArgs...)
+}
+
+// Health returns a copy of an assistant's health state.
+func (c *TelegramCalls) Health(assistantID int64) AssistantHealth {
+	c.healthMu.Lock()
+	defer c.healthMu.Unlock()
+
+	if h, ok := c.health[assistantID]; ok {
+		health := *h
+		health.outcomes, health.reasonArgs = nil, nil
+		return health
+	}
+	return AssistantHealth{Connected: true}
+}
+
+// isDraining reports whether an assistant should not get new chats.
+func (c *TelegramCalls) isDraining(assistantID int64) bool {
+	c.healthMu.Lock()
+	defer c.healthMu.Unlock()
+
+	h, ok := c.health[assistantID]
+	return ok && h.Draining
+}
+
+// healthyAssistants filters out draining assistants. If every assistant is draining, all of them are returned.
+func (c *TelegramCalls) healthyAssistants(ids []int64) []int64 {
+	healthy := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if !c.isDraining(id) {
+			healthy = append(healthy, id)
+		}
+	}
+
+	if len(healthy) == 0 {
+		return ids
+	}
+	return healthy
+}
+
+// recordPlay stores the outcome of a play attempt and reacts to flood waits and frozen accounts.
+func (c *TelegramCalls) recordPlay(assistantID int64, err error) {
+	c.updateHealth(assistantID, func(h *AssistantHealth) {
+		h.outcomes = append(h.outcomes, err == nil)
+		if len(h.outcomes) > healthWindow {
+			h.outcomes = h.outcomes[len(h.outcomes)-healthWindow:]
+		}
+
+		if err == nil {
+			return
+		}
+
+		if wait := telegram.GetFloodWait(err); wait > 0 {
+			h.FloodUntil = time.Now().Add(time.Duration(wait) * time.Second)
+		} else if strings.Contains(err.Error(), "FLOOD_WAIT_X") {
+			h.FloodUntil = time.Now().Add(healthCheckInterval)
+		}
+
+		if strings.Contains(err.Error(), "FROZEN_METHOD_INVALID") {
+			h.Frozen = true
+		}
+	})
+}
+
+// updateHealth applies fn to an assistant's health state and alerts the logger chat when it starts or stops draining.
+func (c *TelegramCalls) updateHealth(assistantID int64, fn func(h *AssistantHealth)) {
+	c.healthMu.Lock()
+	h, ok := c.health[assistantID]
+	if !ok {
+		h = &AssistantHealth{Connected: true}
+		c.health[assistantID] = h
+	}
+
+	wasDraining := h.Draining
+	fn(h)
+	h.evaluate()
+	draining, reason, reasonKey, reasonArgs := h.Draining, h.Reason, h.reasonKey, h.reasonArgs
+	c.healthMu.Unlock()
+
+	if draining == wasDraining {
+		return
+	}
+
+	if draining {
+		logger.Warn("[Health] Draining assistant", "assistant", assistantID, "reason", reason)
+		c.alertHealth("health.draining", assistantID, lang.T(config.Conf.LoggerId, reasonKey, reasonArgs...))
+	} else {
+		logger.Info("[Health] Assistant recovered", "assistant", assistantID)
+		c.alertHealth("health.recovered", assistantID)
+	}
+}
+
+// alertHealth sends the message for key to the logger chat, in the chat's language.
+func (c *TelegramCalls) alertHealth(key string, args ...any) {
+	if c.bot == nil || config.Conf.LoggerId == 0 {
+		return
+	}
+
+	if _, err := c.bot.SendTextMessage(config.Conf.LoggerId, lang.T(config.Conf.LoggerId, key, args...), &td.SendTextMessageOpts{ParseMode: "HTML"}); err != nil {
+		logger.Warn("[Health] Failed to send the alert", "error", err)
+	}
+}
+
+// forgetHealth drops the health state of a removed assistant.
+func (c *TelegramCalls) forgetHealth(assistantID int64) {
+	c.healthMu.Lock()
+	delete(c.health, assistantID)
+	c.healthMu.Unlock()
+}
+
+// startHealthMonitor checks every assistant's connection and freeze status in the background.
+func (c *TelegramCalls) startHealthMonitor(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(healthCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.checkHealth()
+			}
+		}
+	}()
+}
+
+func (c *TelegramCalls) checkHealth() {
+	c.mu.RLock()
+	clients := make(map[int64]*telegram.Client, len(c.clients))
+	for id, client := range c.clients {
+		clients[id] = client
+	}
+	c.mu.RUnlock()
+
+	for id, client := range clients {
+		connected := client.IsConnected()
+		var frozen bool
+		var err error
+		if connected {
+			frozen, err = isFrozen(client)
+			if err != nil {
+				logger.Warn("[Health] Failed to check the freeze status", "assistant", id, "error", err)
+			}
+		}
+
+		c.updateHealth(id, func(h *AssistantHealth) {
+			h.Connected = connected
+			if err == nil {
+				h.Frozen = frozen
+			}
+			if wait := telegram.GetFloodWait(err); wait > 0 {
+				h.FloodUntil = time.Now().Add(time.Duration(wait) * time.Second)
+			}
+			// A drained assistant gets no plays, so its failure rate can only recover by starting over
+			// once it passes a check.
+			if connected && err == nil && !frozen && h.Draining && h.Attempts >= healthMinSamples && h.FailureRate() >= healthMaxFailRate {
+				h.outcomes = nil
+			}
+			h.CheckedAt = time.Now()
+		})
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"strings"
 	"time"
 
+	"ashokshau/tgmusic/src/utils"
 	"ashokshau/tgmusic/src/vc/ntgcalls"
 
 	td "github.com/AshokShau/gotdbot"
@@ -63,12 +64,12 @@ func getVideoDimensions(filePath string) (int, int) {
 
 var isURLRegex = regexp.MustCompile(`^https?://`)
 
-// getMediaDescription creates a media description for ntgcalls based on the provided file path, video status, and ffmpeg parameters.
-func getMediaDescription(filePath string, isVideo bool, ffmpegParameters string) ntgcalls.MediaDescription {
+// getMediaDescription creates a media description for ntgcalls based on the provided file path, video status, ffmpeg parameters and stream profiles.
+func getMediaDescription(filePath string, isVideo bool, ffmpegParameters string, quality utils.VideoQuality, audio utils.AudioProfile) ntgcalls.MediaDescription {
 	audioDescription := &ntgcalls.AudioDescription{
 		MediaSource:  ntgcalls.MediaSourceShell,
-		SampleRate:   48000,
-		ChannelCount: 2,
+		SampleRate:   uint32(audio.SampleRate),
+		ChannelCount: uint8(audio.Channels),
 	}
 
 	quotedPath := fmt.Sprintf("\"%s\"", filePath)
@@ -112,8 +113,8 @@ func getMediaDescription(filePath string, isVideo bool, ffmpegParameters string)
 
 	originalWidth, originalHeight := getVideoDimensions(filePath)
 
-	width := 1280
-	height := 720
+	width := quality.Width()
+	height := quality.Height
 
 	if originalWidth > 0 && originalHeight > 0 {
 		ratio := float64(originalWidth) / float64(originalHeight)
@@ -140,7 +141,7 @@ func getMediaDescription(filePath string, isVideo bool, ffmpegParameters string)
 		MediaSource: ntgcalls.MediaSourceShell,
 		Width:       int16(width),
 		Height:      int16(height),
-		Fps:         30,
+		Fps:         uint8(quality.Fps),
 	}
 
 	var videoCmd strings.Builder
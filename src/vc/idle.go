@@ -0,0 +1,156 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package vc
+
+import (
+	"ashokshau/tgmusic/config"
+	"ashokshau/tgmusic/src/core/cache"
+	"ashokshau/tgmusic/src/core/lang"
+	"ashokshau/tgmusic/src/vc/ubot"
+	"time"
+)
+
+// listenersDebounce is how long participant updates of a chat must settle before the listeners are fetched.
+// A busy voice chat sends many updates in a row; only the last one matters.
+const listenersDebounce = 3 * time.Second
+
+// handleParticipantsChange schedules a listener check for the chat, replacing one that is still pending.
+func (c *TelegramCalls) handleParticipantsChange(_ *ubot.Context, chatID int64) {
+	if chatID > 0 || !cache.ChatCache.IsActive(chatID) {
+		return
+	}
+
+	c.pauseMu.Lock()
+	defer c.pauseMu.Unlock()
+	if timer, ok := c.listenCheck[chatID]; ok {
+		timer.Reset(listenersDebounce)
+		return
+	}
+	c.listenCheck[chatID] = time.AfterFunc(listenersDebounce, func() {
+		c.pauseMu.Lock()
+		delete(c.listenCheck, chatID)
+		c.pauseMu.Unlock()
+		c.checkListeners(chatID)
+	})
+}
+
+// checkListeners pauses playback when only assistants remain in the voice chat
+// and resumes it once a listener joins again.
+func (c *TelegramCalls) checkListeners(chatID int64) {
+	if !cache.ChatCache.IsActive(chatID) {
+		return
+	}
+
+	listeners, err := c.GetListeners(chatID)
+	if err != nil {
+		logger.Debug("Failed to get listeners", "chat_id", chatID, "error", err)
+		return
+	}
+
+	empty := true
+	for _, l := range listeners {
+		if !l.Assistant {
+			empty = false
+			break
+		}
+	}
+
+	if empty {
+		c.startIdle(chatID)
+	} else {
+		c.stopIdle(chatID, true)
+	}
+}
+
+// startIdle pauses the stream of an empty voice chat and schedules the bot to leave after EmptyCallTimeout.
+func (c *TelegramCalls) startIdle(chatID int64) {
+	c.pauseMu.Lock()
+	if _, ok := c.idleTimers[chatID]; ok {
+		c.pauseMu.Unlock()
+		return
+	}
+
+	timeout := time.Duration(config.Conf.EmptyCallTimeout()) * time.Second
+	c.idleTimers[chatID] = time.AfterFunc(timeout, func() {
+		c.leaveIdle(chatID)
+	})
+	c.pauseMu.Unlock()
+
+	paused, err := c.Pause(chatID)
+	if err != nil {
+		logger.Warn("Failed to auto-pause an empty voice chat", "chat_id", chatID, "error", err)
+		return
+	}
+
+	if paused {
+		c.pauseMu.Lock()
+		c.autoPaused[chatID] = true
+		c.pauseMu.Unlock()
+	}
+
+	text := lang.T(chatID, "idle.paused", formatTimeout(chatID, timeout))
+	_, _ = c.bot.SendTextMessage(chatID, text, nil)
+}
+
+// stopIdle cancels a pending idle leave. When resume is true and the stream was paused by startIdle, playback resumes.
+func (c *TelegramCalls) stopIdle(chatID int64, resume bool) {
+	c.pauseMu.Lock()
+	timer, ok := c.idleTimers[chatID]
+	if ok {
+		timer.Stop()
+		delete(c.idleTimers, chatID)
+	}
+
+	wasPaused := c.autoPaused[chatID]
+	delete(c.autoPaused, chatID)
+	_, muted := c.mutePaused[chatID]
+	if wasPaused && muted {
+		// Still muted by an admin; the unmute handler resumes playback instead.
+		c.mutePaused[chatID] = true
+	}
+	c.pauseMu.Unlock()
+
+	if !ok || !resume || !wasPaused || muted {
+		return
+	}
+
+	if _, err := c.Resume(chatID); err != nil {
+		logger.Warn("Failed to resume after a listener joined", "chat_id", chatID, "error", err)
+		return
+	}
+
+	_, _ = c.bot.SendTextMessage(chatID, lang.T(chatID, "idle.resumed"), nil)
+}
+
+// leaveIdle stops playback, clears the queue and leaves a voice chat that stayed empty for too long.
+func (c *TelegramCalls) leaveIdle(chatID int64) {
+	c.pauseMu.Lock()
+	delete(c.idleTimers, chatID)
+	delete(c.autoPaused, chatID)
+	c.pauseMu.Unlock()
+
+	if !cache.ChatCache.IsActive(chatID) {
+		return
+	}
+
+	if err := c.Stop(chatID); err != nil {
+		logger.Warn("Failed to leave an idle voice chat", "chat_id", chatID, "error", err)
+	}
+
+	text := lang.T(chatID, "idle.left", formatTimeout(chatID, time.Duration(config.Conf.EmptyCallTimeout())*time.Second))
+	_, _ = c.bot.SendTextMessage(chatID, text, nil)
+}
+
+// formatTimeout renders a timeout as minutes when it divides evenly, or seconds otherwise.
+func formatTimeout(chatID int64, d time.Duration) string {
+	if d >= time.Minute && d%time.Minute == 0 {
+		return lang.T(chatID, "common.minutes", int(d.Minutes()))
+	}
+	return lang.T(chatID, "common.seconds", int(d.Seconds()))
+}
@@ -11,6 +11,7 @@ package vc
 import (
 	"ashokshau/tgmusic/config"
 	"ashokshau/tgmusic/src/core/cache"
+	"ashokshau/tgmusic/src/core/db"
 	"ashokshau/tgmusic/src/vc/ubot"
 
 	"context"
@@ -57,16 +58,43 @@ func (c *TelegramCalls) LeaveAll() (int, error) {
 	return int(totalLeft.Load()), firstErr
 }
 
-func (c *TelegramCalls) LeaveAllForClient(index int) (int, error) {
+func (c *TelegramCalls) LeaveAllForClient(assistantID int64) (int, error) {
 	c.mu.RLock()
-	call, ok := c.uBContext[index]
+	call, ok := c.uBContext[assistantID]
 	c.mu.RUnlock()
 	if !ok {
-		return 0, fmt.Errorf("no ntgcalls instance was found for client index %d", index)
+		return 0, fmt.Errorf("no ntgcalls instance was found for assistant %d", assistantID)
 	}
 	return c.leaveAssistantDialogs(call)
 }
 
+// LeaveChat stops playback in a chat and makes its assistant leave the chat.
+// Only an existing assignment is used; a chat without a running assistant has nothing to leave.
+func (c *TelegramCalls) LeaveChat(chatID int64) error {
+	assistantID, err := db.Instance.GetAssistant(chatID)
+	if err != nil {
+		return err
+	}
+
+	c.mu.RLock()
+	call, ok := c.uBContext[assistantID]
+	c.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	if err = c.Stop(chatID); err != nil {
+		logger.Warn("Failed to stop playback before leaving", "chat_id", chatID, "error", err)
+	}
+
+	err = call.App.LeaveChannel(chatID)
+	if err != nil && !strings.Contains(err.Error(), "USER_NOT_PARTICIPANT") &&
+		!strings.Contains(err.Error(), "CHANNEL_PRIVATE") {
+		return fmt.Errorf("assistant failed to leave chat %d: %w", chatID, err)
+	}
+	return nil
+}
+
 func (c *TelegramCalls) leaveAssistantDialogs(ctx *ubot.Context) (int, error) {
 	userBot := ctx.App
 	var totalLeft int
@@ -142,13 +170,12 @@ func (c *TelegramCalls) leaveAssistantDialogs(ctx *ubot.Context) (int, error) {
 
 const autoLeaveInterval = 18 * time.Hour
 
+// startAutoLeave runs the auto leave task in the background.
+// AUTO_LEAVE is checked on every tick so it can be toggled at runtime with /config.
 func (c *TelegramCalls) startAutoLeave(ctx context.Context) {
-	if !config.Conf.AutoLeave {
-		return
-	}
 	go func() {
-		logger.Info("AutoLeave enabled, starting background task",
-			"interval", autoLeaveInterval)
+		logger.Info("AutoLeave: starting background task",
+			"interval", autoLeaveInterval, "enabled", config.Conf.AutoLeave())
 		ticker := time.NewTicker(autoLeaveInterval)
 		defer ticker.Stop()
 		for {
@@ -157,7 +184,9 @@ func (c *TelegramCalls) startAutoLeave(ctx context.Context) {
 				logger.Info("AutoLeave: background task stopped")
 				return
 			case <-ticker.C:
-				c.runAutoLeave()
+				if config.Conf.AutoLeave() {
+					c.runAutoLeave()
+				}
 			}
 		}
 	}()
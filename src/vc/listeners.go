@@ -0,0 +1,110 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package vc
+
+import (
+	"slices"
+	"time"
+
+	tg "github.com/amarnathcjd/gogram/telegram"
+)
+
+// speakingWindow is how recently a participant must have been active to count as speaking.
+const speakingWindow = 10 * time.Second
+
+// Listener describes a single voice chat participant.
+type Listener struct {
+	ID        int64 // ID is the participant's user or chat ID in Bot API form.
+	Muted     bool
+	Speaking  bool
+	Camera    bool
+	Screen    bool
+	Assistant bool // Assistant reports whether the participant is one of the bot's assistants.
+	JoinedAt  time.Time
+}
+
+// peerID converts an MTProto peer into the ID format used by the bot client.
+func peerID(peer tg.Peer) int64 {
+	switch p := peer.(type) {
+	case *tg.PeerUser:
+		return p.UserID
+	case *tg.PeerChat:
+		return -p.ChatID
+	case *tg.PeerChannel:
+		return -1000000000000 - p.ChannelID
+	default:
+		return 0
+	}
+}
+
+// isAssistant reports whether the given user ID belongs to one of the assistant clients.
+func (c *TelegramCalls) isAssistant(userID int64) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	_, ok := c.uBContext[userID]
+	return ok
+}
+
+// GetListeners returns the participants of the chat's voice chat, ordered by join time.
+func (c *TelegramCalls) GetListeners(chatID int64) ([]Listener, error) {
+	call, _, err := c.GetGroupAssistant(chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	participants, err := call.GetParticipants(chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	listeners := make([]Listener, 0, len(participants))
+	for _, p := range participants {
+		id := peerID(p.Peer)
+		lastActive := time.Unix(int64(p.ActiveDate), 0)
+		listeners = append(listeners, Listener{
+			ID:        id,
+			Muted:     p.Muted,
+			Speaking:  !p.Muted && p.ActiveDate > 0 && now.Sub(lastActive) < speakingWindow,
+			Camera:    p.Video != nil,
+			Screen:    p.Presentation != nil,
+			Assistant: c.isAssistant(id),
+			JoinedAt:  time.Unix(int64(p.Date), 0),
+		})
+	}
+
+	slices.SortFunc(listeners, func(a, b Listener) int {
+		return a.JoinedAt.Compare(b.JoinedAt)
+	})
+
+	return listeners, nil
+}
+
+// ListenerCount returns the number of participants in the voice chat, excluding assistants.
+// It returns 0 when the participants cannot be fetched.
+func (c *TelegramCalls) ListenerCount(chatID int64) int {
+	if chatID > 0 {
+		return 0
+	}
+
+	listeners, err := c.GetListeners(chatID)
+	if err != nil {
+		logger.Debug("Failed to get listeners", "chat_id", chatID, "error", err)
+		return 0
+	}
+
+	count := 0
+	for _, l := range listeners {
+		if !l.Assistant {
+			count++
+		}
+	}
+	return count
+}
@@ -28,7 +28,7 @@ func sendLogger(client *td.Client, chatID int64, song *utils.CachedTrack) {
 		chatID,
 		song.URL,
 		song.Name,
-		utils.SecToMin(song.Duration),
+		utils.TrackDuration(song.Duration, song.IsLive),
 		song.User,
 		song.Platform,
 		song.IsVideo,
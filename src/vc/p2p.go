@@ -0,0 +1,57 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package vc
+
+import (
+	"ashokshau/tgmusic/config"
+	"ashokshau/tgmusic/src/core/cache"
+	"ashokshau/tgmusic/src/core/db"
+	"ashokshau/tgmusic/src/core/lang"
+	"ashokshau/tgmusic/src/utils"
+	"ashokshau/tgmusic/src/vc/ubot"
+)
+
+// handleIncomingCall applies the configured P2P_CALL_MODE to a private call received by an assistant.
+// Accepted callers get a personal queue keyed by their user ID, which /play in the bot's private chat controls.
+func (c *TelegramCalls) handleIncomingCall(ub *ubot.Context, userID int64) {
+	if !config.Conf.IsP2PAllowed(userID) {
+		if err := ub.DiscardCall(userID); err != nil {
+			ub.App.Logger.Warnf("[OnIncomingCall] Failed to decline the call from %d: %v", userID, err)
+		}
+		return
+	}
+
+	if err := db.Instance.SetAssistant(userID, ub.App.Me().ID); err != nil {
+		ub.App.Logger.Warnf("[OnIncomingCall] Failed to assign the assistant: %v", err)
+	}
+
+	if song := cache.ChatCache.GetPlayingTrack(userID); song != nil {
+		if err := c.playSong(userID, song); err != nil {
+			ub.App.Logger.Warnf("[OnIncomingCall] Failed to resume the personal queue: %v", err)
+		}
+		return
+	}
+
+	_, _ = ub.App.SendMessage(userID, lang.T(userID, "call.incoming_accepted", c.bot.Me.Usernames.EditableUsername))
+	msg, err := utils.GetMessage(c.bot, DefaultStreamURL)
+	if err != nil {
+		ub.App.Logger.Warnf("[OnIncomingCall] Failed to get the message: %v", err)
+		return
+	}
+
+	file, err := msg.Download(c.bot, 1, 0, 0, true)
+	if err != nil {
+		ub.App.Logger.Warnf("[OnIncomingCall] Failed to download the message: %v", err)
+		return
+	}
+
+	if err = c.PlayMedia(userID, file.Local.Path, false, ""); err != nil {
+		ub.App.Logger.Warnf("[OnIncomingCall] Failed to play the media: %v", err)
+	}
+}
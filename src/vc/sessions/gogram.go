@@ -0,0 +1,52 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package sessions
+
+import (
+	"fmt"
+
+	"github.com/amarnathcjd/gogram/telegram"
+)
+
+// Prefixes of gogram string sessions.
+const (
+	gogramPrefix       = "1BvE"
+	gogramLegacyPrefix = "1BvX"
+)
+
+func parseGogram(sessionString string) (*Info, error) {
+	var sess telegram.Session
+	if err := sess.Decode(sessionString); err != nil {
+		return nil, fmt.Errorf("failed to decode the gogram session: %w", err)
+	}
+
+	if len(sess.Key) != authKeySize {
+		return nil, fmt.Errorf("unexpected auth key length: received %d, expected %d", len(sess.Key), authKeySize)
+	}
+
+	info := &Info{
+		Format:   FormatGogram,
+		Hostname: sess.Hostname,
+		AppID:    sess.AppID,
+		Key:      sess.Key,
+	}
+	info.DC, info.TestMode = dcFromHostname(sess.Hostname)
+	return info, nil
+}
+
+func encodeGogram(i *Info) (string, error) {
+	hostname, err := i.hostnameFor()
+	if err != nil {
+		return "", err
+	}
+
+	sess := i.Session()
+	sess.Hostname = hostname
+	return sess.Encode(), nil
+}
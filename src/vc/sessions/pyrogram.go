@@ -10,23 +10,39 @@ package sessions
 
 import (
 	"encoding/base64"
+	"encoding/binary"
 	"fmt"
 
 	"github.com/amarnathcjd/gogram/telegram"
 )
 
+// Sizes of the packed Pyrogram session layouts, in bytes.
+const (
+	pyrogramSize      = 1 + 4 + 1 + authKeySize + 8 + 1 // dc_id, api_id, test_mode, auth_key, user_id, is_bot
+	pyrogramSize64    = 1 + 1 + authKeySize + 8 + 1     // older layout without api_id
+	pyrogramSizeOld32 = 1 + 1 + authKeySize + 4 + 1     // oldest layout with a 32-bit user_id
+)
+
+func isPyrogramLength(n int) bool {
+	for _, size := range []int{pyrogramSize, pyrogramSize64, pyrogramSizeOld32} {
+		if n == base64.RawURLEncoding.EncodedLen(size) {
+			return true
+		}
+	}
+	return false
+}
+
 // DecodePyrogramSessionString decodes a Pyrogram-generated session string into a gogram-compatible session object.
 // It returns an error if the decoding fails or the data is malformed.
 func DecodePyrogramSessionString(encodedString string) (*telegram.Session, error) {
-	const (
-		dcIDSize     = 1
-		apiIDSize    = 4
-		testModeSize = 1
-		authKeySize  = 256
-		userIDSize   = 8
-		isBotSize    = 1
-	)
+	info, err := parsePyrogram(encodedString)
+	if err != nil {
+		return nil, err
+	}
+	return info.Session(), nil
+}
 
+func parsePyrogram(encodedString string) (*Info, error) {
 	for len(encodedString)%4 != 0 {
 		encodedString += "="
 	}
@@ -36,18 +52,64 @@ func DecodePyrogramSessionString(encodedString string) (*telegram.Session, error
 		return nil, fmt.Errorf("failed to decode the base64 string: %w", err)
 	}
 
-	expectedSize := dcIDSize + apiIDSize + testModeSize + authKeySize + userIDSize + isBotSize
-	if len(packedData) != expectedSize {
-		return nil, fmt.Errorf("unexpected data length: received %d, expected %d", len(packedData), expectedSize)
+	info := &Info{Format: FormatPyrogram}
+	var rest []byte
+	switch len(packedData) {
+	case pyrogramSize:
+		info.AppID = int32(binary.BigEndian.Uint32(packedData[1:5]))
+		if info.AppID < 0 {
+			return nil, fmt.Errorf("the app ID is invalid: %d", info.AppID)
+		}
+		info.DC, info.TestMode, rest = int(packedData[0]), packedData[5] != 0, packedData[6:]
+	case pyrogramSize64, pyrogramSizeOld32:
+		info.DC, info.TestMode, rest = int(packedData[0]), packedData[1] != 0, packedData[2:]
+	default:
+		return nil, fmt.Errorf("unexpected data length: received %d, expected %d", len(packedData), pyrogramSize)
 	}
 
-	appID := int32(uint32(packedData[1])<<24 | uint32(packedData[2])<<16 | uint32(packedData[3])<<8 | uint32(packedData[4]))
-	if appID < 0 {
-		return nil, fmt.Errorf("the app ID is invalid: %d", appID)
+	info.Key, rest = rest[:authKeySize], rest[authKeySize:]
+	if len(rest) == 8+1 {
+		info.UserID = int64(binary.BigEndian.Uint64(rest[:8]))
+	} else {
+		info.UserID = int64(binary.BigEndian.Uint32(rest[:4]))
+	}
+	info.IsBot = rest[len(rest)-1] != 0
+
+	info.Hostname = dcAddress(info.DC, info.TestMode)
+	if info.Hostname == "" {
+		return nil, fmt.Errorf("the DC ID is invalid: %d", info.DC)
+	}
+	return info, nil
+}
+
+// encodePyrogram packs the session in the current Pyrogram layout.
+// Pyrogram treats a session without a user ID as logged out, so the user ID and app ID are required.
+func encodePyrogram(i *Info) (string, error) {
+	if i.UserID == 0 {
+		return "", fmt.Errorf("a pyrogram session needs the user ID, which a %s session does not store", i.Format)
+	}
+	if i.AppID <= 0 {
+		return "", fmt.Errorf("a pyrogram session needs the app ID, which a %s session does not store", i.Format)
+	}
+
+	dc, err := i.dcFor()
+	if err != nil {
+		return "", err
+	}
+
+	packed := make([]byte, 0, pyrogramSize)
+	packed = append(packed, byte(dc))
+	packed = binary.BigEndian.AppendUint32(packed, uint32(i.AppID))
+	packed = append(packed, boolByte(i.TestMode))
+	packed = append(packed, i.Key...)
+	packed = binary.BigEndian.AppendUint64(packed, uint64(i.UserID))
+	packed = append(packed, boolByte(i.IsBot))
+	return base64.RawURLEncoding.EncodeToString(packed), nil
+}
+
+func boolByte(b bool) byte {
+	if b {
+		return 1
 	}
-	return &telegram.Session{
-		Hostname: telegram.ResolveDC(int(packedData[0]), packedData[5] != 0, false),
-		AppID:    appID,
-		Key:      packedData[6 : 6+authKeySize],
-	}, nil
+	return 0
 }
@@ -0,0 +1,174 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package sessions
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/amarnathcjd/gogram/telegram"
+)
+
+// Format is the library a session string was generated with.
+type Format string
+
+const (
+	FormatPyrogram Format = "pyrogram"
+	FormatTelethon Format = "telethon"
+	FormatGogram   Format = "gogram"
+)
+
+// Formats lists every supported session format.
+var Formats = []Format{FormatPyrogram, FormatTelethon, FormatGogram}
+
+// ErrUnknownFormat is returned when a session string does not look like any supported format.
+var ErrUnknownFormat = errors.New("the session string is not a pyrogram, telethon or gogram session")
+
+// Info is a decoded session string.
+// Not every format stores every field: only Pyrogram sessions carry the user ID, and Telethon sessions have no app ID.
+type Info struct {
+	Format   Format
+	DC       int    // DC is the data center the auth key belongs to, or 0 if it could not be worked out.
+	Hostname string // Hostname is the "ip:port" address of the data center.
+	TestMode bool
+	AppID    int32
+	UserID   int64
+	IsBot    bool
+	Key      []byte // Key is the 256-byte auth key.
+}
+
+// ParseFormat looks up a session format by name.
+func ParseFormat(name string) (Format, error) {
+	format := Format(strings.ToLower(strings.TrimSpace(name)))
+	for _, f := range Formats {
+		if f == format {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown session format %q", name)
+}
+
+// Detect works out which library generated a session string from its prefix and length.
+func Detect(sessionString string) (Format, error) {
+	sessionString = strings.TrimSpace(sessionString)
+	switch {
+	case strings.HasPrefix(sessionString, gogramPrefix), strings.HasPrefix(sessionString, gogramLegacyPrefix):
+		return FormatGogram, nil
+	case strings.HasPrefix(sessionString, telethonVersion) && isTelethonLength(len(sessionString)-len(telethonVersion)):
+		return FormatTelethon, nil
+	case isPyrogramLength(len(strings.TrimRight(sessionString, "="))):
+		return FormatPyrogram, nil
+	default:
+		return "", ErrUnknownFormat
+	}
+}
+
+// Parse detects the format of a session string and decodes it.
+func Parse(sessionString string) (*Info, error) {
+	format, err := Detect(sessionString)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAs(sessionString, format)
+}
+
+// ParseAs decodes a session string of a known format.
+func ParseAs(sessionString string, format Format) (*Info, error) {
+	sessionString = strings.TrimSpace(sessionString)
+	switch format {
+	case FormatPyrogram:
+		return parsePyrogram(sessionString)
+	case FormatTelethon:
+		return parseTelethon(sessionString)
+	case FormatGogram:
+		return parseGogram(sessionString)
+	default:
+		return nil, fmt.Errorf("unknown session format %q", format)
+	}
+}
+
+// Encode converts the session into a string of the given format.
+func (i *Info) Encode(format Format) (string, error) {
+	if len(i.Key) != authKeySize {
+		return "", fmt.Errorf("the auth key is %d bytes, expected %d", len(i.Key), authKeySize)
+	}
+
+	switch format {
+	case FormatPyrogram:
+		return encodePyrogram(i)
+	case FormatTelethon:
+		return encodeTelethon(i)
+	case FormatGogram:
+		return encodeGogram(i)
+	default:
+		return "", fmt.Errorf("unknown session format %q", format)
+	}
+}
+
+// Session returns the session as a gogram session.
+func (i *Info) Session() *telegram.Session {
+	return &telegram.Session{
+		Hostname: i.Hostname,
+		AppID:    i.AppID,
+		Key:      i.Key,
+	}
+}
+
+// Decode detects the format of a session string and converts it into a gogram-compatible session object.
+func Decode(sessionString string) (*telegram.Session, Format, error) {
+	info, err := Parse(sessionString)
+	if err != nil {
+		return nil, "", err
+	}
+	return info.Session(), info.Format, nil
+}
+
+const authKeySize = 256
+
+// dcAddress returns the default address of a data center.
+func dcAddress(dc int, testMode bool) string {
+	return telegram.ResolveDC(dc, testMode, false)
+}
+
+// dcFromHostname finds the data center a default address belongs to.
+// It reports 0 and false for addresses it does not know.
+func dcFromHostname(hostname string) (dc int, testMode bool) {
+	for dc = 1; dc <= 5; dc++ {
+		if hostname == dcAddress(dc, false) || hostname == telegram.ResolveDC(dc, false, true) {
+			return dc, false
+		}
+		if hostname == dcAddress(dc, true) {
+			return dc, true
+		}
+	}
+	return 0, false
+}
+
+// hostnameFor returns the session's hostname, falling back to the default address of its data center.
+func (i *Info) hostnameFor() (string, error) {
+	if i.Hostname != "" {
+		return i.Hostname, nil
+	}
+	if hostname := dcAddress(i.DC, i.TestMode); hostname != "" {
+		return hostname, nil
+	}
+	return "", fmt.Errorf("the session has no address and DC %d is unknown", i.DC)
+}
+
+// dcFor returns the session's data center, working it out from the hostname if needed.
+func (i *Info) dcFor() (int, error) {
+	if i.DC > 0 {
+		return i.DC, nil
+	}
+	if dc, _ := dcFromHostname(i.Hostname); dc > 0 {
+		return dc, nil
+	}
+	return 0, fmt.Errorf("the data center of %q is unknown", i.Hostname)
+}
@@ -0,0 +1,338 @@
+package sessions
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/amarnathcjd/gogram/telegram"
+)
+
+const (
+	testAppID  = 123456
+	testUserID = 5123456789
+)
+
+func testKey() []byte {
+	key := make([]byte, authKeySize)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	return key
+}
+
+// pyrogramString packs a session the way Pyrogram's current layout does.
+func pyrogramString(dc byte, appID uint32, test bool, userID uint64, bot bool) string {
+	packed := []byte{dc}
+	packed = binary.BigEndian.AppendUint32(packed, appID)
+	packed = append(packed, boolByte(test))
+	packed = append(packed, testKey()...)
+	packed = binary.BigEndian.AppendUint64(packed, userID)
+	packed = append(packed, boolByte(bot))
+	return base64.RawURLEncoding.EncodeToString(packed)
+}
+
+// pyrogramLegacyString packs a session in one of Pyrogram's layouts without an app ID.
+func pyrogramLegacyString(dc byte, userID uint64, userIDSize int) string {
+	packed := []byte{dc, 0}
+	packed = append(packed, testKey()...)
+	if userIDSize == 8 {
+		packed = binary.BigEndian.AppendUint64(packed, userID)
+	} else {
+		packed = binary.BigEndian.AppendUint32(packed, uint32(userID))
+	}
+	packed = append(packed, 0)
+	return base64.RawURLEncoding.EncodeToString(packed)
+}
+
+// telethonString packs a session the way Telethon's StringSession does.
+func telethonString(dc byte, ip string, port uint16) string {
+	addr := net.ParseIP(ip)
+	if ip4 := addr.To4(); ip4 != nil {
+		addr = ip4
+	}
+	packed := append([]byte{dc}, addr...)
+	packed = binary.BigEndian.AppendUint16(packed, port)
+	packed = append(packed, testKey()...)
+	return "1" + base64.URLEncoding.EncodeToString(packed)
+}
+
+func gogramString(hostname string, appID int32) string {
+	return (&telegram.Session{Key: testKey(), Hostname: hostname, AppID: appID}).Encode()
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name    string
+		session string
+		want    Format
+		wantErr bool
+	}{
+		{"pyrogram", pyrogramString(2, testAppID, false, testUserID, false), FormatPyrogram, false},
+		{"pyrogram 64-bit layout", pyrogramLegacyString(2, testUserID, 8), FormatPyrogram, false},
+		{"pyrogram 32-bit layout", pyrogramLegacyString(2, 12345, 4), FormatPyrogram, false},
+		{"pyrogram with padding", pyrogramString(2, testAppID, false, testUserID, false) + "==", FormatPyrogram, false},
+		{"telethon ipv4", telethonString(2, "149.154.167.50", 443), FormatTelethon, false},
+		{"telethon ipv6", telethonString(2, "2001:67c:4e8:f002::a", 443), FormatTelethon, false},
+		{"gogram", gogramString(dcAddress(2, false), testAppID), FormatGogram, false},
+		{"surrounding whitespace", "  " + telethonString(2, "149.154.167.50", 443) + "\n", FormatTelethon, false},
+		{"empty", "", "", true},
+		{"garbage", "not a session", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Detect(tt.session)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Detect() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, ErrUnknownFormat) {
+				t.Errorf("Detect() error = %v, want ErrUnknownFormat", err)
+			}
+			if got != tt.want {
+				t.Errorf("Detect() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		session string
+		want    Info
+		wantErr bool
+	}{
+		{
+			name:    "pyrogram",
+			session: pyrogramString(2, testAppID, false, testUserID, false),
+			want:    Info{Format: FormatPyrogram, DC: 2, Hostname: dcAddress(2, false), AppID: testAppID, UserID: testUserID},
+		},
+		{
+			name:    "pyrogram test server bot",
+			session: pyrogramString(1, testAppID, true, testUserID, true),
+			want:    Info{Format: FormatPyrogram, DC: 1, Hostname: dcAddress(1, true), TestMode: true, AppID: testAppID, UserID: testUserID, IsBot: true},
+		},
+		{
+			name:    "pyrogram 64-bit layout",
+			session: pyrogramLegacyString(4, testUserID, 8),
+			want:    Info{Format: FormatPyrogram, DC: 4, Hostname: dcAddress(4, false), UserID: testUserID},
+		},
+		{
+			name:    "pyrogram 32-bit layout",
+			session: pyrogramLegacyString(5, 12345, 4),
+			want:    Info{Format: FormatPyrogram, DC: 5, Hostname: dcAddress(5, false), UserID: 12345},
+		},
+		{
+			name:    "pyrogram unknown dc",
+			session: pyrogramString(9, testAppID, false, testUserID, false),
+			wantErr: true,
+		},
+		{
+			name:    "pyrogram negative app id",
+			session: pyrogramString(2, 1<<31, false, testUserID, false),
+			wantErr: true,
+		},
+		{
+			name:    "telethon ipv4",
+			session: telethonString(2, "149.154.167.50", 443),
+			want:    Info{Format: FormatTelethon, DC: 2, Hostname: "149.154.167.50:443"},
+		},
+		{
+			name:    "telethon ipv6",
+			session: telethonString(4, "2001:67c:4e8:f002::a", 443),
+			want:    Info{Format: FormatTelethon, DC: 4, Hostname: "[2001:67c:4e8:f002::a]:443"},
+		},
+		{
+			name:    "telethon test server",
+			session: telethonString(2, "149.154.167.40", 443),
+			want:    Info{Format: FormatTelethon, DC: 2, Hostname: "149.154.167.40:443", TestMode: true},
+		},
+		{
+			name:    "gogram",
+			session: gogramString(dcAddress(3, false), testAppID),
+			want:    Info{Format: FormatGogram, DC: 3, Hostname: dcAddress(3, false), AppID: testAppID},
+		},
+		{
+			name:    "gogram unknown address",
+			session: gogramString("10.0.0.1:443", testAppID),
+			want:    Info{Format: FormatGogram, Hostname: "10.0.0.1:443", AppID: testAppID},
+		},
+		{
+			name:    "gogram corrupted",
+			session: gogramPrefix + "!!!!",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.session)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if !bytes.Equal(got.Key, testKey()) {
+				t.Errorf("Parse() key does not match")
+			}
+			got.Key = nil
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAsWrongFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		session string
+		format  Format
+	}{
+		{"pyrogram as telethon", pyrogramString(2, testAppID, false, testUserID, false), FormatTelethon},
+		{"telethon as pyrogram", telethonString(2, "149.154.167.50", 443), FormatPyrogram},
+		{"telethon as gogram", telethonString(2, "149.154.167.50", 443), FormatGogram},
+		{"unknown format", telethonString(2, "149.154.167.50", 443), Format("tdlib")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseAs(tt.session, tt.format); err == nil {
+				t.Errorf("ParseAs() expected an error")
+			}
+		})
+	}
+}
+
+func TestDecoders(t *testing.T) {
+	tests := []struct {
+		name     string
+		decode   func(string) (*telegram.Session, error)
+		session  string
+		hostname string
+		appID    int32
+		wantErr  bool
+	}{
+		{"pyrogram", DecodePyrogramSessionString, pyrogramString(2, testAppID, false, testUserID, false), dcAddress(2, false), testAppID, false},
+		{"pyrogram truncated", DecodePyrogramSessionString, pyrogramString(2, testAppID, false, testUserID, false)[:100], "", 0, true},
+		{"pyrogram bad base64", DecodePyrogramSessionString, "***", "", 0, true},
+		{"telethon", DecodeTelethonSessionString, telethonString(2, "149.154.167.50", 443), "149.154.167.50:443", 0, false},
+		{"telethon truncated", DecodeTelethonSessionString, telethonString(2, "149.154.167.50", 443)[:100], "", 0, true},
+		{"telethon empty", DecodeTelethonSessionString, "", "", 0, true},
+		{"telethon wrong version", DecodeTelethonSessionString, "2" + telethonString(2, "149.154.167.50", 443)[1:], "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.decode(tt.session)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decode error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got.Hostname != tt.hostname || got.AppID != tt.appID || !bytes.Equal(got.Key, testKey()) {
+				t.Errorf("decode = {Hostname: %q, AppID: %d}, want {Hostname: %q, AppID: %d}", got.Hostname, got.AppID, tt.hostname, tt.appID)
+			}
+		})
+	}
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	sources := map[string]string{
+		"pyrogram": pyrogramString(2, testAppID, false, testUserID, false),
+		"telethon": telethonString(2, "149.154.167.50", 443),
+		"gogram":   gogramString(dcAddress(2, false), testAppID),
+	}
+
+	for name, session := range sources {
+		for _, format := range Formats {
+			t.Run(name+" to "+string(format), func(t *testing.T) {
+				info, err := Parse(session)
+				if err != nil {
+					t.Fatalf("Parse() error = %v", err)
+				}
+				// Formats that do not store these need them filled in before converting to Pyrogram.
+				if info.UserID == 0 {
+					info.UserID = testUserID
+				}
+				if info.AppID == 0 {
+					info.AppID = testAppID
+				}
+
+				encoded, err := info.Encode(format)
+				if err != nil {
+					t.Fatalf("Encode() error = %v", err)
+				}
+
+				got, err := Parse(encoded)
+				if err != nil {
+					t.Fatalf("Parse() of the converted session error = %v", err)
+				}
+				if got.Format != format {
+					t.Errorf("converted session detected as %q, want %q", got.Format, format)
+				}
+				if got.DC != 2 || got.Hostname != dcAddress(2, false) || !bytes.Equal(got.Key, testKey()) {
+					t.Errorf("converted session = {DC: %d, Hostname: %q}, want {DC: 2, Hostname: %q}", got.DC, got.Hostname, dcAddress(2, false))
+				}
+			})
+		}
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		info   Info
+		format Format
+	}{
+		{"pyrogram without user id", Info{Format: FormatTelethon, DC: 2, AppID: testAppID, Key: testKey()}, FormatPyrogram},
+		{"pyrogram without app id", Info{Format: FormatTelethon, DC: 2, UserID: testUserID, Key: testKey()}, FormatPyrogram},
+		{"telethon without dc", Info{Format: FormatGogram, Hostname: "10.0.0.1:443", Key: testKey()}, FormatTelethon},
+		{"gogram without address", Info{Format: FormatPyrogram, DC: 9, Key: testKey()}, FormatGogram},
+		{"short key", Info{Format: FormatTelethon, DC: 2, Key: []byte{1, 2, 3}}, FormatGogram},
+		{"unknown format", Info{Format: FormatTelethon, DC: 2, Key: testKey()}, Format("tdlib")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.info.Encode(tt.format); err == nil {
+				t.Errorf("Encode() expected an error")
+			}
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Format
+		wantErr bool
+	}{
+		{"pyrogram", FormatPyrogram, false},
+		{" Telethon ", FormatTelethon, false},
+		{"GOGRAM", FormatGogram, false},
+		{"tdlib", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFormat(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseFormat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -13,43 +13,119 @@ import (
 	"encoding/binary"
 	"fmt"
 	"net"
+	"strconv"
+	"strings"
 
 	"github.com/amarnathcjd/gogram/telegram"
 )
 
+// telethonVersion is the version prefix of Telethon string sessions.
+const telethonVersion = "1"
+
+// Sizes of the packed Telethon session layouts, in bytes: dc_id, ip, port, auth_key.
+const (
+	telethonSizeIPv4 = 1 + net.IPv4len + 2 + authKeySize
+	telethonSizeIPv6 = 1 + net.IPv6len + 2 + authKeySize
+)
+
+func isTelethonLength(n int) bool {
+	for _, size := range []int{telethonSizeIPv4, telethonSizeIPv6} {
+		if n == base64.URLEncoding.EncodedLen(size) || n == base64.RawURLEncoding.EncodedLen(size) {
+			return true
+		}
+	}
+	return false
+}
+
 // DecodeTelethonSessionString decodes a Telethon-generated session string into a gogram-compatible session object.
 // It returns an error if the decoding fails or the data is malformed.
 func DecodeTelethonSessionString(sessionString string) (*telegram.Session, error) {
-	data, err := base64.URLEncoding.DecodeString(sessionString[1:])
+	info, err := parseTelethon(sessionString)
+	if err != nil {
+		return nil, err
+	}
+	return info.Session(), nil
+}
+
+func parseTelethon(sessionString string) (*Info, error) {
+	if !strings.HasPrefix(sessionString, telethonVersion) {
+		return nil, fmt.Errorf("unsupported telethon session version")
+	}
+
+	encoded := strings.TrimPrefix(sessionString, telethonVersion)
+	for len(encoded)%4 != 0 {
+		encoded += "="
+	}
+
+	data, err := base64.URLEncoding.DecodeString(encoded)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode base64: %v", err)
 	}
 
-	ipLen := 4
-	if len(data) == 352 {
-		ipLen = 16
+	ipLen := net.IPv4len
+	if len(data) == telethonSizeIPv6 {
+		ipLen = net.IPv6len
 	}
 
-	expectedLen := 1 + ipLen + 2 + 256
+	expectedLen := 1 + ipLen + 2 + authKeySize
 	if len(data) != expectedLen {
 		return nil, fmt.Errorf("invalid session string length")
 	}
 
 	offset := 1
 
-	ipData := data[offset : offset+ipLen]
-	ip := net.IP(ipData)
-	ipAddress := ip.String()
+	ip := net.IP(data[offset : offset+ipLen])
 	offset += ipLen
 
 	port := binary.BigEndian.Uint16(data[offset : offset+2])
 	offset += 2
 
-	var authKey [256]byte
-	copy(authKey[:], data[offset:offset+256])
+	authKey := make([]byte, authKeySize)
+	copy(authKey, data[offset:offset+authKeySize])
+
+	info := &Info{
+		Format:   FormatTelethon,
+		DC:       int(data[0]),
+		Hostname: net.JoinHostPort(ip.String(), strconv.Itoa(int(port))),
+		Key:      authKey,
+	}
+	_, info.TestMode = dcFromHostname(info.Hostname)
+	return info, nil
+}
+
+func encodeTelethon(i *Info) (string, error) {
+	dc, err := i.dcFor()
+	if err != nil {
+		return "", err
+	}
+
+	hostname, err := i.hostnameFor()
+	if err != nil {
+		return "", err
+	}
+
+	host, portStr, err := net.SplitHostPort(hostname)
+	if err != nil {
+		return "", fmt.Errorf("invalid address %q: %w", hostname, err)
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return "", fmt.Errorf("invalid IP address %q", host)
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return "", fmt.Errorf("invalid port %q: %w", portStr, err)
+	}
 
-	return &telegram.Session{
-		Hostname: ipAddress + ":" + fmt.Sprint(port),
-		Key:      authKey[:],
-	}, nil
+	packed := make([]byte, 0, 1+len(ip)+2+authKeySize)
+	packed = append(packed, byte(dc))
+	packed = append(packed, ip...)
+	packed = binary.BigEndian.AppendUint16(packed, uint16(port))
+	packed = append(packed, i.Key...)
+	return telethonVersion + base64.URLEncoding.EncodeToString(packed), nil
 }
@@ -1,56 +1,45 @@
 package vc
 
 import (
-	"ashokshau/tgmusic/config"
 	"ashokshau/tgmusic/src/vc/sessions"
 	"ashokshau/tgmusic/src/vc/ubot"
 	"fmt"
 	"log/slog"
+	"strings"
 
 	tg "github.com/amarnathcjd/gogram/telegram"
 )
 
-// StartClient initializes a new userbot client and adds it to the pool of available assistants.
-// It authenticates with Telegram using the provided API ID, API hash, and session string.
-// The session type is determined by the configuration (pyrogram, telethon, or gogram).
+// StartClient initializes a new userbot client and adds it to the pool of available assistants,
+// keyed by the account's Telegram user ID. It authenticates with Telegram using the provided API ID, API hash, and session string.
+// The session format (pyrogram, telethon, or gogram) is detected from the string itself.
 func (c *TelegramCalls) StartClient(apiID int32, apiHash, stringSession string) (*ubot.Context, error) {
 	c.mu.Lock()
-	clientIndex := len(c.uBContext)
+	c.clientSeq++
+	clientName := fmt.Sprintf("client%d", c.clientSeq)
 	c.mu.Unlock()
 
-	clientName := fmt.Sprintf("client%d", clientIndex)
+	info, err := sessions.Parse(stringSession)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the session string for %s: %w", clientName, err)
+	}
 
-	var sess *tg.Session
-	var err error
+	// gogram strings are used as they are so nothing beyond the auth key and address is lost.
+	session := strings.TrimSpace(stringSession)
+	if info.Format != sessions.FormatGogram {
+		session = info.Session().Encode()
+	}
 
 	clientConfig := tg.ClientConfig{
 		AppID:         apiID,
 		AppHash:       apiHash,
 		MemorySession: true,
 		SessionName:   clientName,
+		StringSession: session,
 		FloodHandler:  handleFlood,
 		LogLevel:      tg.InfoLevel,
 	}
 
-	switch config.Conf.SessionType {
-	case "telethon":
-		sess, err = sessions.DecodeTelethonSessionString(stringSession)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode telethon session string for %s: %w", clientName, err)
-		}
-		clientConfig.StringSession = sess.Encode()
-	case "pyrogram":
-		sess, err = sessions.DecodePyrogramSessionString(stringSession)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode pyrogram session string for %s: %w", clientName, err)
-		}
-		clientConfig.StringSession = sess.Encode()
-	case "gogram":
-		clientConfig.StringSession = stringSession
-	default:
-		return nil, fmt.Errorf("unsupported session type: %s", config.Conf.SessionType)
-	}
-
 	mtProto, err := tg.NewClient(clientConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create the MTProto client: %w", err)
@@ -66,27 +55,18 @@ func (c *TelegramCalls) StartClient(apiID int32, apiHash, stringSession string)
 		return nil, fmt.Errorf("the client %s is a bot", clientName)
 	}
 
-	appConfig, err := mtProto.HelpGetAppConfig(0)
+	if c.isAssistant(me.ID) {
+		_ = mtProto.Stop()
+		return nil, fmt.Errorf("the account %d is already running as an assistant", me.ID)
+	}
+
+	frozen, err := isFrozen(mtProto)
 	if err != nil {
 		logger.Warn("[TelegramCalls] failed to fetch app config", "client", clientName, "error", err)
-	} else {
-		isFreeze := false
-		if cfg, ok := appConfig.(*tg.HelpAppConfigObj); ok {
-			if cfgObj, ok := cfg.Config.(*tg.JsonObject); ok {
-				for _, entry := range cfgObj.Value {
-					if entry != nil && entry.Key == "freeze_since_date" {
-						isFreeze = true
-						break
-					}
-				}
-			}
-		}
-
-		if isFreeze {
-			logger.Warn("[TelegramCalls] The client is frozen and cannot be used for voice calls", "client", clientName, "id", me.ID, "username", me.Username)
-			_ = mtProto.Stop()
-			return nil, nil
-		}
+	} else if frozen {
+		logger.Warn("[TelegramCalls] The client is frozen and cannot be used for voice calls", "client", clientName, "id", me.ID, "username", me.Username)
+		_ = mtProto.Stop()
+		return nil, nil
 	}
 
 	call, err := ubot.NewInstance(mtProto)
@@ -97,13 +77,32 @@ func (c *TelegramCalls) StartClient(apiID int32, apiHash, stringSession string)
 
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.uBContext[clientIndex] = call
-	c.clients[clientIndex] = mtProto
+	c.uBContext[me.ID] = call
+	c.clients[me.ID] = mtProto
 
-	logger.Info("[TelegramCalls] Client started", "client", clientName, "id", me.ID, "username", me.Username)
+	logger.Info("[TelegramCalls] Client started", "client", clientName, "id", me.ID, "username", me.Username, "session", info.Format)
 	return call, nil
 }
 
+// isFrozen reports whether Telegram has frozen the account, which makes it unusable for voice calls.
+func isFrozen(client *tg.Client) (bool, error) {
+	appConfig, err := client.HelpGetAppConfig(0)
+	if err != nil {
+		return false, err
+	}
+
+	if cfg, ok := appConfig.(*tg.HelpAppConfigObj); ok {
+		if cfgObj, ok := cfg.Config.(*tg.JsonObject); ok {
+			for _, entry := range cfgObj.Value {
+				if entry != nil && entry.Key == "freeze_since_date" {
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, nil
+}
+
 // StopAllClients gracefully stops all active userbot clients and their associated voice calls.
 func (c *TelegramCalls) StopAllClients() {
 	c.mu.RLock()
@@ -113,8 +112,8 @@ func (c *TelegramCalls) StopAllClients() {
 		call.Close()
 	}
 
-	for idx, client := range c.clients {
-		slog.Info("[TelegramCalls] Stopping the client", "index", idx)
+	for id, client := range c.clients {
+		slog.Info("[TelegramCalls] Stopping the client", "assistant", id)
 		_ = client.Stop()
 	}
 }
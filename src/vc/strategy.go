@@ -0,0 +1,242 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package vc
+
+import (
+	"ashokshau/tgmusic/config"
+	"ashokshau/tgmusic/src/core/cache"
+	"ashokshau/tgmusic/src/core/db"
+	"ashokshau/tgmusic/src/vc/ubot"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	td "github.com/AshokShau/gotdbot"
+	"github.com/amarnathcjd/gogram/telegram"
+)
+
+// AssistantLoad describes how busy a running assistant is.
+type AssistantLoad struct {
+	ID          int64
+	ActiveCalls int   // ActiveCalls is the number of calls the assistant is streaming to right now.
+	Chats       int64 // Chats is the number of chats assigned to the assistant. It is only filled for fewest_chats.
+	Weight      int   // Weight is the relative capacity from ASSISTANT_WEIGHTS.
+}
+
+// AssignStrategy picks an assistant for a chat that has none.
+// Once picked, the assistant sticks to the chat through db.AssignAssistant.
+type AssignStrategy interface {
+	// Pick returns the ID of one of the candidates. candidates is never empty.
+	Pick(candidates []AssistantLoad) int64
+}
+
+// scoreStrategy picks the candidate with the lowest score. Ties are broken at random.
+type scoreStrategy func(a AssistantLoad) float64
+
+func (score scoreStrategy) Pick(candidates []AssistantLoad) int64 {
+	var best []int64
+	bestScore := 0.0
+	for _, a := range candidates {
+		s := score(a)
+		switch {
+		case len(best) == 0 || s < bestScore:
+			best, bestScore = []int64{a.ID}, s
+		case s == bestScore:
+			best = append(best, a.ID)
+		}
+	}
+	return best[randIndex(len(best))]
+}
+
+// assignStrategies maps ASSIGN_STRATEGY values to their implementation.
+var assignStrategies = map[string]AssignStrategy{
+	config.AssignLeastCalls:  scoreStrategy(func(a AssistantLoad) float64 { return float64(a.ActiveCalls) }),
+	config.AssignFewestChats: scoreStrategy(func(a AssistantLoad) float64 { return float64(a.Chats) }),
+	config.AssignWeighted: scoreStrategy(func(a AssistantLoad) float64 {
+		return float64(a.ActiveCalls+1) / float64(a.Weight)
+	}),
+	config.AssignRandom: scoreStrategy(func(AssistantLoad) float64 { return 0 }),
+}
+
+// pickAssistant chooses an assistant among ids using the configured strategy.
+func (c *TelegramCalls) pickAssistant(ids []int64) int64 {
+	name := config.Conf.AssignStrategy()
+	strategy, ok := assignStrategies[name]
+	if !ok {
+		strategy = assignStrategies[config.AssignLeastCalls]
+	}
+
+	loads := c.assistantLoads(ids, name == config.AssignFewestChats)
+	if len(loads) == 0 {
+		return ids[0]
+	}
+	return strategy.Pick(loads)
+}
+
+// assistantLoads collects the load of the given assistants. Chat counts need a database query, so they are optional.
+func (c *TelegramCalls) assistantLoads(ids []int64, withChats bool) []AssistantLoad {
+	var chats map[int64]int64
+	if withChats {
+		var err error
+		if chats, err = db.Instance.CountAssistantChats(); err != nil {
+			logger.Warn("[TelegramCalls] Failed to count assistant chats", "error", err)
+		}
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	loads := make([]AssistantLoad, 0, len(ids))
+	for _, id := range ids {
+		call, ok := c.uBContext[id]
+		if !ok {
+			continue
+		}
+		loads = append(loads, AssistantLoad{
+			ID:          id,
+			ActiveCalls: len(call.Calls()),
+			Chats:       chats[id],
+			Weight:      config.Conf.AssistantWeight(id),
+		})
+	}
+	return loads
+}
+
+// Rebalance spreads the joined group chats evenly over the running assistants, in proportion to ASSISTANT_WEIGHTS.
+// Loads are counted from the groups each assistant has really joined, not from the stored assignments.
+// Draining assistants are left out. Only idle assigned group chats are moved: they are reassigned and the old
+// assistant leaves them, so the new one joins on the next play. It returns the number of chats that were moved.
+func (c *TelegramCalls) Rebalance() (int, error) {
+	ids := c.healthyAssistants(c.assistantIDs())
+	if len(ids) < 2 {
+		return 0, errors.New("at least two healthy assistants are needed to rebalance")
+	}
+
+	busy := make(map[int64]bool)
+	contexts := make(map[int64]*ubot.Context, len(ids))
+	c.mu.RLock()
+	for id, call := range c.uBContext {
+		for chatID := range call.Calls() {
+			busy[chatID] = true
+		}
+		if slices.Contains(ids, id) {
+			contexts[id] = call
+		}
+	}
+	c.mu.RUnlock()
+
+	joined := make(map[int64]map[int64]bool, len(ids))
+	for id, call := range contexts {
+		groups, err := joinedGroups(call)
+		if err != nil {
+			return 0, err
+		}
+		joined[id] = groups
+	}
+
+	var total int
+	var totalWeight int
+	for _, id := range ids {
+		total += len(joined[id])
+		totalWeight += config.Conf.AssistantWeight(id)
+	}
+
+	// surplus is how many chats an assistant is in above its fair share; negative means it has room.
+	surplus := make(map[int64]float64, len(ids))
+	for _, id := range ids {
+		share := float64(total) * float64(config.Conf.AssistantWeight(id)) / float64(totalWeight)
+		surplus[id] = float64(len(joined[id])) - share
+	}
+
+	moved := 0
+	for _, from := range ids {
+		if surplus[from] < 1 {
+			continue
+		}
+
+		chats, err := db.Instance.GetAssistantChats(from)
+		if err != nil {
+			return moved, err
+		}
+
+		for _, chatID := range chats {
+			if surplus[from] < 1 {
+				break
+			}
+			// Moving a chat the assistant has not joined would not lighten its load.
+			if chatID > 0 || !joined[from][chatID] || busy[chatID] || cache.ChatCache.IsActive(chatID) {
+				continue
+			}
+
+			to := ids[0]
+			for _, id := range ids {
+				if surplus[id] < surplus[to] {
+					to = id
+				}
+			}
+			if surplus[to] > -1 {
+				return moved, nil
+			}
+
+			if err := db.Instance.SetAssistant(chatID, to); err != nil {
+				return moved, err
+			}
+
+			err := contexts[from].App.LeaveChannel(chatID)
+			if err != nil && !strings.Contains(err.Error(), "USER_NOT_PARTICIPANT") &&
+				!strings.Contains(err.Error(), "CHANNEL_PRIVATE") {
+				logger.Warn("[TelegramCalls] Moved assistant failed to leave chat", "chat_id", chatID, "assistant", from, "error", err)
+			} else {
+				c.UpdateMembership(chatID, from, &td.ChatMemberStatusLeft{})
+			}
+
+			surplus[from]--
+			surplus[to]++
+			moved++
+		}
+	}
+
+	return moved, nil
+}
+
+// joinedGroups returns the IDs of the groups and channels the assistant is a member of.
+func joinedGroups(call *ubot.Context) (map[int64]bool, error) {
+	dialogs, err := call.App.GetDialogs(&telegram.DialogOptions{
+		Limit:            -1,
+		SleepThresholdMs: 20,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("account %s: failed to get dialogs: %w", call.App.Me().FirstName, err)
+	}
+
+	groups := make(map[int64]bool, len(dialogs))
+	for _, d := range dialogs {
+		if chatID := peerID(d.Peer); chatID < 0 {
+			groups[chatID] = true
+		}
+	}
+	return groups, nil
+}
+
+// randIndex returns a random index below n, or 0 if no random number could be generated.
+func randIndex(n int) int {
+	if n <= 1 {
+		return 0
+	}
+
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		logger.Info("[TelegramCalls] Could not generate a random number", "error", err)
+		return 0
+	}
+	return int(i.Int64())
+}
@@ -27,11 +27,22 @@ var urlRegex = regexp.MustCompile(`^https?://`)
 // TelegramCalls manages the state and operations for voice calls, including userbots and the main bot client.
 type TelegramCalls struct {
 	mu          sync.RWMutex
-	uBContext   map[int]*ubot.Context
-	clients     map[int]*tg.Client
+	uBContext   map[int64]*ubot.Context
+	clients     map[int64]*tg.Client
+	clientSeq   int
 	bot         *td.Client
 	statusCache *cache.Cache[td.ChatMemberStatus]
 	inviteCache *cache.Cache[string]
+	pauseMu     sync.Mutex
+	idleTimers  map[int64]*time.Timer
+	listenCheck map[int64]*time.Timer
+	autoPaused  map[int64]bool
+	mutePaused  map[int64]bool
+	healthMu    sync.Mutex
+	health      map[int64]*AssistantHealth
+	streamMu    sync.Mutex
+	positions   map[int64]streamPosition
+	failingOver map[int64]bool
 }
 
 var (
@@ -43,10 +54,17 @@ var (
 func getCalls() *TelegramCalls {
 	once.Do(func() {
 		instance = &TelegramCalls{
-			uBContext:   make(map[int]*ubot.Context),
-			clients:     make(map[int]*tg.Client),
+			uBContext:   make(map[int64]*ubot.Context),
+			clients:     make(map[int64]*tg.Client),
 			statusCache: cache.NewCache[td.ChatMemberStatus](2 * time.Hour),
 			inviteCache: cache.NewCache[string](2 * time.Hour),
+			idleTimers:  make(map[int64]*time.Timer),
+			listenCheck: make(map[int64]*time.Timer),
+			autoPaused:  make(map[int64]bool),
+			mutePaused:  make(map[int64]bool),
+			health:      make(map[int64]*AssistantHealth),
+			positions:   make(map[int64]streamPosition),
+			failingOver: make(map[int64]bool),
 		}
 	})
 	return instance
@@ -38,6 +38,8 @@ type Context struct {
 	waitConnect           map[int64]chan error
 	self                  *tg.UserObj
 	incomingCallCallbacks []func(client *Context, chatId int64)
+	participantsCallbacks []func(client *Context, chatId int64)
+	callEventCallbacks    []func(client *Context, event types.CallEvent)
 	streamEndCallbacks    []ntgcalls.StreamEndCallback
 	frameCallbacks        []ntgcalls.FrameCallback
 }
@@ -70,6 +72,21 @@ func (ctx *Context) OnIncomingCall(callback func(client *Context, chatId int64))
 	ctx.incomingCallCallbacks = append(ctx.incomingCallCallbacks, callback)
 }
 
+func (ctx *Context) OnParticipantsChange(callback func(client *Context, chatId int64)) {
+	ctx.participantsCallbacks = append(ctx.participantsCallbacks, callback)
+}
+
+func (ctx *Context) OnCallEvent(callback func(client *Context, event types.CallEvent)) {
+	ctx.callEventCallbacks = append(ctx.callEventCallbacks, callback)
+}
+
+func (ctx *Context) emitCallEvent(eventType types.CallEventType, chatId int64) {
+	event := types.CallEvent{Type: eventType, ChatId: chatId}
+	for _, callback := range ctx.callEventCallbacks {
+		go callback(ctx, event)
+	}
+}
+
 func (ctx *Context) OnStreamEnd(callback ntgcalls.StreamEndCallback) {
 	ctx.streamEndCallbacks = append(ctx.streamEndCallbacks, callback)
 }
@@ -0,0 +1,32 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package ubot
+
+import (
+	tg "github.com/amarnathcjd/gogram/telegram"
+)
+
+func (ctx *Context) DiscardCall(userId int64) error {
+	ctx.inputCallsMu.Lock()
+	inputCall := ctx.inputCalls[userId]
+	delete(ctx.inputCalls, userId)
+	ctx.inputCallsMu.Unlock()
+	ctx.p2pConfigsMu.Lock()
+	delete(ctx.p2pConfigs, userId)
+	ctx.p2pConfigsMu.Unlock()
+	_ = ctx.binding.Stop(userId)
+	if inputCall == nil {
+		return nil
+	}
+	_, err := ctx.App.PhoneDiscardCall(&tg.PhoneDiscardCallParams{
+		Peer:   inputCall,
+		Reason: &tg.PhoneCallDiscardReasonHangup{},
+	})
+	return err
+}
@@ -101,6 +101,7 @@ func (ctx *Context) handleUpdates() {
 			delete(ctx.inputCalls, userId)
 			ctx.inputCallsMu.Unlock()
 			_ = ctx.binding.Stop(userId)
+			ctx.emitCallEvent(types.CallDiscarded, userId)
 		case *tg.PhoneCallRequested:
 			ctx.p2pConfigsMu.RLock()
 			p2pConfig := ctx.p2pConfigs[userId]
@@ -134,6 +135,10 @@ func (ctx *Context) handleUpdates() {
 			for _, participant := range participantsUpdate.Participants {
 				participantId := getParticipantId(participant.Peer)
 				if participant.Left {
+					if participantId == ctx.self.ID && ctx.binding.Calls()[chatId] != nil {
+						_ = ctx.binding.Stop(chatId)
+						ctx.emitCallEvent(types.AssistantRemoved, chatId)
+					}
 					delete(ctx.callParticipants[chatId].CallParticipants, participantId)
 					ctx.callSourcesMu.Lock()
 					if ctx.callSources != nil && ctx.callSources[chatId] != nil {
@@ -189,6 +194,10 @@ func (ctx *Context) handleUpdates() {
 			ctx.callParticipants[chatId].LastMtprotoUpdate = time.Now()
 			ctx.participantsMutex.Unlock()
 
+			for _, callback := range ctx.participantsCallbacks {
+				go callback(ctx, chatId)
+			}
+
 			for _, participant := range participantsUpdate.Participants {
 				participantId := getParticipantId(participant.Peer)
 				if participantId == ctx.self.ID {
@@ -207,6 +216,7 @@ func (ctx *Context) handleUpdates() {
 					} else if !participant.CanSelfUnmute {
 						if !slices.Contains(ctx.mutedByAdmin, chatId) {
 							ctx.mutedByAdmin = append(ctx.mutedByAdmin, chatId)
+							ctx.emitCallEvent(types.MutedByAdmin, chatId)
 						}
 					} else if slices.Contains(ctx.mutedByAdmin, chatId) {
 						state, err := ctx.binding.GetState(chatId)
@@ -218,6 +228,7 @@ func (ctx *Context) handleUpdates() {
 							panic(err)
 						}
 						ctx.mutedByAdmin = stdRemove(ctx.mutedByAdmin, chatId)
+						ctx.emitCallEvent(types.UnmutedByAdmin, chatId)
 					}
 				}
 			}
@@ -280,7 +291,11 @@ func (ctx *Context) handleUpdates() {
 				ctx.inputGroupCallsMutex.Lock()
 				delete(ctx.inputGroupCalls, chatID)
 				ctx.inputGroupCallsMutex.Unlock()
+				ctx.pendingConnectionsMu.Lock()
+				delete(ctx.pendingConnections, chatID)
+				ctx.pendingConnectionsMu.Unlock()
 				_ = ctx.binding.Stop(chatID)
+				ctx.emitCallEvent(types.CallDiscarded, chatID)
 				return nil
 			default:
 				ctx.App.Log.Warnf("Received UpdateGroupCall with unknown type:%v", groupCallRaw)
@@ -360,12 +375,20 @@ func (ctx *Context) handleUpdates() {
 			switch state.State {
 			case ntgcalls.Connected:
 				waitConnect <- nil
+				if state.Kind == ntgcalls.NormalConnection {
+					ctx.emitCallEvent(types.CallStarted, chatId)
+				}
 			case ntgcalls.Closed, ntgcalls.Failed:
 				waitConnect <- fmt.Errorf("connection failed")
 			case ntgcalls.Timeout:
 				waitConnect <- fmt.Errorf("connection timeout")
 			default:
 			}
+			return
+		}
+
+		if state.Kind == ntgcalls.NormalConnection && (state.State == ntgcalls.Failed || state.State == ntgcalls.Timeout) {
+			ctx.emitCallEvent(types.ConnectionLost, chatId)
 		}
 	})
 
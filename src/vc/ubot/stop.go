@@ -13,6 +13,9 @@ func (ctx *Context) Stop(chatId int64) error {
 	ctx.callSourcesMu.Lock()
 	delete(ctx.callSources, chatId)
 	ctx.callSourcesMu.Unlock()
+	if chatId >= 0 {
+		return ctx.DiscardCall(chatId)
+	}
 	err := ctx.binding.Stop(chatId)
 	if err != nil {
 		return err
@@ -0,0 +1,44 @@
+/*
+ * TgMusicBot - Telegram Music Bot
+ *  Copyright (c) 2025-2026 Ashok Shau
+ *
+ *  Licensed under GNU GPL v3
+ *  See https://github.com/AshokShau/TgMusicBot
+ */
+
+package types
+
+type CallEventType int
+
+const (
+	CallStarted CallEventType = iota
+	CallDiscarded
+	AssistantRemoved
+	MutedByAdmin
+	UnmutedByAdmin
+	ConnectionLost
+)
+
+func (t CallEventType) String() string {
+	switch t {
+	case CallStarted:
+		return "call_started"
+	case CallDiscarded:
+		return "call_discarded"
+	case AssistantRemoved:
+		return "assistant_removed"
+	case MutedByAdmin:
+		return "muted_by_admin"
+	case UnmutedByAdmin:
+		return "unmuted_by_admin"
+	case ConnectionLost:
+		return "connection_lost"
+	default:
+		return "unknown"
+	}
+}
+
+type CallEvent struct {
+	Type   CallEventType
+	ChatId int64
+}
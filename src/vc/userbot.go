@@ -24,21 +24,22 @@ import (
 )
 
 // joinAssistant ensures the assistant is a member of the specified chat.
-func (c *TelegramCalls) joinAssistant(chatID int64, call *ubot.Context, index int) error {
-	status, err := c.checkUserStats(chatID, call, index)
+func (c *TelegramCalls) joinAssistant(chatID int64, call *ubot.Context) error {
+	assistantID := call.App.Me().ID
+	status, err := c.checkUserStats(chatID, call)
 	if err != nil {
-		return fmt.Errorf("joinAssistant (client-%d): check user status: %w", index, err)
+		return fmt.Errorf("joinAssistant (assistant %d): check user status: %w", assistantID, err)
 	}
 
-	logger.Info("chat member status", "chat_id", chatID, "status", status, "index", index)
+	logger.Info("chat member status", "chat_id", chatID, "status", status, "assistant", assistantID)
 
 	switch status.(type) {
 	case *td.ChatMemberStatusMember, td.ChatMemberStatusCreator, td.ChatMemberStatusAdministrator, td.ChatMemberStatusMember:
 		return nil
 
 	case *td.ChatMemberStatusLeft, td.ChatMemberStatusLeft:
-		logger.Info("assistant is not in chat, joining", "chat_id", chatID, "index", index)
-		return c.joinUb(chatID, call, index)
+		logger.Info("assistant is not in chat, joining", "chat_id", chatID, "assistant", assistantID)
+		return c.joinUb(chatID, call)
 
 	case *td.ChatMemberStatusBanned, *td.ChatMemberStatusRestricted,
 		td.ChatMemberStatusBanned, td.ChatMemberStatusRestricted:
@@ -51,25 +52,25 @@ func (c *TelegramCalls) joinAssistant(chatID int64, call *ubot.Context, index in
 		isMuted := isMutedPtr || isMutedVal
 
 		logger.Info("assistant is banned or restricted, attempting recovery",
-			"chat_id", chatID, "banned", isBanned, "muted", isMuted, "index", index)
+			"chat_id", chatID, "banned", isBanned, "muted", isMuted, "assistant", assistantID)
 
-		return c.recoverBannedAssistant(chatID, call, index, isBanned)
+		return c.recoverBannedAssistant(chatID, call, isBanned)
 
 	default:
-		logger.Warn("unknown assistant status, attempting to join", "status", status, "index", index)
-		return c.joinUb(chatID, call, index)
+		logger.Warn("unknown assistant status, attempting to join", "status", status, "assistant", assistantID)
+		return c.joinUb(chatID, call)
 	}
 }
 
 // recoverBannedAssistant attempts to unban or unmute the assistant using bot admin rights.
-func (c *TelegramCalls) recoverBannedAssistant(chatID int64, call *ubot.Context, index int, isBanned bool) error {
+func (c *TelegramCalls) recoverBannedAssistant(chatID int64, call *ubot.Context, isBanned bool) error {
 	ubID := call.App.Me().ID
 	botStatus, err := cache.GetUserAdmin(c.bot, chatID, c.bot.Me.Id, false)
 	if err != nil {
 		if strings.Contains(err.Error(), "is not an admin in chat") {
 			return fmt.Errorf(
-				"client %d: bot is not an admin, cannot unban my assistant (<code>%d</code>)",
-				index, ubID,
+				"bot is not an admin, cannot unban my assistant (<code>%d</code>)",
+				ubID,
 			)
 		}
 		return fmt.Errorf("failed to check bot admin status: %w", err)
@@ -78,8 +79,8 @@ func (c *TelegramCalls) recoverBannedAssistant(chatID int64, call *ubot.Context,
 	admin, ok := botStatus.Status.(*td.ChatMemberStatusAdministrator)
 	if !ok || admin.Rights == nil || !admin.Rights.CanRestrictMembers {
 		return fmt.Errorf(
-			"assistant (client %d, <code>%d</code>): bot lacks CanRestrictMembers",
-			index, ubID,
+			"assistant (<code>%d</code>): bot lacks CanRestrictMembers",
+			ubID,
 		)
 	}
 
@@ -89,10 +90,10 @@ func (c *TelegramCalls) recoverBannedAssistant(chatID int64, call *ubot.Context,
 			td.MessageSenderUser{UserId: ubID},
 			&td.ChatMemberStatusMember{},
 		); err != nil {
-			logger.Warn("failed to unban assistant", "ub_id", ubID, "error", err, "index", index)
+			logger.Warn("failed to unban assistant", "ub_id", ubID, "error", err)
 		}
 
-		return c.joinUb(chatID, call, index)
+		return c.joinUb(chatID, call)
 	}
 
 	// isMuted: restricted but not banned — nothing actionable right now.
@@ -103,24 +104,14 @@ func (c *TelegramCalls) recoverBannedAssistant(chatID int64, call *ubot.Context,
 // JoinAssistant attempts to join the assigned assistant to the chat.
 // If it fails, it returns an error and removes the assistant from the database.
 func (c *TelegramCalls) JoinAssistant(chatID int64) (*ubot.Context, error) {
-	index, err := c.getClientIndex(chatID)
+	call, assistantID, err := c.GetGroupAssistant(chatID)
 	if err != nil {
 		return nil, err
 	}
 
-	c.mu.RLock()
-	call, ok := c.uBContext[index]
-	c.mu.RUnlock()
-
-	if !ok {
-		return nil, fmt.Errorf("client %d not found in context", index)
-	}
-
-	assistantID := call.App.Me().ID
-
-	if err = c.joinAssistant(chatID, call, index); err != nil {
+	if err = c.joinAssistant(chatID, call); err != nil {
 		slog.Info("assistant failed to join chat",
-			"chat_id", chatID, "assistant_id", assistantID, "error", err, "index", index)
+			"chat_id", chatID, "assistant_id", assistantID, "error", err)
 
 		cacheKey := fmt.Sprintf("%d:%d", chatID, assistantID)
 		c.statusCache.Delete(cacheKey)
@@ -129,29 +120,29 @@ func (c *TelegramCalls) JoinAssistant(chatID int64) (*ubot.Context, error) {
 		return nil, err
 	}
 
-	if err := db.Instance.SetAssistant(chatID, index); err != nil {
-		slog.Warn("failed to set assistant in database", "chat_id", chatID, "index", index, "error", err)
+	if err := db.Instance.SetAssistant(chatID, assistantID); err != nil {
+		slog.Warn("failed to set assistant in database", "chat_id", chatID, "assistant_id", assistantID, "error", err)
 	}
 
 	return call, nil
 }
 
-// clientIndexFor returns the 0-based index for the given call, or -1 if not found.
+// assistantIDFor returns the assistant ID for the given call, or 0 if not found.
 // Caller must not hold mu.
-func (c *TelegramCalls) clientIndexFor(call *ubot.Context) int {
+func (c *TelegramCalls) assistantIDFor(call *ubot.Context) int64 {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	for idx, ctx := range c.uBContext {
+	for id, ctx := range c.uBContext {
 		if ctx == call {
-			return idx
+			return id
 		}
 	}
-	return -1
+	return 0
 }
 
 // checkUserStats returns the assistant's membership status in chatID.
 // Results are cached; a cache miss triggers a live Telegram API call.
-func (c *TelegramCalls) checkUserStats(chatID int64, call *ubot.Context, index int) (td.ChatMemberStatus, error) {
+func (c *TelegramCalls) checkUserStats(chatID int64, call *ubot.Context) (td.ChatMemberStatus, error) {
 	userID := call.App.Me().ID
 	cacheKey := fmt.Sprintf("%d:%d", chatID, userID)
 	if cached, ok := c.statusCache.Get(cacheKey); ok {
@@ -166,7 +157,7 @@ func (c *TelegramCalls) checkUserStats(chatID int64, call *ubot.Context, index i
 			return &td.ChatMemberStatusLeft{}, nil
 		}
 
-		return nil, fmt.Errorf("GetChatMember (client %d) chat=%d user=%d: %w", index, chatID, userID, err)
+		return nil, fmt.Errorf("GetChatMember chat=%d user=%d: %w", chatID, userID, err)
 	}
 
 	c.UpdateMembership(chatID, userID, member.Status)
@@ -174,7 +165,7 @@ func (c *TelegramCalls) checkUserStats(chatID int64, call *ubot.Context, index i
 }
 
 // joinUb joins the assistant to chatID via an ChatInviteLink link.
-func (c *TelegramCalls) joinUb(chatID int64, call *ubot.Context, index int) error {
+func (c *TelegramCalls) joinUb(chatID int64, call *ubot.Context) error {
 	ub := call.App
 	cacheKey := strconv.FormatInt(chatID, 10)
 
@@ -183,11 +174,11 @@ func (c *TelegramCalls) joinUb(chatID int64, call *ubot.Context, index int) erro
 		return err
 	}
 
-	logger.Info("joining via invite link", "chat_id", chatID, "index", index)
+	logger.Info("joining via invite link", "chat_id", chatID, "assistant", ub.Me().ID)
 
 	_, err = ub.JoinChannel(link)
 	if err != nil {
-		return c.handleJoinError(chatID, ub.Me().ID, index, err)
+		return c.handleJoinError(chatID, ub.Me().ID, err)
 	}
 
 	c.UpdateMembership(chatID, ub.Me().ID, &td.ChatMemberStatusMember{})
@@ -220,7 +211,7 @@ func (c *TelegramCalls) resolveInviteLink(chatID int64, cacheKey string) (string
 }
 
 // handleJoinError maps JoinChannel error strings to actionable responses.
-func (c *TelegramCalls) handleJoinError(chatID, userID int64, index int, err error) error {
+func (c *TelegramCalls) handleJoinError(chatID, userID int64, err error) error {
 	errMsg := err.Error()
 	switch {
 	case strings.Contains(errMsg, "INVITE_REQUEST_SENT"):
@@ -229,8 +220,8 @@ func (c *TelegramCalls) handleJoinError(chatID, userID int64, index int, err err
 			chatID, userID,
 			&td.ProcessChatJoinRequestOpts{Approve: true},
 		); approveErr != nil {
-			slog.Warn("failed to approve join request", "error", approveErr, "index", index)
-			return fmt.Errorf("client %d: assistant (<code>%d</code>) has a pending join request: %v", index, userID, approveErr)
+			slog.Warn("failed to approve join request", "error", approveErr, "assistant", userID)
+			return fmt.Errorf("assistant (<code>%d</code>) has a pending join request: %v", userID, approveErr)
 		}
 		return nil
 
@@ -241,14 +232,14 @@ func (c *TelegramCalls) handleJoinError(chatID, userID int64, index int, err err
 	case strings.Contains(errMsg, "INVITE_HASH_EXPIRED"):
 		c.inviteCache.Delete(strconv.FormatInt(chatID, 10))
 		c.UpdateMembership(chatID, userID, &td.ChatMemberStatusBanned{})
-		return fmt.Errorf("client %d: assistant (<code>%d</code>) invite link expired or assistant is banned", index, userID)
+		return fmt.Errorf("assistant (<code>%d</code>) invite link expired or assistant is banned", userID)
 
 	case strings.Contains(errMsg, "CHANNEL_PRIVATE"):
 		c.UpdateMembership(chatID, userID, &td.ChatMemberStatusLeft{})
 		c.inviteCache.Delete(strconv.FormatInt(chatID, 10))
-		return fmt.Errorf("client %d: assistant (<code>%d</code>) is banned from this group", index, userID)
+		return fmt.Errorf("assistant (<code>%d</code>) is banned from this group", userID)
 	}
 
-	logger.Warn("unhandled JoinChannel error", "error", err, "index", index)
-	return fmt.Errorf("(client%d, <code>%d</code>): assistant failed to join: %w", index, userID, err)
+	logger.Warn("unhandled JoinChannel error", "error", err, "assistant", userID)
+	return fmt.Errorf("assistant (<code>%d</code>) failed to join: %w", userID, err)
 }